@@ -0,0 +1,48 @@
+# Analyze an existing group allocation
+data "doit_allocation_analysis" "teams" {
+  allocation_id = doit_allocation.teams.id
+}
+
+# Analyze candidate rules before applying them
+data "doit_allocation_analysis" "candidate" {
+  lookback_days = 90
+  rules = [
+    {
+      action = "create"
+      name   = "Shared services"
+      components = [
+        {
+          type   = "fixed"
+          key    = "project_id"
+          mode   = "is"
+          values = ["shared-services"]
+        }
+      ]
+    },
+    {
+      action = "create"
+      name   = "Production"
+      components = [
+        {
+          type   = "label"
+          key    = "env"
+          mode   = "is"
+          values = ["prod"]
+        }
+      ]
+    }
+  ]
+}
+
+output "unallocated_share" {
+  value = data.doit_allocation_analysis.teams.unallocated_costs_share
+}
+
+# Rules that allocate nothing, either because no costs match them or
+# because earlier rules claim all of their costs
+output "dead_rules" {
+  value = [
+    for r in data.doit_allocation_analysis.teams.rule_results : r.name
+    if r.never_matches || r.shadowed
+  ]
+}
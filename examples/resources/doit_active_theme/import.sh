@@ -1 +1,4 @@
 terraform import doit_active_theme.this active-theme
+
+# Or import into a specific customer context (DoiT employees only)
+terraform import doit_active_theme.this "[customer_context]/active-theme"
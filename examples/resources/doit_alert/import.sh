@@ -1 +1,7 @@
 terraform import doit_alert.alert [id]
+
+# Or import by exact name
+terraform import doit_alert.alert "name:[name]"
+
+# Or import into a specific customer context (DoiT employees only)
+terraform import doit_alert.alert "[customer_context]/[id]"
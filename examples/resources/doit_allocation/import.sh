@@ -1 +1,7 @@
 terraform import doit_allocation.allocation [id]
+
+# Or import by exact name
+terraform import doit_allocation.allocation "name:[name]"
+
+# Or import into a specific customer context (DoiT employees only)
+terraform import doit_allocation.allocation "[customer_context]/[id]"
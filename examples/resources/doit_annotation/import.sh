@@ -1 +1,4 @@
 terraform import doit_annotation.annotation [id]
+
+# Or import into a specific customer context (DoiT employees only)
+terraform import doit_annotation.annotation "[customer_context]/[id]"
@@ -1,2 +1,5 @@
 # Find the asset ID using the doit_assets data source, then import:
 terraform import doit_asset.licenses <asset-id>
+
+# Or import into a specific customer context (DoiT employees only)
+terraform import doit_asset.licenses "[customer_context]/<asset-id>"
@@ -1 +1,7 @@
 terraform import doit_budget.budget [id]
+
+# Or import by exact name
+terraform import doit_budget.budget "name:[name]"
+
+# Or import into a specific customer context (DoiT employees only)
+terraform import doit_budget.budget "[customer_context]/[id]"
@@ -1 +1,4 @@
 terraform import doit_cloudconnect_aws_account.basic 123456789012
+
+# Or import into a specific customer context (DoiT employees only)
+terraform import doit_cloudconnect_aws_account.basic "[customer_context]/123456789012"
@@ -1 +1,4 @@
 terraform import doit_custom_theme.example [id]
+
+# Or import into a specific customer context (DoiT employees only)
+terraform import doit_custom_theme.example "[customer_context]/[id]"
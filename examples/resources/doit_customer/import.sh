@@ -1,2 +1,5 @@
 # Import the customer settings for the current account:
 terraform import doit_customer.main <customer-id>
+
+# Or import into a specific customer context (DoiT employees only)
+terraform import doit_customer.main "[customer_context]/<customer-id>"
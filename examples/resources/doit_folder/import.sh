@@ -1 +1,7 @@
 terraform import doit_folder.analytics [id]
+
+# Or import by exact name
+terraform import doit_folder.analytics "name:[name]"
+
+# Or import into a specific customer context (DoiT employees only)
+terraform import doit_folder.analytics "[customer_context]/[id]"
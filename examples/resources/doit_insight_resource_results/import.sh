@@ -1,2 +1,5 @@
 # Import using the format: sourceID/insightKey
 terraform import doit_insight_resource_results.example public-api/my-insight-key
+
+# Or import into a specific customer context (DoiT employees only)
+terraform import doit_insight_resource_results.example "[customer_context]/public-api/my-insight-key"
@@ -1 +1,4 @@
 terraform import doit_label.label [id]
+
+# Or import into a specific customer context (DoiT employees only)
+terraform import doit_label.label "[customer_context]/[id]"
@@ -1 +1,7 @@
 terraform import doit_report.report [id]
+
+# Or import by exact name
+terraform import doit_report.report "name:[name]"
+
+# Or import into a specific customer context (DoiT employees only)
+terraform import doit_report.report "[customer_context]/[id]"
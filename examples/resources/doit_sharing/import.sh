@@ -1,2 +1,5 @@
 # Import using the composite ID: {resourceType}/{resourceId}
 terraform import doit_sharing.example reports/abc123def456
+
+# Or import into a specific customer context (DoiT employees only)
+terraform import doit_sharing.example "[customer_context]/reports/abc123def456"
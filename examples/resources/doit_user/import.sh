@@ -1 +1,4 @@
 terraform import doit_user.example user@example.com
+
+# Or import into a specific customer context (DoiT employees only)
+terraform import doit_user.example "[customer_context]/user@example.com"
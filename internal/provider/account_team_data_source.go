@@ -25,7 +25,8 @@ type accountTeamDataSource struct {
 
 type accountTeamDataSourceModel struct {
 	datasource_account_team.AccountTeamModel
-	Timeouts timeouts.Value `tfsdk:"timeouts"`
+	CustomerContext types.String   `tfsdk:"customer_context"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (d *accountTeamDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -35,6 +36,7 @@ func (d *accountTeamDataSource) Metadata(ctx context.Context, req datasource.Met
 func (d *accountTeamDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	s := datasource_account_team.AccountTeamDataSourceSchema(ctx)
 
+	s.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx)
 
 	resp.Schema = s
@@ -71,6 +73,7 @@ func (d *accountTeamDataSource) Read(ctx context.Context, req datasource.ReadReq
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	apiResp, err := d.client.ListAccountTeamWithResponse(ctx)
@@ -18,9 +18,7 @@ const syntheticActiveThemeID = "active-theme"
 func (r *activeThemeResource) populateState(ctx context.Context, state *activeThemeResourceModel) diag.Diagnostics {
 	themeResp, err := r.client.GetActiveThemeWithResponse(ctx)
 	if err != nil {
-		return diag.Diagnostics{
-			diag.NewErrorDiagnostic("Error Reading Active Theme", "Could not read active theme: "+err.Error()),
-		}
+		return apiErrorDiagnostics("Error Reading Active Theme", "Could not read active theme", err)
 	}
 
 	if themeResp.StatusCode() == 404 {
@@ -24,7 +24,8 @@ type (
 	}
 	activeThemeDataSourceModel struct {
 		datasource_active_theme.ActiveThemeModel
-		Timeouts timeouts.Value `tfsdk:"timeouts"`
+		CustomerContext types.String   `tfsdk:"customer_context"`
+		Timeouts        timeouts.Value `tfsdk:"timeouts"`
 	}
 )
 
@@ -38,6 +39,7 @@ func (d *activeThemeDataSource) Schema(ctx context.Context, _ datasource.SchemaR
 	s.Description = "Retrieve the active color theme for the current account."
 	s.MarkdownDescription = s.Description
 
+	s.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx)
 
 	resp.Schema = s
@@ -74,6 +76,7 @@ func (d *activeThemeDataSource) Read(ctx context.Context, req datasource.ReadReq
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	// No inputs to check for unknown — this is a singleton endpoint with no parameters.
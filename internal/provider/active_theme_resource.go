@@ -17,12 +17,14 @@ import (
 
 type (
 	activeThemeResource struct {
-		client *models.ClientWithResponses
+		client   *models.ClientWithResponses
+		readOnly bool
 	}
 	activeThemeResourceModel struct {
 		resource_active_theme.ActiveThemeModel
-		Id       types.String   `tfsdk:"id"`
-		Timeouts timeouts.Value `tfsdk:"timeouts"`
+		Id              types.String   `tfsdk:"id"`
+		CustomerContext types.String   `tfsdk:"customer_context"`
+		Timeouts        timeouts.Value `tfsdk:"timeouts"`
 	}
 )
 
@@ -44,16 +46,17 @@ func (r *activeThemeResource) Configure(_ context.Context, req resource.Configur
 		return
 	}
 
-	client, ok := req.ProviderData.(*models.ClientWithResponses)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *models.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.readOnly = data.readOnly
 }
 
 func (r *activeThemeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -61,6 +64,10 @@ func (r *activeThemeResource) Metadata(_ context.Context, req resource.MetadataR
 }
 
 func (r *activeThemeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, id, diags := importCustomerContext(ctx, req, resp, 1)
+	resp.Diagnostics.Append(diags...)
+	req.ID = id
+
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
@@ -82,6 +89,7 @@ func (r *activeThemeResource) Schema(ctx context.Context, _ resource.SchemaReque
 		"Destroying this resource resets the theme to the built-in default."
 	s.MarkdownDescription = s.Description
 
+	s.Attributes["customer_context"] = customerContextResourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx, timeouts.Opts{
 		Create: true,
 		Read:   true,
@@ -93,6 +101,11 @@ func (r *activeThemeResource) Schema(ctx context.Context, _ resource.SchemaReque
 }
 
 func (r *activeThemeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_active_theme", "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan activeThemeResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -106,16 +119,14 @@ func (r *activeThemeResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	ctx = withCustomerContext(ctx, plan.CustomerContext)
 	defer cancel()
 
 	apiReq := plan.toUpdateRequest()
 
 	themeResp, err := r.client.SetActiveThemeWithResponse(ctx, apiReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Creating Active Theme",
-			"Could not set active theme, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Creating Active Theme", "Could not set active theme", err)...)
 		return
 	}
 
@@ -154,6 +165,7 @@ func (r *activeThemeResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
 
 	resp.Diagnostics.Append(r.populateState(ctx, &state)...)
@@ -170,6 +182,11 @@ func (r *activeThemeResource) Read(ctx context.Context, req resource.ReadRequest
 }
 
 func (r *activeThemeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_active_theme", "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan activeThemeResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -183,16 +200,14 @@ func (r *activeThemeResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	ctx = withCustomerContext(ctx, plan.CustomerContext)
 	defer cancel()
 
 	apiReq := plan.toUpdateRequest()
 
 	updateResp, err := r.client.SetActiveThemeWithResponse(ctx, apiReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Updating Active Theme",
-			"Could not update active theme, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Updating Active Theme", "Could not update active theme", err)...)
 		return
 	}
 
@@ -218,6 +233,11 @@ func (r *activeThemeResource) Update(ctx context.Context, req resource.UpdateReq
 }
 
 func (r *activeThemeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_active_theme", "delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var state activeThemeResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -231,6 +251,7 @@ func (r *activeThemeResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
 
 	// Reset to built-in default — there is no DELETE endpoint.
@@ -240,10 +261,7 @@ func (r *activeThemeResource) Delete(ctx context.Context, req resource.DeleteReq
 
 	deleteResp, err := r.client.SetActiveThemeWithResponse(ctx, resetReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Deleting Active Theme",
-			"Could not reset active theme to default, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Deleting Active Theme", "Could not reset active theme to default", err)...)
 		return
 	}
 
@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/doitintl/terraform-provider-doit/internal/provider/models"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// adoptExistingAttribute returns the adopt_existing attribute of resources
+// whose Create can take over an object of the same name, for tenants where
+// the object was already created in the DoiT console. kind is the
+// human-readable object type ("folder", "label", ...), and match describes
+// which objects are candidates, e.g. "the same name".
+func adoptExistingAttribute(kind, match string) schema.BoolAttribute {
+	description := fmt.Sprintf("Whether creating this resource takes over an existing %[1]s with %[2]s "+
+		"instead of creating a new one. The adopted %[1]s is updated to match the configuration, and is deleted "+
+		"when the resource is destroyed. Creation fails if more than one %[1]s matches. Has no effect "+
+		"after creation. Defaults to false.", kind, match)
+	return schema.BoolAttribute{
+		Optional:            true,
+		Description:         description,
+		MarkdownDescription: description,
+	}
+}
+
+// findAdoptable returns the ID of the object of kind named name, for Create
+// with adopt_existing set, or "" when there is none and Create should create
+// it. Several objects with the name are an error, as is a failed lookup.
+func findAdoptable(ctx context.Context, kind, name string, lookup nameLookupFunc) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	ids, err := lookup(ctx, name)
+	if err != nil {
+		diags.Append(apiErrorDiagnostics("Error Adopting Existing Object", fmt.Sprintf("Could not look up %s named %q", kind, name), err)...)
+		return "", diags
+	}
+
+	switch len(ids) {
+	case 0:
+		tflog.Debug(ctx, "No existing object to adopt, creating it", map[string]any{"kind": kind, "name": name})
+		return "", diags
+	case 1:
+		tflog.Info(ctx, "Adopting existing object", map[string]any{"kind": kind, "name": name, "id": ids[0]})
+		return ids[0], diags
+	default:
+		diags.AddAttributeError(
+			path.Root("adopt_existing"),
+			"Existing Object Is Ambiguous",
+			fmt.Sprintf("%d objects of type %s are named %q (IDs: %s), so none can be adopted. "+
+				"Rename or delete the duplicates, or import one of them by ID instead.", len(ids), kind, name, strings.Join(ids, ", ")),
+		)
+		return "", diags
+	}
+}
+
+// folderLookup adapts GET /analytics/v1/folders to a nameLookupFunc that only
+// matches folders directly under parentID, so adopting a folder never moves
+// one of the same name from elsewhere in the tree. A null or empty parentID
+// is the root folder, as on create.
+func folderLookup(client *models.ClientWithResponses, parentID types.String) nameLookupFunc {
+	parent := parentID.ValueString()
+	if parent == "" {
+		parent = rootFolderID
+	}
+	return func(ctx context.Context, name string) ([]string, error) {
+		folders, err := listFolderModels(ctx, client)
+		if err != nil {
+			return nil, err
+		}
+		var ids []string
+		for _, folder := range folders {
+			folderParent := rootFolderID
+			if folder.ParentFolderId != nil && *folder.ParentFolderId != "" {
+				folderParent = *folder.ParentFolderId
+			}
+			if folder.Id != nil && folder.Name != nil && *folder.Name == name && folderParent == parent {
+				ids = append(ids, *folder.Id)
+			}
+		}
+		return ids, nil
+	}
+}
+
+// datahubDatasetLookup adapts GET /datahub/v1/datasets/{name} to a
+// nameLookupFunc: datasets are identified by their name.
+func datahubDatasetLookup(client *models.ClientWithResponses) nameLookupFunc {
+	return func(ctx context.Context, name string) ([]string, error) {
+		apiResp, err := client.GetDatahubDatasetWithResponse(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		switch apiResp.StatusCode() {
+		case http.StatusOK:
+			return []string{name}, nil
+		case http.StatusNotFound:
+			return nil, nil
+		default:
+			return nil, listStatusError(apiResp.StatusCode(), apiResp.Body)
+		}
+	}
+}
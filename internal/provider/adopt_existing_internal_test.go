@@ -0,0 +1,193 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestFindAdoptable(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	lookup := func(ids []string, err error) nameLookupFunc {
+		return func(context.Context, string) ([]string, error) { return ids, err }
+	}
+	tests := []struct {
+		name    string
+		lookup  nameLookupFunc
+		wantID  string
+		wantErr string
+	}{
+		{"none", lookup(nil, nil), "", ""},
+		{"one", lookup([]string{"f1"}, nil), "f1", ""},
+		{"ambiguous", lookup([]string{"f1", "f2"}, nil), "", "Existing Object Is Ambiguous"},
+		{"lookup failed", lookup(nil, errors.New("boom")), "", "Error Adopting Existing Object"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			id, diags := findAdoptable(ctx, "folder", "team", tt.lookup)
+			if id != tt.wantID {
+				t.Errorf("id = %q, want %q", id, tt.wantID)
+			}
+			if tt.wantErr == "" {
+				if diags.HasError() {
+					t.Errorf("unexpected diagnostics: %v", diags)
+				}
+				return
+			}
+			if diags.ErrorsCount() != 1 || diags.Errors()[0].Summary() != tt.wantErr {
+				t.Errorf("diagnostics = %v, want %q", diags, tt.wantErr)
+			}
+		})
+	}
+
+	_, diags := findAdoptable(ctx, "folder", "team", lookup([]string{"f1", "f2"}, nil))
+	if d, ok := diags[0].(diag.DiagnosticWithPath); !ok || !d.Path().Equal(path.Root("adopt_existing")) {
+		t.Errorf("ambiguity reported at %v, want adopt_existing", diags[0])
+	}
+}
+
+// TestLabelResource_Create_AdoptExisting verifies that Create with
+// adopt_existing updates the label of the same name instead of creating one.
+func TestLabelResource_Create_AdoptExisting(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	var methods []string
+	r := &labelResource{client: newImportTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		methods = append(methods, req.Method+" "+req.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case req.Method == http.MethodGet && req.URL.Path == "/analytics/v1/labels":
+			_, _ = io.WriteString(w, `{"labels": [{"id": "l1", "name": "team", "color": "blue"}, {"id": "l2", "name": "other", "color": "blue"}]}`)
+		case req.Method == http.MethodPatch && req.URL.Path == "/analytics/v1/labels/l1":
+			_, _ = io.WriteString(w, `{"id": "l1", "name": "team", "color": "purple", "type": "custom"}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	sch := schemaResp.Schema
+	nullRaw := tftypes.NewValue(sch.Type().TerraformType(ctx), nil)
+
+	plan := tfsdk.Plan{Raw: nullRaw, Schema: sch}
+	diags := plan.SetAttribute(ctx, path.Root("name"), types.StringValue("team"))
+	diags.Append(plan.SetAttribute(ctx, path.Root("color"), types.StringValue("purple"))...)
+	diags.Append(plan.SetAttribute(ctx, path.Root("adopt_existing"), types.BoolValue(true))...)
+	diags.Append(plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+	diags.Append(plan.SetAttribute(ctx, path.Root("type"), types.StringUnknown())...)
+	diags.Append(plan.SetAttribute(ctx, path.Root("create_time"), types.StringUnknown())...)
+	diags.Append(plan.SetAttribute(ctx, path.Root("update_time"), types.StringUnknown())...)
+	if diags.HasError() {
+		t.Fatalf("setting plan: %v", diags)
+	}
+
+	resp := resource.CreateResponse{State: tfsdk.State{Raw: nullRaw, Schema: sch}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var id types.String
+	if diags := resp.State.GetAttribute(ctx, path.Root("id"), &id); diags.HasError() {
+		t.Fatalf("reading id: %v", diags)
+	}
+	if id.ValueString() != "l1" {
+		t.Errorf("id = %q, want the adopted label l1", id.ValueString())
+	}
+	if got := strings.Join(methods, ", "); got != "GET /analytics/v1/labels, PATCH /analytics/v1/labels/l1" {
+		t.Errorf("requests = %s, want a lookup and an update", got)
+	}
+}
+
+// TestFolderResource_Create_AdoptExisting_Parent verifies that Create with
+// adopt_existing only adopts a folder of the same name under the planned
+// parent folder, and creates the folder when the name exists only elsewhere.
+func TestFolderResource_Create_AdoptExisting_Parent(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		parentID     types.String
+		wantID       string
+		wantRequests string
+	}{
+		{"under parent", types.StringValue("p1"), "f2", "GET /analytics/v1/folders, PATCH /analytics/v1/folders/f2"},
+		{"at root", types.StringValue(rootFolderID), "f1", "GET /analytics/v1/folders, PATCH /analytics/v1/folders/f1"},
+		{"only elsewhere", types.StringValue("p2"), "new", "GET /analytics/v1/folders, POST /analytics/v1/folders"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests []string
+			r := &folderResource{client: newImportTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+				requests = append(requests, req.Method+" "+req.URL.Path)
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case req.Method == http.MethodGet && req.URL.Path == "/analytics/v1/folders":
+					_, _ = io.WriteString(w, `{"folders": [
+						{"id": "f1", "name": "team", "parentFolderId": "root"},
+						{"id": "f2", "name": "team", "parentFolderId": "p1"},
+						{"id": "p1", "name": "parent"}
+					]}`)
+				case req.Method == http.MethodPatch && strings.HasPrefix(req.URL.Path, "/analytics/v1/folders/"):
+					id := strings.TrimPrefix(req.URL.Path, "/analytics/v1/folders/")
+					_, _ = io.WriteString(w, `{"id": "`+id+`", "name": "team", "parentFolderId": "`+tt.parentID.ValueString()+`"}`)
+				case req.Method == http.MethodPost && req.URL.Path == "/analytics/v1/folders":
+					w.WriteHeader(http.StatusCreated)
+					_, _ = io.WriteString(w, `{"id": "new", "name": "team", "parentFolderId": "`+tt.parentID.ValueString()+`"}`)
+				default:
+					w.WriteHeader(http.StatusBadRequest)
+				}
+			})}
+
+			var schemaResp resource.SchemaResponse
+			r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+			sch := schemaResp.Schema
+			nullRaw := tftypes.NewValue(sch.Type().TerraformType(ctx), nil)
+
+			plan := tfsdk.Plan{Raw: nullRaw, Schema: sch}
+			diags := plan.SetAttribute(ctx, path.Root("name"), types.StringValue("team"))
+			diags.Append(plan.SetAttribute(ctx, path.Root("parent_folder_id"), tt.parentID)...)
+			diags.Append(plan.SetAttribute(ctx, path.Root("adopt_existing"), types.BoolValue(true))...)
+			diags.Append(plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+			diags.Append(plan.SetAttribute(ctx, path.Root("description"), types.StringUnknown())...)
+			if diags.HasError() {
+				t.Fatalf("setting plan: %v", diags)
+			}
+
+			resp := resource.CreateResponse{State: tfsdk.State{Raw: nullRaw, Schema: sch}}
+			r.Create(ctx, resource.CreateRequest{Plan: plan}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var id types.String
+			if diags := resp.State.GetAttribute(ctx, path.Root("id"), &id); diags.HasError() {
+				t.Fatalf("reading id: %v", diags)
+			}
+			if id.ValueString() != tt.wantID {
+				t.Errorf("id = %q, want %q", id.ValueString(), tt.wantID)
+			}
+			if got := strings.Join(requests, ", "); got != tt.wantRequests {
+				t.Errorf("requests = %s, want %s", got, tt.wantRequests)
+			}
+		})
+	}
+}
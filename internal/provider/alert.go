@@ -7,6 +7,8 @@ import (
 	"github.com/doitintl/terraform-provider-doit/internal/provider/resource_alert"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -256,10 +258,7 @@ func (r *alertResource) populateState(ctx context.Context, state *alertResourceM
 	// Get refreshed alert value from API
 	alertResp, err := r.client.GetAlertWithResponse(ctx, state.Id.ValueString())
 	if err != nil {
-		diags.AddError(
-			"Error Reading Alert",
-			"Could not read alert ID "+state.Id.ValueString()+": "+err.Error(),
-		)
+		diags.Append(apiErrorDiagnostics("Error Reading Alert", "Could not read alert ID "+state.Id.ValueString(), err)...)
 		return
 	}
 
@@ -281,6 +280,26 @@ func (r *alertResource) populateState(ctx context.Context, state *alertResourceM
 	return mapAlertToModel(ctx, resp, state)
 }
 
+// checkConcurrentModification refreshes the alert in prior from the API, as
+// Read does, and fails when it changed since the last refresh. See
+// diffConcurrentModification.
+func (r *alertResource) checkConcurrentModification(ctx context.Context, prior tfsdk.State) diag.Diagnostics {
+	var remote alertResourceModel
+	diags := prior.Get(ctx, &remote)
+	if diags.HasError() {
+		return diags
+	}
+	id := remote.Id.ValueString()
+
+	diags.Append(r.populateState(ctx, &remote)...)
+	// A deleted alert is left to the update request to report.
+	if diags.HasError() || remote.Id.IsNull() {
+		return diags
+	}
+	diags.Append(diffConcurrentModification(ctx, "alert", id, prior, &remote)...)
+	return diags
+}
+
 // mapAlertToModel maps the API response to the Terraform model.
 func mapAlertToModel(ctx context.Context, resp *models.Alert, state *alertResourceModel) (diags diag.Diagnostics) {
 	if resp == nil {
@@ -367,6 +386,7 @@ func mapAlertConfigToModel(ctx context.Context, config *models.AlertConfig, exis
 			// and converts them to includeNull=true. We restore any stripped sentinels
 			// by comparing the API response against the prior state.
 			// See: https://doitintl.atlassian.net/browse/CMP-38116
+			scopePath := path.Root("config").AtName("scopes").AtListIndex(i)
 			apiIncludeNull := scope.IncludeNull != nil && *scope.IncludeNull
 			var apiValues []string
 			if scope.Values != nil {
@@ -377,6 +397,7 @@ func mapAlertConfigToModel(ctx context.Context, config *models.AlertConfig, exis
 				var stateVals []string
 				if d := existingScopeValues[i].ElementsAs(ctx, &stateVals, false); !d.HasError() {
 					mergedValues = mergeSentinelValues(apiValues, stateVals, apiIncludeNull)
+					explainRestoredSentinels(ctx, scopePath.AtName("values"), apiValues, mergedValues, apiIncludeNull)
 				}
 			}
 			var valuesVal types.List
@@ -396,9 +417,11 @@ func mapAlertConfigToModel(ctx context.Context, config *models.AlertConfig, exis
 			// E.g. user configures "allocation_rule", API returns "attribution" — preserve user's value.
 			if i < len(existingScopeTypes) {
 				scopeType = normalizeDimensionsType(scopeType, existingScopeTypes[i])
+				explainNormalizedType(ctx, scopePath.AtName("type"), string(scope.Type), scopeType)
 			}
 			if i < len(existingScopeIDs) {
 				scopeID = normalizeDimensionsType(scopeID, existingScopeIDs[i])
+				explainNormalizedType(ctx, scopePath.AtName("id"), scope.Id, scopeID)
 			}
 
 			// The API does not reliably echo includeNull — it returns false as a default
@@ -26,7 +26,8 @@ type alertDataSource struct {
 
 type alertDataSourceModel struct {
 	datasource_alert.AlertModel
-	Timeouts timeouts.Value `tfsdk:"timeouts"`
+	CustomerContext types.String   `tfsdk:"customer_context"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (ds *alertDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -51,6 +52,7 @@ func (ds *alertDataSource) Configure(_ context.Context, req datasource.Configure
 func (ds *alertDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	s := datasource_alert.AlertDataSourceSchema(ctx)
 
+	s.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx)
 
 	resp.Schema = s
@@ -69,6 +71,7 @@ func (ds *alertDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	// If ID is unknown (depends on a resource not yet created), set all computed
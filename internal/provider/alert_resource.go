@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure the implementation satisfies expected interfaces.
@@ -20,15 +21,27 @@ var (
 	_ resource.ResourceWithConfigure        = (*alertResource)(nil)
 	_ resource.ResourceWithImportState      = (*alertResource)(nil)
 	_ resource.ResourceWithConfigValidators = (*alertResource)(nil)
+	_ resource.ResourceWithModifyPlan       = (*alertResource)(nil)
 )
 
 type (
 	alertResource struct {
-		client *models.ClientWithResponses
+		client                       *models.ClientWithResponses
+		readOnly                     bool
+		detectConcurrentModification bool
+		defaultLabels                []string
+		defaults                     notificationDefaults
+		validateDimensions           bool
+		explainDrift                 bool
+		// recipientValidation and customerContext configure
+		// validateRecipients; see recipient_validation.go.
+		recipientValidation string
+		customerContext     string
 	}
 	alertResourceModel struct {
 		resource_alert.AlertModel
-		Timeouts timeouts.Value `tfsdk:"timeouts"`
+		CustomerContext types.String   `tfsdk:"customer_context"`
+		Timeouts        timeouts.Value `tfsdk:"timeouts"`
 	}
 )
 
@@ -43,16 +56,24 @@ func (r *alertResource) Configure(_ context.Context, req resource.ConfigureReque
 		return
 	}
 
-	client, ok := req.ProviderData.(*models.ClientWithResponses)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *models.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.readOnly = data.readOnly
+	r.detectConcurrentModification = data.detectConcurrentModification
+	r.defaultLabels = data.defaultLabels
+	r.validateDimensions = data.validateDimensions
+	r.explainDrift = data.explainDrift
+	r.recipientValidation = data.recipientValidation
+	r.customerContext = data.customerContext
+	r.defaults = data.notificationDefaults
 }
 
 func (r *alertResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -60,7 +81,12 @@ func (r *alertResource) Metadata(_ context.Context, req resource.MetadataRequest
 }
 
 func (r *alertResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	ctx, id, diags := importCustomerContext(ctx, req, resp, 1)
+	resp.Diagnostics.Append(diags...)
+	req.ID = id
+
+	// Accepts either an ID or "name:<exact name>".
+	importStatePassthroughIDOrName(ctx, req, resp, "alert", nameLookup(r.client, listAlerts))
 }
 
 func (r *alertResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -102,6 +128,7 @@ func (r *alertResource) Schema(ctx context.Context, _ resource.SchemaRequest, re
 		s.Attributes["config"] = configAttr
 	}
 
+	s.Attributes["customer_context"] = customerContextResourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx, timeouts.Opts{
 		Create: true,
 		Read:   true,
@@ -120,7 +147,36 @@ func (r *alertResource) ConfigValidators(_ context.Context) []resource.ConfigVal
 	}
 }
 
+// ModifyPlan fills the provider's default notification settings into
+// attributes left out of configuration.
+func (r *alertResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return // destroy
+	}
+
+	recipients, diags := r.defaults.recipientsList(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	applyListDefault(ctx, req, resp, path.Root("recipients"), recipients)
+
+	if r.validateDimensions {
+		resp.Diagnostics.Append(validateDimensionFilters(ctx, r.client, req, "id",
+			path.MatchRoot("config").AtName("scopes").AtAnyListIndex())...)
+	}
+
+	resp.Diagnostics.Append(validateRecipients(ctx, r.client, r.recipientValidation, r.customerContext, resp.Plan, req.State,
+		path.MatchRoot("recipients").AtAnyListIndex())...)
+}
+
 func (r *alertResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_alert", "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan alertResourceModel
 
 	// Read Terraform plan data into the model
@@ -135,6 +191,7 @@ func (r *alertResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	ctx = withCustomerContext(ctx, plan.CustomerContext)
 	defer cancel()
 
 	// Convert model to API alert request
@@ -147,10 +204,7 @@ func (r *alertResource) Create(ctx context.Context, req resource.CreateRequest,
 	// Create new alert via API
 	alertResp, err := r.client.CreateAlertWithResponse(ctx, alertReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Creating Alert",
-			"Could not create alert, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Creating Alert", "Could not create alert", err)...)
 		return
 	}
 
@@ -177,6 +231,8 @@ func (r *alertResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	resp.Diagnostics.Append(assignDefaultLabels(ctx, r.client, r.defaultLabels, models.LabelAssignmentObjectObjectTypeAlert, plan.Id.ValueString())...)
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
@@ -196,7 +252,12 @@ func (r *alertResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
+	var drift *driftExplanations
+	if r.explainDrift {
+		ctx, drift = withDriftExplanations(ctx)
+	}
 
 	// Populate state from API
 	resp.Diagnostics.Append(r.populateState(ctx, &state)...)
@@ -210,11 +271,18 @@ func (r *alertResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	resp.Diagnostics.Append(drift.diagnostics()...)
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
 func (r *alertResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_alert", "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan alertResourceModel
 
 	// Read Terraform plan data
@@ -229,6 +297,7 @@ func (r *alertResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	ctx = withCustomerContext(ctx, plan.CustomerContext)
 	defer cancel()
 
 	// Convert model to API alert update request
@@ -246,13 +315,17 @@ func (r *alertResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 	alertID := state.Id.ValueString()
 
+	if r.detectConcurrentModification {
+		resp.Diagnostics.Append(r.checkConcurrentModification(ctx, req.State)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// Update alert via API
 	updateResp, err := r.client.UpdateAlertWithResponse(ctx, alertID, alertReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Updating Alert",
-			"Could not update alert, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Updating Alert", "Could not update alert", err)...)
 		return
 	}
 
@@ -285,6 +358,11 @@ func (r *alertResource) Update(ctx context.Context, req resource.UpdateRequest,
 }
 
 func (r *alertResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_alert", "delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var state alertResourceModel
 
 	// Read Terraform prior state data into the model
@@ -299,15 +377,15 @@ func (r *alertResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
 
+	resp.Diagnostics.Append(unassignDefaultLabels(ctx, r.client, r.defaultLabels, models.LabelAssignmentObjectObjectTypeAlert, state.Id.ValueString())...)
+
 	// Delete alert via API
 	deleteResp, err := r.client.DeleteAlertWithResponse(ctx, state.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Deleting DoiT Alert",
-			"Could not delete alert, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Deleting DoiT Alert", "Could not delete alert", err)...)
 		return
 	}
 
@@ -26,7 +26,8 @@ type alertsDataSource struct {
 
 type alertsDataSourceModel struct {
 	datasource_alerts.AlertsModel
-	Timeouts timeouts.Value `tfsdk:"timeouts"`
+	CustomerContext types.String   `tfsdk:"customer_context"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (d *alertsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -36,6 +37,7 @@ func (d *alertsDataSource) Metadata(ctx context.Context, req datasource.Metadata
 func (d *alertsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	s := datasource_alerts.AlertsDataSourceSchema(ctx)
 
+	s.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx)
 
 	resp.Schema = s
@@ -72,6 +74,7 @@ func (d *alertsDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	// If any filter/pagination input is unknown, return unknown list
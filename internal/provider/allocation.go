@@ -12,6 +12,8 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
@@ -289,10 +291,7 @@ func (r *allocationResource) populateState(ctx context.Context, state *allocatio
 	// Get refreshed allocation value from DoiT using the ID from the state.
 	httpResp, err := r.client.GetAllocationWithResponse(ctx, state.Id.ValueString())
 	if err != nil {
-		diags.AddError(
-			"Error Reading Doit Console Allocation",
-			"Could not read Doit Console Allocation ID "+state.Id.ValueString()+": "+err.Error(),
-		)
+		diags.Append(apiErrorDiagnostics("Error Reading Doit Console Allocation", "Could not read Doit Console Allocation ID "+state.Id.ValueString(), err)...)
 		return
 	}
 
@@ -323,6 +322,26 @@ func (r *allocationResource) populateState(ctx context.Context, state *allocatio
 	return mapAllocationToModel(ctx, r.client, httpResp.JSON200, state)
 }
 
+// checkConcurrentModification refreshes the allocation in prior from the API, as
+// Read does, and fails when it changed since the last refresh. See
+// diffConcurrentModification.
+func (r *allocationResource) checkConcurrentModification(ctx context.Context, prior tfsdk.State) diag.Diagnostics {
+	var remote allocationResourceModel
+	diags := prior.Get(ctx, &remote)
+	if diags.HasError() {
+		return diags
+	}
+	id := remote.Id.ValueString()
+
+	diags.Append(r.populateState(ctx, &remote)...)
+	// A deleted allocation is left to the update request to report.
+	if diags.HasError() || remote.Id.IsNull() {
+		return diags
+	}
+	diags.Append(diffConcurrentModification(ctx, "allocation", id, prior, &remote)...)
+	return diags
+}
+
 // mapAllocationToModel maps an Allocation API response to the Terraform resource model.
 // This is used by both populateState (for Read) and directly by Create/Update
 // when the API returns the full object in the response.
@@ -369,7 +388,7 @@ func mapAllocationToModel(ctx context.Context, client *models.ClientWithResponse
 				}
 			}
 			var d diag.Diagnostics
-			m["components"], d = toAllocationRuleComponentsListValue(ctx, rule.Components, existingComponents)
+			m["components"], d = toAllocationRuleComponentsListValue(ctx, path.Root("rule").AtName("components"), rule.Components, existingComponents)
 			diags.Append(d...)
 			if diags.HasError() {
 				return
@@ -477,7 +496,7 @@ func mapAllocationToModel(ctx context.Context, client *models.ClientWithResponse
 					}
 				}
 				var d diag.Diagnostics
-				m["components"], d = toAllocationRuleComponentsListValue(ctx, components, existingComponents)
+				m["components"], d = toAllocationRuleComponentsListValue(ctx, path.Root("rules").AtListIndex(len(rules)).AtName("components"), components, existingComponents)
 				diags.Append(d...)
 				if diags.HasError() {
 					return
@@ -517,7 +536,10 @@ func mapAllocationToModel(ctx context.Context, client *models.ClientWithResponse
 	return
 }
 
-func toAllocationRuleComponentsListValue(ctx context.Context, components []models.AllocationComponent, existingComponents []resource_allocation.ComponentsValue) (res basetypes.ListValue, diags diag.Diagnostics) {
+// toAllocationRuleComponentsListValue maps API components to the list at
+// componentsPath, restoring the configured form of normalized values from
+// existingComponents.
+func toAllocationRuleComponentsListValue(ctx context.Context, componentsPath path.Path, components []models.AllocationComponent, existingComponents []resource_allocation.ComponentsValue) (res basetypes.ListValue, diags diag.Diagnostics) {
 	// Handle empty slice: return an empty list without indexing stateComponents[0].
 	if len(components) == 0 {
 		res, diags = types.ListValueFrom(ctx, resource_allocation.ComponentsValue{}.Type(ctx), []resource_allocation.ComponentsValue{})
@@ -529,6 +551,7 @@ func toAllocationRuleComponentsListValue(ctx context.Context, components []model
 		compType := string(component.Type)
 		if i < len(existingComponents) {
 			compType = normalizeDimensionsType(compType, existingComponents[i].ComponentsType.ValueString())
+			explainNormalizedType(ctx, componentsPath.AtListIndex(i).AtName("type"), string(component.Type), compType)
 		}
 
 		// Field echo behavior (verified via API probe):
@@ -600,6 +623,7 @@ func toAllocationRuleComponentsListValue(ctx context.Context, components []model
 				return
 			}
 			apiValues = mergeSentinelValues(apiValues, stateVals, apiIncludeNull)
+			explainRestoredSentinels(ctx, componentsPath.AtListIndex(i).AtName("values"), component.Values, apiValues, apiIncludeNull)
 		}
 		values := make([]attr.Value, len(apiValues))
 		for j := range apiValues {
@@ -0,0 +1,449 @@
+package provider
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/doitintl/terraform-provider-doit/internal/provider/models"
+)
+
+// Allocation rule analysis for the doit_allocation_analysis data source.
+//
+// A group allocation assigns each cost row to the first of its rules that
+// matches. Costs matched by two rules silently land in the earlier one, and a
+// rule whose costs are all claimed by earlier rules allocates nothing. The
+// analysis queries the costs each rule matches on its own, grouped by every
+// dimension the rules filter on, and evaluates the other rules against those
+// rows locally to find overlaps and what each rule actually receives.
+
+// allocationRuleSpec is one rule to analyze, from an existing allocation or
+// from inline configuration.
+type allocationRuleSpec struct {
+	name       string
+	formula    string
+	components []models.AllocationComponent
+}
+
+// analysisDimension identifies a dimension a rule component filters on.
+type analysisDimension struct {
+	dimType string
+	key     string
+}
+
+// analysisRow is the cost of one combination of dimension values. A nil
+// value is a row without a value for that dimension.
+type analysisRow struct {
+	values []*string
+	cost   float64
+}
+
+// analysisRule is a rule compiled for evaluation against analysisRows.
+type analysisRule struct {
+	spec    allocationRuleSpec
+	formula formulaExpr
+	// matchers test each component against the value of its dimension, at
+	// dims[i] in the row.
+	matchers []func(*string) bool
+	dims     []int
+}
+
+// compileAnalysisRules compiles rules and returns the dimensions their
+// components filter on, in the order the rows hold them.
+func compileAnalysisRules(specs []allocationRuleSpec) ([]analysisRule, []analysisDimension, error) {
+	var dims []analysisDimension
+	dimIndex := map[analysisDimension]int{}
+
+	rules := make([]analysisRule, len(specs))
+	for i, spec := range specs {
+		expr, err := parseFormula(spec.formula, len(spec.components))
+		if err != nil {
+			return nil, nil, fmt.Errorf("rule %d (%s): %w", i, spec.name, err)
+		}
+		rule := analysisRule{spec: spec, formula: expr}
+		for j, c := range spec.components {
+			matcher, err := componentMatcher(c)
+			if err != nil {
+				return nil, nil, fmt.Errorf("rule %d (%s), component %d: %w", i, spec.name, j, err)
+			}
+			dim := analysisDimension{dimType: string(c.Type), key: c.Key}
+			idx, ok := dimIndex[dim]
+			if !ok {
+				idx = len(dims)
+				dimIndex[dim] = idx
+				dims = append(dims, dim)
+			}
+			rule.matchers = append(rule.matchers, matcher)
+			rule.dims = append(rule.dims, idx)
+		}
+		rules[i] = rule
+	}
+	return rules, dims, nil
+}
+
+// matches reports whether the rule matches the row.
+func (r analysisRule) matches(row analysisRow) bool {
+	results := make([]bool, len(r.matchers))
+	for i, m := range r.matchers {
+		results[i] = m(row.values[r.dims[i]])
+	}
+	return r.formula.eval(results)
+}
+
+// queryFilters returns the components the API can apply as report filters
+// when querying the costs the rule matches, or nil when the formula is not a
+// plain conjunction and the rule must be evaluated locally on unfiltered rows.
+func (r analysisRule) queryFilters() []models.ExternalConfigFilter {
+	refs, ok := conjunctionRefs(r.formula)
+	if !ok {
+		return nil
+	}
+	filters := make([]models.ExternalConfigFilter, 0, len(refs))
+	for _, i := range refs {
+		c := r.spec.components[i]
+		filters = append(filters, models.ExternalConfigFilter{
+			CaseInsensitive: c.CaseInsensitive,
+			Id:              c.Key,
+			IncludeNull:     c.IncludeNull,
+			Inverse:         new(c.Inverse != nil && *c.Inverse || c.InverseSelection != nil && *c.InverseSelection),
+			Mode:            new(models.ExternalConfigFilterMode(c.Mode)),
+			Type:            models.DimensionsTypes(c.Type),
+			Values:          new(slices.Clone(c.Values)),
+		})
+	}
+	return filters
+}
+
+// componentMatcher returns a function reporting whether a dimension value
+// satisfies the component. Rows without a value match only with
+// include_null; inverse negates the match of the other rows.
+func componentMatcher(c models.AllocationComponent) (func(*string) bool, error) {
+	// case_insensitive applies to the substring modes only.
+	fold := c.CaseInsensitive != nil && *c.CaseInsensitive &&
+		(c.Mode == models.AllocationComponentModeStartsWith ||
+			c.Mode == models.AllocationComponentModeEndsWith ||
+			c.Mode == models.AllocationComponentModeContains)
+	norm := func(s string) string {
+		if fold {
+			return strings.ToLower(s)
+		}
+		return s
+	}
+	values := make([]string, len(c.Values))
+	for i, v := range c.Values {
+		values[i] = norm(v)
+	}
+
+	var match func(string) bool
+	switch c.Mode {
+	case models.AllocationComponentModeIs:
+		match = func(v string) bool { return slices.Contains(values, v) }
+	case models.AllocationComponentModeStartsWith:
+		match = func(v string) bool {
+			return slices.ContainsFunc(values, func(p string) bool { return strings.HasPrefix(v, p) })
+		}
+	case models.AllocationComponentModeEndsWith:
+		match = func(v string) bool {
+			return slices.ContainsFunc(values, func(s string) bool { return strings.HasSuffix(v, s) })
+		}
+	case models.AllocationComponentModeContains:
+		match = func(v string) bool {
+			return slices.ContainsFunc(values, func(s string) bool { return strings.Contains(v, s) })
+		}
+	case models.AllocationComponentModeRegexp:
+		patterns := make([]*regexp.Regexp, len(c.Values))
+		for i, v := range c.Values {
+			re, err := regexp.Compile(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid regexp %q: %w", v, err)
+			}
+			patterns[i] = re
+		}
+		match = func(v string) bool {
+			return slices.ContainsFunc(patterns, func(re *regexp.Regexp) bool { return re.MatchString(v) })
+		}
+	default:
+		return nil, fmt.Errorf("unsupported mode %q", c.Mode)
+	}
+
+	includeNull := c.IncludeNull != nil && *c.IncludeNull
+	inverse := c.Inverse != nil && *c.Inverse || c.InverseSelection != nil && *c.InverseSelection
+	return func(v *string) bool {
+		if v == nil {
+			return includeNull
+		}
+		return match(norm(*v)) != inverse
+	}, nil
+}
+
+// allocationAnalysis is the result of analyzeAllocationRules.
+type allocationAnalysis struct {
+	totalCost       float64
+	allocatedCost   float64
+	unallocatedCost float64
+	// unallocatedShare is unallocatedCost as a fraction of totalCost.
+	unallocatedShare float64
+	rules            []ruleAnalysis
+	overlaps         []ruleOverlap
+}
+
+// ruleAnalysis describes one rule of the allocation.
+type ruleAnalysis struct {
+	// matchedCost is the cost the rule matches on its own.
+	matchedCost float64
+	// allocatedCost is the part of matchedCost no earlier rule claims.
+	allocatedCost float64
+	// neverMatches is set when no cost row matches the rule.
+	neverMatches bool
+	// shadowed is set when the rule matches costs but earlier rules claim
+	// all of them.
+	shadowed     bool
+	overlapsWith []int
+}
+
+// ruleOverlap is cost matched by two rules, allocated to the first.
+type ruleOverlap struct {
+	firstRule  int
+	secondRule int
+	cost       float64
+}
+
+// analyzeAllocationRules evaluates rules against rowsPerRule, the rows each
+// rule matches on its own, and totalCost, the cost of all rows in the
+// analyzed period.
+func analyzeAllocationRules(rules []analysisRule, rowsPerRule [][]analysisRow, totalCost float64) allocationAnalysis {
+	result := allocationAnalysis{
+		totalCost: roundCost(totalCost),
+		rules:     make([]ruleAnalysis, len(rules)),
+	}
+	overlapCost := map[[2]int]float64{}
+
+	for i, rows := range rowsPerRule {
+		ra := &result.rules[i]
+		ra.neverMatches = len(rows) == 0
+		for _, row := range rows {
+			ra.matchedCost += row.cost
+			claimed := false
+			for j, other := range rules {
+				if j == i || !other.matches(row) {
+					continue
+				}
+				if j < i {
+					claimed = true
+				} else {
+					// Each overlapping row is in the rows of both rules;
+					// count it once, from the earlier rule.
+					overlapCost[[2]int{i, j}] += row.cost
+				}
+			}
+			if !claimed {
+				ra.allocatedCost += row.cost
+			}
+		}
+		ra.matchedCost = roundCost(ra.matchedCost)
+		ra.allocatedCost = roundCost(ra.allocatedCost)
+		ra.shadowed = !ra.neverMatches && ra.allocatedCost == 0 && ra.matchedCost != 0
+		result.allocatedCost += ra.allocatedCost
+	}
+
+	for pair, cost := range overlapCost {
+		result.overlaps = append(result.overlaps, ruleOverlap{firstRule: pair[0], secondRule: pair[1], cost: roundCost(cost)})
+		result.rules[pair[0]].overlapsWith = append(result.rules[pair[0]].overlapsWith, pair[1])
+		result.rules[pair[1]].overlapsWith = append(result.rules[pair[1]].overlapsWith, pair[0])
+	}
+	slices.SortFunc(result.overlaps, func(a, b ruleOverlap) int {
+		if a.firstRule != b.firstRule {
+			return a.firstRule - b.firstRule
+		}
+		return a.secondRule - b.secondRule
+	})
+	for i := range result.rules {
+		slices.Sort(result.rules[i].overlapsWith)
+	}
+
+	result.allocatedCost = roundCost(result.allocatedCost)
+	result.unallocatedCost = roundCost(math.Max(0, result.totalCost-result.allocatedCost))
+	if result.totalCost > 0 {
+		result.unallocatedShare = math.Round(result.unallocatedCost/result.totalCost*1e4) / 1e4
+	}
+	return result
+}
+
+// roundCost rounds to cents, dropping floating-point noise from sums.
+func roundCost(c float64) float64 {
+	return math.Round(c*100) / 100
+}
+
+// formulaExpr is a parsed allocation rule formula, such as "A AND (B OR C)".
+type formulaExpr interface {
+	eval(components []bool) bool
+}
+
+type (
+	formulaRef int
+	formulaNot struct{ x formulaExpr }
+	formulaAnd struct{ l, r formulaExpr }
+	formulaOr  struct{ l, r formulaExpr }
+)
+
+func (f formulaRef) eval(c []bool) bool { return c[f] }
+func (f formulaNot) eval(c []bool) bool { return !f.x.eval(c) }
+func (f formulaAnd) eval(c []bool) bool { return f.l.eval(c) && f.r.eval(c) }
+func (f formulaOr) eval(c []bool) bool  { return f.l.eval(c) || f.r.eval(c) }
+
+// conjunctionRefs returns the components a formula ANDs together, when it
+// uses no other operator.
+func conjunctionRefs(expr formulaExpr) ([]int, bool) {
+	switch e := expr.(type) {
+	case formulaRef:
+		return []int{int(e)}, true
+	case formulaAnd:
+		l, ok := conjunctionRefs(e.l)
+		if !ok {
+			return nil, false
+		}
+		r, ok := conjunctionRefs(e.r)
+		if !ok {
+			return nil, false
+		}
+		return append(l, r...), true
+	default:
+		return nil, false
+	}
+}
+
+// parseFormula parses a rule formula over n components. Components are the
+// letters A to Z; AND binds tighter than OR, and NOT tighter than both. An
+// empty formula ANDs all components.
+func parseFormula(formula string, n int) (formulaExpr, error) {
+	tokens, err := tokenizeFormula(formula)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		if n == 0 {
+			return nil, fmt.Errorf("rule has no components")
+		}
+		var expr formulaExpr = formulaRef(0)
+		for i := 1; i < n; i++ {
+			expr = formulaAnd{expr, formulaRef(i)}
+		}
+		return expr, nil
+	}
+
+	p := &formulaParser{tokens: tokens, n: n}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q in formula %q", p.tokens[p.pos], formula)
+	}
+	return expr, nil
+}
+
+func tokenizeFormula(formula string) ([]string, error) {
+	var tokens []string
+	runes := []rune(formula)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')':
+			tokens = append(tokens, string(r))
+			i++
+		case unicode.IsLetter(r):
+			j := i
+			for j < len(runes) && unicode.IsLetter(runes[j]) {
+				j++
+			}
+			tokens = append(tokens, strings.ToUpper(string(runes[i:j])))
+			i = j
+		default:
+			return nil, fmt.Errorf("unexpected %q in formula %q", r, formula)
+		}
+	}
+	return tokens, nil
+}
+
+type formulaParser struct {
+	tokens []string
+	pos    int
+	n      int
+}
+
+func (p *formulaParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *formulaParser) parseOr() (formulaExpr, error) {
+	l, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "OR" {
+		p.pos++
+		r, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l = formulaOr{l, r}
+	}
+	return l, nil
+}
+
+func (p *formulaParser) parseAnd() (formulaExpr, error) {
+	l, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "AND" {
+		p.pos++
+		r, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		l = formulaAnd{l, r}
+	}
+	return l, nil
+}
+
+func (p *formulaParser) parseUnary() (formulaExpr, error) {
+	tok := p.peek()
+	p.pos++
+	switch {
+	case tok == "NOT":
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return formulaNot{x}, nil
+	case tok == "(":
+		x, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("missing ) in formula")
+		}
+		p.pos++
+		return x, nil
+	case len(tok) == 1 && tok[0] >= 'A' && tok[0] <= 'Z':
+		idx := int(tok[0] - 'A')
+		if idx >= p.n {
+			return nil, fmt.Errorf("formula refers to component %s, but the rule has %d components", tok, p.n)
+		}
+		return formulaRef(idx), nil
+	case tok == "":
+		return nil, fmt.Errorf("formula ends unexpectedly")
+	default:
+		return nil, fmt.Errorf("unexpected %q in formula", tok)
+	}
+}
@@ -0,0 +1,703 @@
+// Package provider implements the doit_allocation_analysis data source.
+//
+// Like doit_report_query, this is a hand-written data source: it reads an
+// allocation, or takes rules in the doit_allocation format, and runs report
+// queries through POST /analytics/v1/reports/query to find overlapping
+// rules, rules that match nothing and the share of costs left unallocated.
+// See allocation_analysis.go for the evaluation.
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/doitintl/terraform-provider-doit/internal/provider/models"
+	"github.com/doitintl/terraform-provider-doit/internal/provider/resource_allocation"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	rsschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/oapi-codegen/nullable"
+)
+
+// defaultAnalysisLookbackDays is the period analyzed when lookback_days is
+// not set.
+const defaultAnalysisLookbackDays = 30
+
+// Compile-time interface checks.
+var _ datasource.DataSource = (*allocationAnalysisDataSource)(nil)
+var _ datasource.DataSourceWithConfigure = (*allocationAnalysisDataSource)(nil)
+
+// NewAllocationAnalysisDataSource creates a new instance of the data source.
+func NewAllocationAnalysisDataSource() datasource.DataSource {
+	return &allocationAnalysisDataSource{}
+}
+
+// allocationAnalysisDataSource implements datasource.DataSource for
+// allocation rule analysis.
+type allocationAnalysisDataSource struct {
+	client *models.ClientWithResponses
+}
+
+// allocationAnalysisDataSourceModel is the Terraform state model.
+type allocationAnalysisDataSourceModel struct {
+	// Inputs
+	AllocationId types.String `tfsdk:"allocation_id"`
+	Rules        types.List   `tfsdk:"rules"`
+	LookbackDays types.Int64  `tfsdk:"lookback_days"`
+
+	// Outputs
+	TotalCost             types.Float64 `tfsdk:"total_cost"`
+	AllocatedCost         types.Float64 `tfsdk:"allocated_cost"`
+	UnallocatedCost       types.Float64 `tfsdk:"unallocated_cost"`
+	UnallocatedCostsShare types.Float64 `tfsdk:"unallocated_costs_share"`
+	RuleResults           types.List    `tfsdk:"rule_results"`
+	Overlaps              types.List    `tfsdk:"overlaps"`
+
+	CustomerContext types.String   `tfsdk:"customer_context"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+}
+
+// allocationRuleResultModel is an element of rule_results.
+type allocationRuleResultModel struct {
+	Index         types.Int64   `tfsdk:"index"`
+	Name          types.String  `tfsdk:"name"`
+	MatchedCost   types.Float64 `tfsdk:"matched_cost"`
+	AllocatedCost types.Float64 `tfsdk:"allocated_cost"`
+	NeverMatches  types.Bool    `tfsdk:"never_matches"`
+	Shadowed      types.Bool    `tfsdk:"shadowed"`
+	OverlapsWith  types.List    `tfsdk:"overlaps_with"`
+}
+
+// allocationRuleOverlapModel is an element of overlaps.
+type allocationRuleOverlapModel struct {
+	FirstRule  types.Int64   `tfsdk:"first_rule"`
+	SecondRule types.Int64   `tfsdk:"second_rule"`
+	Cost       types.Float64 `tfsdk:"cost"`
+}
+
+var allocationRuleResultAttrTypes = map[string]attr.Type{
+	"index":          types.Int64Type,
+	"name":           types.StringType,
+	"matched_cost":   types.Float64Type,
+	"allocated_cost": types.Float64Type,
+	"never_matches":  types.BoolType,
+	"shadowed":       types.BoolType,
+	"overlaps_with":  types.ListType{ElemType: types.Int64Type},
+}
+
+var allocationRuleOverlapAttrTypes = map[string]attr.Type{
+	"first_rule":  types.Int64Type,
+	"second_rule": types.Int64Type,
+	"cost":        types.Float64Type,
+}
+
+func (d *allocationAnalysisDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_allocation_analysis"
+}
+
+func (d *allocationAnalysisDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	// Inline rules take the shape of the doit_allocation rules attribute, so
+	// rules can be analyzed before they are applied.
+	allocationSchema := resource_allocation.AllocationResourceSchema(ctx)
+	rulesAttr, ok := allocationSchema.Attributes["rules"].(rsschema.ListNestedAttribute)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Internal Error",
+			"Could not convert allocation rules schema to ListNestedAttribute. Please report this issue to the provider developers.",
+		)
+		return
+	}
+	dsRulesAttrs, convertDiags := convertResourceAttrsToDataSource(rulesAttr.NestedObject.Attributes)
+	resp.Diagnostics.Append(convertDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Schema = dsschema.Schema{
+		Description: "Analyzes the rules of a group allocation for overlaps, rules that match no costs, and the share of " +
+			"costs left unallocated." +
+			"\n\nGroup allocation rules are evaluated in order, and each cost goes to the first rule that matches it. " +
+			"The data source runs one report query per rule for the costs the rule matches on its own, grouped by " +
+			"every dimension the rules filter on, and evaluates the other rules against the result." +
+			"\n\nNote: Results cover the last lookback_days days of billing data and change as new data is ingested. " +
+			"Every terraform plan re-runs the queries.",
+		MarkdownDescription: "Analyzes the rules of a group allocation for overlaps, rules that match no costs, and the " +
+			"share of costs left unallocated." +
+			"\n\nGroup allocation rules are evaluated in order, and each cost goes to the first rule that matches it. " +
+			"The data source runs one report query per rule for the costs the rule matches on its own, grouped by " +
+			"every dimension the rules filter on, and evaluates the other rules against the result." +
+			"\n\n~> **Note:** Results cover the last `lookback_days` days of billing data and change as new data is " +
+			"ingested. Every `terraform plan` re-runs the queries.",
+		Attributes: map[string]dsschema.Attribute{
+			// --- Input ---
+			"allocation_id": dsschema.StringAttribute{
+				Description:         "ID of the allocation to analyze. Exactly one of allocation_id and rules is required.",
+				MarkdownDescription: "ID of the allocation to analyze. Exactly one of `allocation_id` and `rules` is required.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("rules")),
+				},
+			},
+			"rules": dsschema.ListNestedAttribute{
+				NestedObject: dsschema.NestedAttributeObject{
+					Attributes: dsRulesAttrs,
+					CustomType: rulesAttr.NestedObject.CustomType,
+				},
+				Optional: true,
+				Description: "Rules to analyze, in the format of the rules attribute of doit_allocation. " +
+					"Rules with action select are read from the allocation they select.",
+				MarkdownDescription: "Rules to analyze, in the format of the `rules` attribute of `doit_allocation`. " +
+					"Rules with action `select` are read from the allocation they select.",
+			},
+			"lookback_days": dsschema.Int64Attribute{
+				Description:         "Number of days of billing data to analyze, ending yesterday. Defaults to 30.",
+				MarkdownDescription: "Number of days of billing data to analyze, ending yesterday. Defaults to `30`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 365),
+				},
+			},
+
+			// --- Outputs ---
+			"total_cost": dsschema.Float64Attribute{
+				Description:         "Cost of all billing data in the analyzed period.",
+				MarkdownDescription: "Cost of all billing data in the analyzed period.",
+				Computed:            true,
+			},
+			"allocated_cost": dsschema.Float64Attribute{
+				Description:         "Cost allocated to any rule.",
+				MarkdownDescription: "Cost allocated to any rule.",
+				Computed:            true,
+			},
+			"unallocated_cost": dsschema.Float64Attribute{
+				Description:         "Cost no rule matches, which the allocation reports as unallocated costs.",
+				MarkdownDescription: "Cost no rule matches, which the allocation reports as unallocated costs.",
+				Computed:            true,
+			},
+			"unallocated_costs_share": dsschema.Float64Attribute{
+				Description:         "unallocated_cost as a fraction of total_cost, between 0 and 1.",
+				MarkdownDescription: "`unallocated_cost` as a fraction of `total_cost`, between 0 and 1.",
+				Computed:            true,
+			},
+			"rule_results": dsschema.ListNestedAttribute{
+				Description:         "Analysis of each rule, in rule order.",
+				MarkdownDescription: "Analysis of each rule, in rule order.",
+				Computed:            true,
+				NestedObject: dsschema.NestedAttributeObject{
+					Attributes: map[string]dsschema.Attribute{
+						"index": dsschema.Int64Attribute{
+							Description:         "Zero-based position of the rule.",
+							MarkdownDescription: "Zero-based position of the rule.",
+							Computed:            true,
+						},
+						"name": dsschema.StringAttribute{
+							Description:         "Name of the rule.",
+							MarkdownDescription: "Name of the rule.",
+							Computed:            true,
+						},
+						"matched_cost": dsschema.Float64Attribute{
+							Description:         "Cost the rule matches on its own, including costs earlier rules claim.",
+							MarkdownDescription: "Cost the rule matches on its own, including costs earlier rules claim.",
+							Computed:            true,
+						},
+						"allocated_cost": dsschema.Float64Attribute{
+							Description:         "Cost allocated to the rule: matched_cost less the costs earlier rules claim.",
+							MarkdownDescription: "Cost allocated to the rule: `matched_cost` less the costs earlier rules claim.",
+							Computed:            true,
+						},
+						"never_matches": dsschema.BoolAttribute{
+							Description:         "Whether no costs in the analyzed period match the rule.",
+							MarkdownDescription: "Whether no costs in the analyzed period match the rule.",
+							Computed:            true,
+						},
+						"shadowed": dsschema.BoolAttribute{
+							Description:         "Whether the rule matches costs, but earlier rules claim all of them, so it allocates nothing.",
+							MarkdownDescription: "Whether the rule matches costs, but earlier rules claim all of them, so it allocates nothing.",
+							Computed:            true,
+						},
+						"overlaps_with": dsschema.ListAttribute{
+							ElementType:         types.Int64Type,
+							Description:         "Indexes of the other rules that match some of the same costs.",
+							MarkdownDescription: "Indexes of the other rules that match some of the same costs.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"overlaps": dsschema.ListNestedAttribute{
+				Description:         "Pairs of rules that match the same costs. The costs are allocated to first_rule.",
+				MarkdownDescription: "Pairs of rules that match the same costs. The costs are allocated to `first_rule`.",
+				Computed:            true,
+				NestedObject: dsschema.NestedAttributeObject{
+					Attributes: map[string]dsschema.Attribute{
+						"first_rule": dsschema.Int64Attribute{
+							Description:         "Index of the earlier rule, which receives the overlapping costs.",
+							MarkdownDescription: "Index of the earlier rule, which receives the overlapping costs.",
+							Computed:            true,
+						},
+						"second_rule": dsschema.Int64Attribute{
+							Description:         "Index of the later rule.",
+							MarkdownDescription: "Index of the later rule.",
+							Computed:            true,
+						},
+						"cost": dsschema.Float64Attribute{
+							Description:         "Cost both rules match.",
+							MarkdownDescription: "Cost both rules match.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"customer_context": customerContextDataSourceAttribute(),
+			"timeouts":         timeouts.Attributes(ctx),
+		},
+	}
+}
+
+func (d *allocationAnalysisDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*models.ClientWithResponses)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *models.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *allocationAnalysisDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data allocationAnalysisDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, DefaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
+	defer cancel()
+
+	// Rules that depend on unresolved resources cannot be analyzed yet.
+	if !req.Config.Raw.IsFullyKnown() {
+		data.TotalCost = types.Float64Unknown()
+		data.AllocatedCost = types.Float64Unknown()
+		data.UnallocatedCost = types.Float64Unknown()
+		data.UnallocatedCostsShare = types.Float64Unknown()
+		data.RuleResults = types.ListUnknown(types.ObjectType{AttrTypes: allocationRuleResultAttrTypes})
+		data.Overlaps = types.ListUnknown(types.ObjectType{AttrTypes: allocationRuleOverlapAttrTypes})
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	var specs []allocationRuleSpec
+	if !data.AllocationId.IsNull() {
+		specs, diags = d.allocationRules(ctx, data.AllocationId.ValueString())
+	} else {
+		specs, diags = d.inlineRules(ctx, data.Rules)
+	}
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rules, dims, err := compileAnalysisRules(specs)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Allocation Rule",
+			"Could not analyze the allocation rules: "+err.Error(),
+		)
+		return
+	}
+
+	lookbackDays := int64(defaultAnalysisLookbackDays)
+	if !data.LookbackDays.IsNull() {
+		lookbackDays = data.LookbackDays.ValueInt64()
+	}
+
+	totalRows, err := d.query(ctx, lookbackDays, nil, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Running Query",
+			"Could not query the total cost: "+err.Error(),
+		)
+		return
+	}
+	var totalCost float64
+	for _, row := range totalRows {
+		totalCost += row.cost
+	}
+
+	rowsPerRule := make([][]analysisRow, len(rules))
+	for i, rule := range rules {
+		filters := rule.queryFilters()
+		rows, err := d.query(ctx, lookbackDays, dims, filters)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Running Query",
+				fmt.Sprintf("Could not query the costs of rule %d (%s): %v", i, rule.spec.name, err),
+			)
+			return
+		}
+		// Without filters the query returns every row; keep the rule's own.
+		if filters == nil {
+			matched := rows[:0]
+			for _, row := range rows {
+				if rule.matches(row) {
+					matched = append(matched, row)
+				}
+			}
+			rows = matched
+		}
+		rowsPerRule[i] = rows
+	}
+
+	analysis := analyzeAllocationRules(rules, rowsPerRule, totalCost)
+
+	data.TotalCost = types.Float64Value(analysis.totalCost)
+	data.AllocatedCost = types.Float64Value(analysis.allocatedCost)
+	data.UnallocatedCost = types.Float64Value(analysis.unallocatedCost)
+	data.UnallocatedCostsShare = types.Float64Value(analysis.unallocatedShare)
+
+	ruleResults := make([]allocationRuleResultModel, len(analysis.rules))
+	for i, ra := range analysis.rules {
+		overlapsWith, diags := types.ListValueFrom(ctx, types.Int64Type, ra.overlapsWith)
+		resp.Diagnostics.Append(diags...)
+		ruleResults[i] = allocationRuleResultModel{
+			Index:         types.Int64Value(int64(i)),
+			Name:          types.StringValue(specs[i].name),
+			MatchedCost:   types.Float64Value(ra.matchedCost),
+			AllocatedCost: types.Float64Value(ra.allocatedCost),
+			NeverMatches:  types.BoolValue(ra.neverMatches),
+			Shadowed:      types.BoolValue(ra.shadowed),
+			OverlapsWith:  overlapsWith,
+		}
+	}
+	data.RuleResults, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: allocationRuleResultAttrTypes}, ruleResults)
+	resp.Diagnostics.Append(diags...)
+
+	overlaps := make([]allocationRuleOverlapModel, len(analysis.overlaps))
+	for i, o := range analysis.overlaps {
+		overlaps[i] = allocationRuleOverlapModel{
+			FirstRule:  types.Int64Value(int64(o.firstRule)),
+			SecondRule: types.Int64Value(int64(o.secondRule)),
+			Cost:       types.Float64Value(o.cost),
+		}
+	}
+	data.Overlaps, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: allocationRuleOverlapAttrTypes}, overlaps)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// allocationRules reads the rules of an existing allocation. A single-rule
+// allocation is analyzed as one rule.
+func (d *allocationAnalysisDataSource) allocationRules(ctx context.Context, id string) ([]allocationRuleSpec, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	allocation, err := d.getAllocation(ctx, id)
+	if err != nil {
+		diags.AddError(
+			"Error Reading Allocation",
+			"Could not read allocation ID "+id+": "+err.Error(),
+		)
+		return nil, diags
+	}
+
+	if rule := nullableToPointer(allocation.Rule); rule != nil {
+		return []allocationRuleSpec{{
+			name:       derefString(allocation.Name),
+			formula:    rule.Formula,
+			components: rule.Components,
+		}}, diags
+	}
+	if allocation.Rules == nil {
+		diags.AddError(
+			"Error Reading Allocation",
+			"Allocation ID "+id+" has no rules to analyze.",
+		)
+		return nil, diags
+	}
+
+	var specs []allocationRuleSpec
+	for i, n := range *allocation.Rules {
+		rule := nullableToPointer(n)
+		if rule == nil {
+			continue
+		}
+		spec := allocationRuleSpec{name: derefString(rule.Name)}
+		if rule.Formula != nil {
+			spec.formula = *rule.Formula
+		}
+		if rule.Components != nil {
+			spec.components = *rule.Components
+		}
+		// The API often omits the formula and components of group rules;
+		// they live on the single allocation each rule refers to.
+		if (spec.formula == "" || spec.components == nil) && rule.Id != nil {
+			if diags.Append(d.fillRuleFromAllocation(ctx, &spec, *rule.Id)...); diags.HasError() {
+				return nil, diags
+			}
+		}
+		if len(spec.components) == 0 {
+			diags.AddError(
+				"Error Reading Allocation",
+				fmt.Sprintf("Rule %d (%s) of allocation ID %s has no components to analyze.", i, spec.name, id),
+			)
+			return nil, diags
+		}
+		specs = append(specs, spec)
+	}
+	return specs, diags
+}
+
+// inlineRules converts rules configured in the doit_allocation format.
+func (d *allocationAnalysisDataSource) inlineRules(ctx context.Context, rules types.List) ([]allocationRuleSpec, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var values []resource_allocation.RulesValue
+	if diags.Append(rules.ElementsAs(ctx, &values, false)...); diags.HasError() {
+		return nil, diags
+	}
+
+	specs := make([]allocationRuleSpec, len(values))
+	for i, rule := range values {
+		specs[i] = allocationRuleSpec{
+			name:    rule.Name.ValueString(),
+			formula: rule.Formula.ValueString(),
+		}
+		if rule.Action.ValueString() == string(models.Select) {
+			if rule.Id.ValueString() == "" {
+				diags.AddAttributeError(
+					path.Root("rules").AtListIndex(i).AtName("id"),
+					"Missing Allocation ID",
+					"A rule with action \"select\" must set id to the allocation it selects.",
+				)
+				return nil, diags
+			}
+			if diags.Append(d.fillRuleFromAllocation(ctx, &specs[i], rule.Id.ValueString())...); diags.HasError() {
+				return nil, diags
+			}
+			continue
+		}
+
+		if rule.Components.IsNull() {
+			diags.AddAttributeError(
+				path.Root("rules").AtListIndex(i).AtName("components"),
+				"Missing Rule Components",
+				"A rule with action \"create\" or \"update\" must set components.",
+			)
+			return nil, diags
+		}
+		var components []resource_allocation.ComponentsValue
+		if diags.Append(rule.Components.ElementsAs(ctx, &components, false)...); diags.HasError() {
+			return nil, diags
+		}
+		modelComponents, d := convertComponentsToModels(ctx, components)
+		if diags.Append(d...); diags.HasError() {
+			return nil, diags
+		}
+		specs[i].components = modelComponents
+	}
+	return specs, diags
+}
+
+// fillRuleFromAllocation completes spec with the rule of the single-rule
+// allocation id.
+func (d *allocationAnalysisDataSource) fillRuleFromAllocation(ctx context.Context, spec *allocationRuleSpec, id string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	allocation, err := d.getAllocation(ctx, id)
+	if err != nil {
+		diags.AddError(
+			"Error Reading Allocation",
+			"Could not read allocation ID "+id+": "+err.Error(),
+		)
+		return diags
+	}
+	rule := nullableToPointer(allocation.Rule)
+	if rule == nil {
+		diags.AddError(
+			"Error Reading Allocation",
+			"Allocation ID "+id+" is not a single-rule allocation and cannot be used as a rule.",
+		)
+		return diags
+	}
+	if spec.name == "" {
+		spec.name = derefString(allocation.Name)
+	}
+	spec.formula = rule.Formula
+	spec.components = rule.Components
+	return diags
+}
+
+func (d *allocationAnalysisDataSource) getAllocation(ctx context.Context, id string) (*models.Allocation, error) {
+	resp, err := d.client.GetAllocationWithResponse(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != 200 || resp.JSON200 == nil {
+		return nil, fmt.Errorf("status: %d, body: %s", resp.StatusCode(), string(resp.Body))
+	}
+	return resp.JSON200, nil
+}
+
+// query runs a cost query over the last lookbackDays days, grouped by dims
+// and restricted by filters, and returns one row per combination of
+// dimension values.
+func (d *allocationAnalysisDataSource) query(ctx context.Context, lookbackDays int64, dims []analysisDimension, filters []models.ExternalConfigFilter) ([]analysisRow, error) {
+	config := models.ExternalConfig{
+		Aggregation:  new(models.Total),
+		TimeInterval: new(models.ExternalConfigTimeIntervalMonth),
+		Metric:       &models.ExternalMetric{Type: models.ExternalMetricTypeBasic, Value: "cost"},
+		TimeRange: &models.TimeSettings{
+			Mode:           new(models.TimeSettingsModeLast),
+			Amount:         &lookbackDays,
+			Unit:           new(models.TimeSettingsUnitDay),
+			IncludeCurrent: new(false),
+		},
+	}
+	if len(dims) > 0 {
+		groups := make([]models.Group, len(dims))
+		for i, dim := range dims {
+			groups[i] = models.Group{Id: &dim.key, Type: new(models.DimensionsTypes(dim.dimType))}
+		}
+		config.Group = &groups
+	}
+	if len(filters) > 0 {
+		config.Filters = &filters
+	}
+
+	resp, err := d.client.QueryWithResponse(ctx, models.QueryJSONRequestBody{Config: &config})
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != 200 || resp.JSON200 == nil {
+		return nil, fmt.Errorf("status: %d, body: %s", resp.StatusCode(), string(resp.Body))
+	}
+	if resp.JSON200.Result == nil || resp.JSON200.Result.Rows == nil {
+		return nil, nil
+	}
+	return analysisRowsFromResult(*resp.JSON200.Result.Rows, len(dims))
+}
+
+// analysisRowsFromResult sums report rows by their first nDims cells, the
+// group dimensions. The metric is the last cell; the time columns between
+// them are summed over.
+func analysisRowsFromResult(rows [][]nullable.Nullable[models.Value], nDims int) ([]analysisRow, error) {
+	var result []analysisRow
+	index := map[string]int{}
+	for _, row := range rows {
+		if len(row) < nDims+1 {
+			return nil, fmt.Errorf("result row has %d columns, want at least %d", len(row), nDims+1)
+		}
+		cost, err := cellNumber(row[len(row)-1])
+		if err != nil {
+			return nil, err
+		}
+
+		values := make([]*string, nDims)
+		var key strings.Builder
+		for i := range nDims {
+			v, err := cellString(row[i])
+			if err != nil {
+				return nil, err
+			}
+			// Legacy "[... N/A]" sentinels stand for rows without a value.
+			if v != nil && isNAFallback(*v) {
+				v = nil
+			}
+			values[i] = v
+			if v == nil {
+				key.WriteString("\x01")
+			} else {
+				key.WriteString(strconv.Quote(*v))
+			}
+			key.WriteString("\x00")
+		}
+
+		if i, ok := index[key.String()]; ok {
+			result[i].cost += cost
+			continue
+		}
+		index[key.String()] = len(result)
+		result = append(result, analysisRow{values: values, cost: cost})
+	}
+	return result, nil
+}
+
+// cellJSON decodes a result cell, returning nil for a null cell.
+func cellJSON(cell nullable.Nullable[models.Value]) (any, error) {
+	if !cell.IsSpecified() || cell.IsNull() {
+		return nil, nil
+	}
+	raw, err := cell.MustGet().MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var v any
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func cellString(cell nullable.Nullable[models.Value]) (*string, error) {
+	v, err := cellJSON(cell)
+	switch s := v.(type) {
+	case nil:
+		return nil, err
+	case string:
+		return &s, nil
+	default:
+		return new(fmt.Sprint(s)), nil
+	}
+}
+
+func cellNumber(cell nullable.Nullable[models.Value]) (float64, error) {
+	v, err := cellJSON(cell)
+	switch n := v.(type) {
+	case nil:
+		return 0, err
+	case float64:
+		return n, nil
+	case string:
+		return strconv.ParseFloat(n, 64)
+	default:
+		return 0, fmt.Errorf("unexpected metric value %v", v)
+	}
+}
+
+// derefString returns the string p points to, or "" for nil.
+func derefString(p *string) string {
+	if p == nil {
+		return ""
+	}
+	return *p
+}
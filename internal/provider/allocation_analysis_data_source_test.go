@@ -0,0 +1,112 @@
+package provider_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+// TestAccAllocationAnalysisDataSource verifies that inline rules are analyzed
+// and every rule gets a result.
+func TestAccAllocationAnalysisDataSource(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProvidersProtoV6Factories,
+		PreCheck:                 testAccPreCheckFunc(t),
+		TerraformVersionChecks:   testAccTFVersionChecks,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAllocationAnalysisDataSourceConfig(),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.doit_allocation_analysis.test",
+						tfjsonpath.New("total_cost"),
+						knownvalue.NotNull()),
+					statecheck.ExpectKnownValue(
+						"data.doit_allocation_analysis.test",
+						tfjsonpath.New("unallocated_costs_share"),
+						knownvalue.NotNull()),
+					statecheck.ExpectKnownValue(
+						"data.doit_allocation_analysis.test",
+						tfjsonpath.New("rule_results"),
+						knownvalue.ListSizeExact(2)),
+					statecheck.ExpectKnownValue(
+						"data.doit_allocation_analysis.test",
+						tfjsonpath.New("rule_results").AtSliceIndex(1).AtMapKey("name"),
+						knownvalue.StringExact("Amazon")),
+				},
+			},
+		},
+	})
+}
+
+// TestAccAllocationAnalysisDataSource_InvalidFormula verifies that a formula
+// referencing a missing component is rejected before any query runs.
+func TestAccAllocationAnalysisDataSource_InvalidFormula(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProvidersProtoV6Factories,
+		PreCheck:                 testAccPreCheckFunc(t),
+		TerraformVersionChecks:   testAccTFVersionChecks,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "doit_allocation_analysis" "test" {
+  rules = [
+    {
+      action  = "create"
+      name    = "Broken"
+      formula = "A AND B"
+      components = [
+        {
+          type   = "fixed"
+          key    = "cloud_provider"
+          mode   = "is"
+          values = ["google-cloud"]
+        }
+      ]
+    }
+  ]
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Allocation Rule`),
+			},
+		},
+	})
+}
+
+func testAccAllocationAnalysisDataSourceConfig() string {
+	return `
+data "doit_allocation_analysis" "test" {
+  lookback_days = 7
+  rules = [
+    {
+      action = "create"
+      name   = "Google"
+      components = [
+        {
+          type   = "fixed"
+          key    = "cloud_provider"
+          mode   = "is"
+          values = ["google-cloud"]
+        }
+      ]
+    },
+    {
+      action = "create"
+      name   = "Amazon"
+      components = [
+        {
+          type   = "fixed"
+          key    = "cloud_provider"
+          mode   = "is"
+          values = ["amazon-web-services"]
+        }
+      ]
+    }
+  ]
+}
+`
+}
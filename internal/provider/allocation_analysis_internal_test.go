@@ -0,0 +1,310 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/doitintl/terraform-provider-doit/internal/provider/models"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestParseFormula(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		formula string
+		n       int
+		// want lists the component combinations, as bit masks over A, B, C,
+		// for which the formula holds.
+		want []int
+		conj bool
+	}{
+		{formula: "A", n: 1, want: []int{0b1}, conj: true},
+		{formula: "", n: 2, want: []int{0b11}, conj: true},
+		{formula: "A AND B", n: 2, want: []int{0b11}, conj: true},
+		{formula: "a or b", n: 2, want: []int{0b01, 0b10, 0b11}},
+		{formula: "A AND B OR C", n: 3, want: []int{0b011, 0b100, 0b101, 0b110, 0b111}},
+		{formula: "A AND (B OR C)", n: 3, want: []int{0b011, 0b101, 0b111}},
+		{formula: "A AND NOT B", n: 2, want: []int{0b01}},
+	}
+	for _, tt := range tests {
+		expr, err := parseFormula(tt.formula, tt.n)
+		if err != nil {
+			t.Errorf("parseFormula(%q) error = %v", tt.formula, err)
+			continue
+		}
+		var got []int
+		for mask := range 1 << tt.n {
+			components := make([]bool, tt.n)
+			for i := range components {
+				components[i] = mask&(1<<i) != 0
+			}
+			if expr.eval(components) {
+				got = append(got, mask)
+			}
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("parseFormula(%q) holds for %b, want %b", tt.formula, got, tt.want)
+		}
+		if _, conj := conjunctionRefs(expr); conj != tt.conj {
+			t.Errorf("conjunctionRefs(%q) = %v, want %v", tt.formula, conj, tt.conj)
+		}
+	}
+
+	for _, formula := range []string{"A AND", "A B", "(A", "A AND C", "A + B", "AB"} {
+		if _, err := parseFormula(formula, 2); err == nil {
+			t.Errorf("parseFormula(%q) succeeded, want an error", formula)
+		}
+	}
+}
+
+func TestComponentMatcher(t *testing.T) {
+	t.Parallel()
+
+	value := func(s string) *string { return &s }
+	tests := []struct {
+		name      string
+		component models.AllocationComponent
+		matches   []*string
+		misses    []*string
+	}{
+		{
+			name:      "is",
+			component: models.AllocationComponent{Mode: "is", Values: []string{"prod", "staging"}},
+			matches:   []*string{value("prod")},
+			misses:    []*string{value("Prod"), value("dev"), nil},
+		},
+		{
+			name: "starts_with case-insensitive",
+			component: models.AllocationComponent{Mode: "starts_with", Values: []string{"Team-"},
+				CaseInsensitive: new(true)},
+			matches: []*string{value("team-a"), value("TEAM-B")},
+			misses:  []*string{value("my-team-a")},
+		},
+		{
+			name:      "regexp",
+			component: models.AllocationComponent{Mode: "regexp", Values: []string{`^proj-\d+$`}},
+			matches:   []*string{value("proj-42")},
+			misses:    []*string{value("proj-x")},
+		},
+		{
+			name: "inverse with null",
+			component: models.AllocationComponent{Mode: "contains", Values: []string{"test"},
+				Inverse: new(true), IncludeNull: new(true)},
+			matches: []*string{value("prod"), nil},
+			misses:  []*string{value("load-test")},
+		},
+	}
+	for _, tt := range tests {
+		m, err := componentMatcher(tt.component)
+		if err != nil {
+			t.Fatalf("%s: componentMatcher() error = %v", tt.name, err)
+		}
+		for _, v := range tt.matches {
+			if !m(v) {
+				t.Errorf("%s: %v does not match", tt.name, v)
+			}
+		}
+		for _, v := range tt.misses {
+			if m(v) {
+				t.Errorf("%s: %v matches", tt.name, v)
+			}
+		}
+	}
+
+	if _, err := componentMatcher(models.AllocationComponent{Mode: "regexp", Values: []string{"("}}); err == nil {
+		t.Error("componentMatcher accepted an invalid regexp")
+	}
+}
+
+// analysisTestSpecs are four rules over the project and env dimensions:
+// rule 0 claims project "shared", rule 1 overlaps it on env "prod", and
+// rule 2 is entirely covered by rule 0, and rule 3 matches no cost.
+func analysisTestSpecs() []allocationRuleSpec {
+	return []allocationRuleSpec{
+		{name: "shared", components: []models.AllocationComponent{
+			{Type: "fixed", Key: "project_id", Mode: "is", Values: []string{"shared"}},
+		}},
+		{name: "prod", components: []models.AllocationComponent{
+			{Type: "label", Key: "env", Mode: "is", Values: []string{"prod"}},
+		}},
+		{name: "shared-prod", formula: "A AND B", components: []models.AllocationComponent{
+			{Type: "fixed", Key: "project_id", Mode: "is", Values: []string{"shared"}},
+			{Type: "label", Key: "env", Mode: "is", Values: []string{"prod"}},
+		}},
+		{name: "retired", components: []models.AllocationComponent{
+			{Type: "fixed", Key: "project_id", Mode: "is", Values: []string{"old"}},
+		}},
+	}
+}
+
+func TestAnalyzeAllocationRules(t *testing.T) {
+	t.Parallel()
+
+	rules, dims, err := compileAnalysisRules(analysisTestSpecs())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []analysisDimension{{"fixed", "project_id"}, {"label", "env"}}; !slices.Equal(dims, want) {
+		t.Fatalf("dims = %v, want %v", dims, want)
+	}
+
+	value := func(s string) *string { return &s }
+	sharedProd := analysisRow{values: []*string{value("shared"), value("prod")}, cost: 30}
+	sharedDev := analysisRow{values: []*string{value("shared"), value("dev")}, cost: 20}
+	appProd := analysisRow{values: []*string{value("app"), value("prod")}, cost: 40}
+	rowsPerRule := [][]analysisRow{
+		{sharedProd, sharedDev},
+		{sharedProd, appProd},
+		{sharedProd},
+		nil,
+	}
+
+	got := analyzeAllocationRules(rules, rowsPerRule, 100)
+
+	if got.totalCost != 100 || got.allocatedCost != 90 || got.unallocatedCost != 10 || got.unallocatedShare != 0.1 {
+		t.Errorf("totals = %v/%v/%v/%v, want 100/90/10/0.1",
+			got.totalCost, got.allocatedCost, got.unallocatedCost, got.unallocatedShare)
+	}
+	want := []ruleAnalysis{
+		{matchedCost: 50, allocatedCost: 50, overlapsWith: []int{1, 2}},
+		{matchedCost: 70, allocatedCost: 40, overlapsWith: []int{0, 2}},
+		{matchedCost: 30, allocatedCost: 0, shadowed: true, overlapsWith: []int{0, 1}},
+		{neverMatches: true},
+	}
+	for i, w := range want {
+		g := got.rules[i]
+		if g.matchedCost != w.matchedCost || g.allocatedCost != w.allocatedCost || g.shadowed != w.shadowed ||
+			g.neverMatches != w.neverMatches || !slices.Equal(g.overlapsWith, w.overlapsWith) {
+			t.Errorf("rule %d = %+v, want %+v", i, g, w)
+		}
+	}
+	wantOverlaps := []ruleOverlap{{0, 1, 30}, {0, 2, 30}, {1, 2, 30}}
+	if !slices.Equal(got.overlaps, wantOverlaps) {
+		t.Errorf("overlaps = %v, want %v", got.overlaps, wantOverlaps)
+	}
+}
+
+func TestAllocationAnalysisDataSource_Read(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	var queries []models.ExternalConfig
+	client := newImportTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/analytics/v1/allocations/group-1":
+			rules := make([]map[string]any, 0, 4)
+			for _, spec := range analysisTestSpecs() {
+				rules = append(rules, map[string]any{"action": "create", "name": spec.name, "formula": spec.formula,
+					"components": spec.components})
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "group-1", "name": "Teams", "rules": rules})
+		case r.Method == http.MethodPost && r.URL.Path == "/analytics/v1/reports/query":
+			var body models.QueryJSONRequestBody
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decoding query: %v", err)
+			}
+			queries = append(queries, *body.Config)
+			_, _ = io.WriteString(w, analysisTestQueryResult(*body.Config))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	d := &allocationAnalysisDataSource{client: client}
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("Schema() diagnostics: %v", schemaResp.Diagnostics)
+	}
+	sch := schemaResp.Schema
+	if diags := sch.ValidateImplementation(ctx); diags.HasError() {
+		t.Fatalf("invalid schema: %v", diags)
+	}
+
+	// Build the configuration through a state, which supports SetAttribute.
+	config := tfsdk.State{Raw: tftypes.NewValue(sch.Type().TerraformType(ctx), nil), Schema: sch}
+	if diags := config.SetAttribute(ctx, path.Root("allocation_id"), types.StringValue("group-1")); diags.HasError() {
+		t.Fatalf("setting config: %v", diags)
+	}
+	resp := datasource.ReadResponse{State: tfsdk.State{Raw: config.Raw, Schema: sch}}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Raw: config.Raw, Schema: sch}}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read() diagnostics: %v", resp.Diagnostics)
+	}
+
+	// One total query and one per rule; the conjunctive rules filter in the
+	// API, grouped by both dimensions.
+	if len(queries) != 5 {
+		t.Fatalf("ran %d queries, want 5", len(queries))
+	}
+	if queries[0].Group != nil || queries[0].Filters != nil {
+		t.Errorf("total query = %+v, want no groups or filters", queries[0])
+	}
+	if q := queries[3]; q.Group == nil || len(*q.Group) != 2 || q.Filters == nil || len(*q.Filters) != 2 {
+		t.Errorf("query of rule 2 = %+v, want 2 groups and 2 filters", q)
+	}
+
+	var got allocationAnalysisDataSourceModel
+	if diags := resp.State.Get(ctx, &got); diags.HasError() {
+		t.Fatalf("reading state: %v", diags)
+	}
+	if got.TotalCost.ValueFloat64() != 100 || got.UnallocatedCostsShare.ValueFloat64() != 0.1 {
+		t.Errorf("total_cost = %v, unallocated_costs_share = %v; want 100, 0.1", got.TotalCost, got.UnallocatedCostsShare)
+	}
+	var results []allocationRuleResultModel
+	if diags := got.RuleResults.ElementsAs(ctx, &results, false); diags.HasError() {
+		t.Fatalf("reading rule_results: %v", diags)
+	}
+	if len(results) != 4 || !results[2].Shadowed.ValueBool() || !results[3].NeverMatches.ValueBool() ||
+		results[1].AllocatedCost.ValueFloat64() != 40 || results[0].Name.ValueString() != "shared" {
+		t.Errorf("rule_results = %+v", results)
+	}
+	if n := len(got.Overlaps.Elements()); n != 3 {
+		t.Errorf("got %d overlaps, want 3", n)
+	}
+}
+
+// analysisTestQueryResult answers a query over the rows of
+// TestAnalyzeAllocationRules, split across two months, applying the "is"
+// filters of the query.
+func analysisTestQueryResult(config models.ExternalConfig) string {
+	type row struct{ project, env string }
+	costs := map[row]float64{{"shared", "prod"}: 30, {"shared", "dev"}: 20, {"app", "prod"}: 40, {"app", "dev"}: 10}
+
+	var rows []string
+	for r, cost := range costs {
+		keep := true
+		if config.Filters != nil {
+			for _, f := range *config.Filters {
+				v := map[string]string{"project_id": r.project, "env": r.env}[f.Id]
+				keep = keep && slices.Contains(*f.Values, v)
+			}
+		}
+		if !keep {
+			continue
+		}
+		var cells []string
+		if config.Group != nil {
+			for _, g := range *config.Group {
+				cells = append(cells, `"`+map[string]string{"project_id": r.project, "env": r.env}[*g.Id]+`"`)
+			}
+		}
+		for _, month := range []string{"05", "06"} {
+			half, _ := json.Marshal(cost / 2)
+			rows = append(rows, "["+strings.Join(append(slices.Clone(cells), `"2026"`, `"`+month+`"`, string(half)), ",")+"]")
+		}
+	}
+	return `{"result":{"rows":[` + strings.Join(rows, ",") + `]}}`
+}
@@ -26,7 +26,8 @@ type (
 	}
 	allocationDataSourceModel struct {
 		datasource_allocation.AllocationModel
-		Timeouts timeouts.Value `tfsdk:"timeouts"`
+		CustomerContext types.String   `tfsdk:"customer_context"`
+		Timeouts        timeouts.Value `tfsdk:"timeouts"`
 	}
 )
 
@@ -37,6 +38,7 @@ func (ds *allocationDataSource) Metadata(_ context.Context, req datasource.Metad
 func (ds *allocationDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	s := datasource_allocation.AllocationDataSourceSchema(ctx)
 
+	s.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx)
 
 	resp.Schema = s
@@ -74,6 +76,7 @@ func (ds *allocationDataSource) Read(ctx context.Context, req datasource.ReadReq
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	// If ID is unknown (depends on a resource not yet created), set all computed
@@ -8,6 +8,7 @@ import (
 	"github.com/doitintl/terraform-provider-doit/internal/provider/models"
 	"github.com/doitintl/terraform-provider-doit/internal/provider/resource_allocation"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -18,7 +19,7 @@ func TestToAllocationRuleComponentsListValue_EmptySlice(t *testing.T) {
 	ctx := context.Background()
 
 	// This must not panic
-	result, diags := toAllocationRuleComponentsListValue(ctx, []models.AllocationComponent{}, nil)
+	result, diags := toAllocationRuleComponentsListValue(ctx, path.Root("rule").AtName("components"), []models.AllocationComponent{}, nil)
 
 	if diags.HasError() {
 		t.Fatalf("unexpected diagnostics: %v", diags)
@@ -20,11 +20,20 @@ import (
 
 type (
 	allocationResource struct {
-		client *models.ClientWithResponses
+		client                       *models.ClientWithResponses
+		readOnly                     bool
+		detectConcurrentModification bool
+		defaultLabels                []string
+		deletionProtection           bool
+		validateDimensions           bool
+		explainDrift                 bool
+		validateReferences           bool
 	}
 	allocationResourceModel struct {
 		resource_allocation.AllocationModel
-		Timeouts timeouts.Value `tfsdk:"timeouts"`
+		DeletionProtection types.Bool     `tfsdk:"deletion_protection"`
+		CustomerContext    types.String   `tfsdk:"customer_context"`
+		Timeouts           timeouts.Value `tfsdk:"timeouts"`
 	}
 )
 
@@ -48,20 +57,32 @@ func (r *allocationResource) Configure(_ context.Context, req resource.Configure
 		return
 	}
 
-	client, ok := req.ProviderData.(*models.ClientWithResponses)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *models.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.readOnly = data.readOnly
+	r.detectConcurrentModification = data.detectConcurrentModification
+	r.deletionProtection = data.deletionProtection
+	r.defaultLabels = data.defaultLabels
+	r.validateDimensions = data.validateDimensions
+	r.explainDrift = data.explainDrift
+	r.validateReferences = data.validateReferences
 }
 
 func (r *allocationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	ctx, id, diags := importCustomerContext(ctx, req, resp, 1)
+	resp.Diagnostics.Append(diags...)
+	req.ID = id
+
+	// Accepts either an ID or "name:<exact name>".
+	importStatePassthroughIDOrName(ctx, req, resp, "allocation", nameLookup(r.client, listAllocations))
 }
 
 func (r *allocationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -150,6 +171,9 @@ func (r *allocationResource) Schema(ctx context.Context, _ resource.SchemaReques
 		s.Attributes["anomaly_detection"] = attr
 	}
 
+	s.Attributes["deletion_protection"] = deletionProtectionAttribute()
+
+	s.Attributes["customer_context"] = customerContextResourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx, timeouts.Opts{
 		Create: true,
 		Read:   true,
@@ -185,6 +209,11 @@ func (r *allocationResource) ConfigValidators(_ context.Context) []resource.Conf
 }
 
 func (r *allocationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_allocation", "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan allocationResourceModel
 
 	diags := req.Plan.Get(ctx, &plan)
@@ -199,6 +228,7 @@ func (r *allocationResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	ctx = withCustomerContext(ctx, plan.CustomerContext)
 	defer cancel()
 
 	// Generate API request body from state
@@ -211,10 +241,7 @@ func (r *allocationResource) Create(ctx context.Context, req resource.CreateRequ
 	// Save state into Terraform state
 	allocationResp, err := r.client.CreateAllocationWithResponse(ctx, allocationReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating allocation",
-			"Could not create allocation, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error creating allocation", "Could not create allocation", err)...)
 		return
 	}
 
@@ -252,6 +279,8 @@ func (r *allocationResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
+	resp.Diagnostics.Append(assignDefaultLabels(ctx, r.client, r.defaultLabels, models.LabelAssignmentObjectObjectTypeAllocation, plan.Id.ValueString())...)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -269,7 +298,12 @@ func (r *allocationResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
+	var drift *driftExplanations
+	if r.explainDrift {
+		ctx, drift = withDriftExplanations(ctx)
+	}
 
 	diags = r.populateState(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -283,10 +317,17 @@ func (r *allocationResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	resp.Diagnostics.Append(drift.diagnostics()...)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
 func (r *allocationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_allocation", "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan allocationResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -300,6 +341,7 @@ func (r *allocationResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	ctx = withCustomerContext(ctx, plan.CustomerContext)
 	defer cancel()
 
 	// Generate API request body from plan
@@ -325,13 +367,17 @@ func (r *allocationResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
+	if r.detectConcurrentModification {
+		resp.Diagnostics.Append(r.checkConcurrentModification(ctx, req.State)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// Update the allocation
 	updateResp, err := r.client.UpdateAllocationWithResponse(ctx, stateId.ValueString(), allocation)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error updating allocation",
-			"Could not update allocation, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error updating allocation", "Could not update allocation", err)...)
 		return
 	}
 
@@ -370,6 +416,11 @@ func (r *allocationResource) Update(ctx context.Context, req resource.UpdateRequ
 }
 
 func (r *allocationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_allocation", "delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var state allocationResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -377,20 +428,25 @@ func (r *allocationResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
+	resp.Diagnostics.Append(checkDeletionProtection(state.DeletionProtection, r.deletionProtection, "allocation", state.Id.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	deleteTimeout, diags := state.Timeouts.Delete(ctx, DefaultDeleteTimeout)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
 
+	resp.Diagnostics.Append(unassignDefaultLabels(ctx, r.client, r.defaultLabels, models.LabelAssignmentObjectObjectTypeAllocation, state.Id.ValueString())...)
+
 	deleteResp, err := r.client.DeleteAllocationWithResponse(ctx, state.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Deleting DoiT Allocation",
-			"Could not delete allocation, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Deleting DoiT Allocation", "Could not delete allocation", err)...)
 		return
 	}
 
@@ -414,8 +470,22 @@ func (r *allocationResource) Delete(ctx context.Context, req resource.DeleteRequ
 // formula, and components), the rule cannot be matched and a warning is emitted for
 // action="update" rules — set the id attribute explicitly in that case.
 func (r *allocationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
-	// Skip if state or plan is null (e.g. resource creation or deletion)
-	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+	if req.Plan.Raw.IsNull() {
+		return // destroy
+	}
+
+	if r.validateDimensions {
+		resp.Diagnostics.Append(validateDimensionFilters(ctx, r.client, req, "key",
+			path.MatchRoot("rule").AtName("components").AtAnyListIndex(),
+			path.MatchRoot("rules").AtAnyListIndex().AtName("components").AtAnyListIndex())...)
+	}
+	if r.validateReferences {
+		resp.Diagnostics.Append(validateReferences(ctx, r.client, req.Plan, req.State,
+			referenceCheck{expr: path.MatchRoot("folder_id"), kind: "folder"})...)
+	}
+
+	// Skip the rest on create (no prior state)
+	if req.State.Raw.IsNull() {
 		return
 	}
 
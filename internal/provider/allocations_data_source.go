@@ -25,7 +25,8 @@ type allocationsDataSource struct {
 
 type allocationsDataSourceModel struct {
 	datasource_allocations.AllocationsModel
-	Timeouts timeouts.Value `tfsdk:"timeouts"`
+	CustomerContext types.String   `tfsdk:"customer_context"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (d *allocationsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -35,6 +36,7 @@ func (d *allocationsDataSource) Metadata(ctx context.Context, req datasource.Met
 func (d *allocationsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	s := datasource_allocations.AllocationsDataSourceSchema(ctx)
 
+	s.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx)
 
 	resp.Schema = s
@@ -71,6 +73,7 @@ func (d *allocationsDataSource) Read(ctx context.Context, req datasource.ReadReq
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	// If any filter/pagination input is unknown, return unknown list
@@ -16,9 +16,7 @@ import (
 func (r *annotationResource) populateState(ctx context.Context, state *annotationResourceModel) diag.Diagnostics {
 	annotationResp, err := r.client.GetAnnotationWithResponse(ctx, state.Id.ValueString())
 	if err != nil {
-		return diag.Diagnostics{
-			diag.NewErrorDiagnostic("Error Reading Annotation", "Could not read annotation ID "+state.Id.ValueString()+": "+err.Error()),
-		}
+		return apiErrorDiagnostics("Error Reading Annotation", "Could not read annotation ID "+state.Id.ValueString(), err)
 	}
 
 	if annotationResp.StatusCode() == 404 {
@@ -26,7 +26,8 @@ type (
 	}
 	annotationDataSourceModel struct {
 		datasource_annotation.AnnotationModel
-		Timeouts timeouts.Value `tfsdk:"timeouts"`
+		CustomerContext types.String   `tfsdk:"customer_context"`
+		Timeouts        timeouts.Value `tfsdk:"timeouts"`
 	}
 )
 
@@ -37,6 +38,7 @@ func (d *annotationDataSource) Metadata(_ context.Context, req datasource.Metada
 func (d *annotationDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	s := datasource_annotation.AnnotationDataSourceSchema(ctx)
 
+	s.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx)
 
 	resp.Schema = s
@@ -74,6 +76,7 @@ func (d *annotationDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	// If ID is unknown (depends on a resource not yet created), set all computed
@@ -7,19 +7,25 @@ import (
 	"github.com/doitintl/terraform-provider-doit/internal/provider/models"
 	"github.com/doitintl/terraform-provider-doit/internal/provider/resource_annotation"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 type (
 	annotationResource struct {
-		client *models.ClientWithResponses
+		client             *models.ClientWithResponses
+		readOnly           bool
+		defaultLabels      []string
+		validateReferences bool
 	}
 	annotationResourceModel struct {
 		resource_annotation.AnnotationModel
-		Timeouts timeouts.Value `tfsdk:"timeouts"`
+		CustomerContext types.String   `tfsdk:"customer_context"`
+		Timeouts        timeouts.Value `tfsdk:"timeouts"`
 	}
 )
 
@@ -28,6 +34,7 @@ var (
 	_ resource.Resource                = (*annotationResource)(nil)
 	_ resource.ResourceWithConfigure   = (*annotationResource)(nil)
 	_ resource.ResourceWithImportState = (*annotationResource)(nil)
+	_ resource.ResourceWithModifyPlan  = (*annotationResource)(nil)
 )
 
 // NewAnnotationResource creates a new annotation resource instance.
@@ -41,16 +48,30 @@ func (r *annotationResource) Configure(_ context.Context, req resource.Configure
 		return
 	}
 
-	client, ok := req.ProviderData.(*models.ClientWithResponses)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *models.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.readOnly = data.readOnly
+	r.defaultLabels = data.defaultLabels
+	r.validateReferences = data.validateReferences
+}
+
+// ModifyPlan checks that the planned reports and labels exist unless the
+// provider's skip_reference_validation is set.
+func (r *annotationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if !r.validateReferences {
+		return
+	}
+	resp.Diagnostics.Append(validateReferences(ctx, r.client, req.Plan, req.State,
+		referenceCheck{expr: path.MatchRoot("reports").AtAnyListIndex(), kind: "report"},
+		referenceCheck{expr: path.MatchRoot("labels").AtAnyListIndex(), kind: "label"})...)
 }
 
 func (r *annotationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -58,6 +79,10 @@ func (r *annotationResource) Metadata(_ context.Context, req resource.MetadataRe
 }
 
 func (r *annotationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, id, diags := importCustomerContext(ctx, req, resp, 1)
+	resp.Diagnostics.Append(diags...)
+	req.ID = id
+
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
@@ -93,6 +118,7 @@ func (r *annotationResource) Schema(ctx context.Context, _ resource.SchemaReques
 		"reports", // managed externally via doit_label_assignments
 	)
 
+	s.Attributes["customer_context"] = customerContextResourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx, timeouts.Opts{
 		Create: true,
 		Read:   true,
@@ -104,6 +130,11 @@ func (r *annotationResource) Schema(ctx context.Context, _ resource.SchemaReques
 }
 
 func (r *annotationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_annotation", "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan annotationResourceModel
 
 	// Read Terraform plan data into the model
@@ -118,6 +149,7 @@ func (r *annotationResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	ctx = withCustomerContext(ctx, plan.CustomerContext)
 	defer cancel()
 
 	// Build API request
@@ -130,10 +162,7 @@ func (r *annotationResource) Create(ctx context.Context, req resource.CreateRequ
 	// Create new annotation via API
 	annotationResp, err := r.client.CreateAnnotationWithResponse(ctx, apiReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Creating Annotation",
-			"Could not create annotation, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Creating Annotation", "Could not create annotation", err)...)
 		return
 	}
 
@@ -161,6 +190,8 @@ func (r *annotationResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
+	resp.Diagnostics.Append(assignDefaultLabels(ctx, r.client, r.defaultLabels, models.LabelAssignmentObjectObjectTypeAnnotation, annotationResp.JSON201.Id)...)
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
@@ -180,8 +211,10 @@ func (r *annotationResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
 
+	configuredLabels := state.Labels
 	diags := r.populateState(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -194,11 +227,22 @@ func (r *annotationResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	state.Labels, diags = withoutDefaultLabels(ctx, state.Labels, configuredLabels, r.defaultLabels)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
 func (r *annotationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_annotation", "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan annotationResourceModel
 
 	// Read Terraform plan data
@@ -213,6 +257,7 @@ func (r *annotationResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	ctx = withCustomerContext(ctx, plan.CustomerContext)
 	defer cancel()
 
 	// Get the ID from the state
@@ -223,8 +268,20 @@ func (r *annotationResource) Update(ctx context.Context, req resource.UpdateRequ
 	}
 	annotationID := state.Id.ValueString()
 
+	// Send the default labels the annotation still has along with the
+	// configured ones; otherwise the PATCH would unassign them. The plan
+	// itself keeps only the configured labels.
+	configuredLabels := plan.Labels
+	reqPlan := plan
+	var labelDiags diag.Diagnostics
+	reqPlan.Labels, labelDiags = withDefaultLabels(ctx, plan.Labels, r.defaultLabels, annotationLabels(r.client, annotationID))
+	resp.Diagnostics.Append(labelDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Build API request
-	apiReq, buildDiags := plan.toUpdateRequest(ctx)
+	apiReq, buildDiags := reqPlan.toUpdateRequest(ctx)
 	resp.Diagnostics.Append(buildDiags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -233,10 +290,7 @@ func (r *annotationResource) Update(ctx context.Context, req resource.UpdateRequ
 	// Update annotation via API
 	updateResp, err := r.client.UpdateAnnotationWithResponse(ctx, annotationID, apiReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Updating Annotation",
-			"Could not update annotation, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Updating Annotation", "Could not update annotation", err)...)
 		return
 	}
 
@@ -264,11 +318,22 @@ func (r *annotationResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
+	plan.Labels, labelDiags = withoutDefaultLabels(ctx, plan.Labels, configuredLabels, r.defaultLabels)
+	resp.Diagnostics.Append(labelDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
 func (r *annotationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_annotation", "delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var state annotationResourceModel
 
 	// Read Terraform prior state data into the model
@@ -283,15 +348,15 @@ func (r *annotationResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
 
+	resp.Diagnostics.Append(unassignDefaultLabels(ctx, r.client, r.defaultLabels, models.LabelAssignmentObjectObjectTypeAnnotation, state.Id.ValueString())...)
+
 	// Delete annotation via API
 	deleteResp, err := r.client.DeleteAnnotationWithResponse(ctx, state.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Deleting DoiT Annotation",
-			"Could not delete annotation, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Deleting DoiT Annotation", "Could not delete annotation", err)...)
 		return
 	}
 
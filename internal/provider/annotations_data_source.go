@@ -26,7 +26,8 @@ type annotationsDataSource struct {
 
 type annotationsDataSourceModel struct {
 	datasource_annotations.AnnotationsModel
-	Timeouts timeouts.Value `tfsdk:"timeouts"`
+	CustomerContext types.String   `tfsdk:"customer_context"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (d *annotationsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -36,6 +37,7 @@ func (d *annotationsDataSource) Metadata(ctx context.Context, req datasource.Met
 func (d *annotationsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	s := datasource_annotations.AnnotationsDataSourceSchema(ctx)
 
+	s.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx)
 
 	resp.Schema = s
@@ -72,6 +74,7 @@ func (d *annotationsDataSource) Read(ctx context.Context, req datasource.ReadReq
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	// If any filter/pagination input is unknown, return unknown list
@@ -27,7 +27,8 @@ type anomaliesDataSource struct {
 
 type anomaliesDataSourceModel struct {
 	datasource_anomalies.AnomaliesModel
-	Timeouts timeouts.Value `tfsdk:"timeouts"`
+	CustomerContext types.String   `tfsdk:"customer_context"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (d *anomaliesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -37,6 +38,7 @@ func (d *anomaliesDataSource) Metadata(ctx context.Context, req datasource.Metad
 func (d *anomaliesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	s := datasource_anomalies.AnomaliesDataSourceSchema(ctx)
 
+	s.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx)
 
 	resp.Schema = s
@@ -73,6 +75,7 @@ func (d *anomaliesDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	// If any filter/pagination input is unknown, return unknown list and unknown computed attributes
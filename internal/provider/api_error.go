@@ -0,0 +1,241 @@
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/doitintl/terraform-provider-doit/internal/provider/models"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// maxAPIErrorBody bounds how much of an unparseable error body is kept as the
+// message.
+const maxAPIErrorBody = 1024
+
+// APIError is an error response from the DoiT API. DCIRetryClient returns it
+// for every status it neither retries nor passes through to the caller.
+//
+// The API describes errors with RFC 9457 problem details (see
+// models.ProblemDetails); some endpoints still answer with the older
+// {"error": "..."} body. Both are parsed, and any other body is kept as the
+// message verbatim.
+type APIError struct {
+	// StatusCode is the HTTP status of the response.
+	StatusCode int
+	// Code is the API's machine-readable error code, e.g.
+	// "validation_failed". Empty for older error bodies.
+	Code string
+	// Message is the human-readable description of the error.
+	Message string
+	// Fields lists the request fields the API rejected, if any.
+	Fields []APIErrorField
+	// RequestID identifies the request for DoiT support.
+	RequestID string
+	// DocsURL links to documentation of the error, if the API sent one.
+	DocsURL string
+}
+
+// APIErrorField is one issue the API reported with a request.
+type APIErrorField struct {
+	// Field is the API's name of the rejected field, e.g.
+	// "config.metrics[0].type". Empty when the issue concerns the request
+	// as a whole.
+	Field string
+	// Issue is the machine-readable issue, e.g. "invalid_value".
+	Issue string
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "API error %d", e.StatusCode)
+	if e.Code != "" {
+		fmt.Fprintf(&b, " (%s)", e.Code)
+	}
+	if e.Message != "" {
+		b.WriteString(": " + e.Message)
+	}
+	for _, f := range e.Fields {
+		if f.Field != "" {
+			fmt.Fprintf(&b, "; %s: %s", f.Field, f.Issue)
+		} else {
+			b.WriteString("; " + f.Issue)
+		}
+	}
+	return b.String()
+}
+
+// newAPIError parses an error response.
+func newAPIError(status int, header http.Header, body []byte) *APIError {
+	e := &APIError{
+		StatusCode: status,
+		RequestID:  header.Get("Request-Id"),
+	}
+
+	var problem models.ProblemDetails
+	if json.Unmarshal(body, &problem) == nil && (problem.Code != "" || problem.Detail != "" || problem.Title != "") {
+		e.Code = problem.Code
+		e.Message = problem.Detail
+		if e.Message == "" {
+			e.Message = problem.Title
+		}
+		if problem.Details != nil {
+			for _, d := range *problem.Details {
+				f := APIErrorField{Issue: d.Issue}
+				if d.Field != nil {
+					f.Field = *d.Field
+				}
+				e.Fields = append(e.Fields, f)
+			}
+		}
+		if e.RequestID == "" {
+			e.RequestID = problem.Instance
+		}
+		if docs, err := problem.DocsUrl.Get(); err == nil {
+			e.DocsURL = docs
+		}
+		return e
+	}
+
+	var legacy struct {
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(body, &legacy) == nil && (legacy.Error != "" || legacy.Message != "") {
+		e.Message = legacy.Error
+		if e.Message == "" {
+			e.Message = legacy.Message
+		}
+		return e
+	}
+
+	e.Message = strings.TrimSpace(string(body))
+	if len(e.Message) > maxAPIErrorBody {
+		e.Message = strings.ToValidUTF8(e.Message[:maxAPIErrorBody], "") + "..."
+	}
+	if e.Message == "" {
+		e.Message = http.StatusText(status)
+	}
+	return e
+}
+
+// hint suggests how to resolve errors whose cause usually lies in the
+// provider configuration rather than in the resource.
+func (e *APIError) hint() string {
+	const customerContextHint = "If the API key can access more than one customer, for example as a DoiT " +
+		"employee, check that customer_context, on the provider or on this resource, names the intended customer."
+
+	switch {
+	case e.StatusCode == http.StatusUnauthorized:
+		return "The DoiT API did not accept the API key. Check that api_token, DOIT_API_TOKEN, api_token_file or " +
+			"api_token_command provides a current key; keys that were revoked or belong to a removed user stop working."
+	case e.StatusCode == http.StatusForbidden:
+		return "The API key is valid but not allowed to perform this operation. Check that the key's user has a " +
+			"role with the permission this object type requires. " + customerContextHint
+	case e.Code == "tenant_id_required" || e.Code == "tenant_id_mismatch":
+		return customerContextHint
+	default:
+		return ""
+	}
+}
+
+// apiErrorDiagnostics reports err, returned by an API call, as diagnostics.
+// summary and detail describe what failed, e.g. "Error Creating Folder" and
+// "Could not create folder".
+//
+// An *APIError that names request fields yields one diagnostic per field,
+// attached to the matching attribute so Terraform points at the offending
+// configuration line. Authentication and permission errors carry a hint about
+// the provider configuration. Any other error is reported with its message.
+func apiErrorDiagnostics(summary, detail string, err error) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		diags.AddError(summary, detail+": "+err.Error())
+		return diags
+	}
+
+	var b strings.Builder
+	b.WriteString(detail + ": " + apiErr.Error())
+	if hint := apiErr.hint(); hint != "" {
+		b.WriteString("\n\n" + hint)
+	}
+	if apiErr.DocsURL != "" {
+		b.WriteString("\n\nSee " + apiErr.DocsURL)
+	}
+	if apiErr.RequestID != "" {
+		b.WriteString("\n\nRequest ID: " + apiErr.RequestID)
+	}
+	text := b.String()
+
+	for _, f := range apiErr.Fields {
+		if p, ok := apiFieldPath(f.Field); ok {
+			diags.AddAttributeError(p, summary, text)
+		}
+	}
+	if len(diags) == 0 {
+		diags.AddError(summary, text)
+	}
+	return diags
+}
+
+// apiFieldSegment matches one step of an API field name: a property, or a
+// bracketed list index.
+var apiFieldSegment = regexp.MustCompile(`[^.\[\]/]+|\[\d+\]`)
+
+// apiFieldPath converts an API field name such as "config.metrics[0].type"
+// or the JSON pointer "/config/metrics/0/type" to the attribute path
+// config.metrics[0].type. Schemas generated from the OpenAPI spec name
+// attributes after the snake_case form of the API's camelCase properties.
+func apiFieldPath(field string) (path.Path, bool) {
+	field = strings.TrimPrefix(field, "$.")
+	segments := apiFieldSegment.FindAllString(field, -1)
+	if len(segments) == 0 {
+		return path.Empty(), false
+	}
+
+	var p path.Path
+	for i, s := range segments {
+		index, err := strconv.Atoi(strings.Trim(s, "[]"))
+		switch {
+		case err == nil && i == 0:
+			return path.Empty(), false
+		case err == nil:
+			p = p.AtListIndex(index)
+		case i == 0:
+			p = path.Root(snakeCase(s))
+		default:
+			p = p.AtName(snakeCase(s))
+		}
+	}
+	return p, true
+}
+
+// snakeCase converts a camelCase API property name to snake_case, keeping
+// acronyms together: "parentFolderId" becomes "parent_folder_id", "urlUI"
+// becomes "url_ui" and "scopeIDs" becomes "scope_ids".
+func snakeCase(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			// The last capital of an acronym starts a new word ("URLPath"),
+			// unless it is followed by a plural s ("scopeIDs").
+			startsWord := i+1 < len(runes) && unicode.IsLower(runes[i+1]) &&
+				(runes[i+1] != 's' || i+2 < len(runes) && unicode.IsLower(runes[i+2]))
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && startsWord) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,216 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func TestNewAPIError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		status int
+		header http.Header
+		body   string
+		want   string
+		fields []APIErrorField
+		reqID  string
+	}{
+		{
+			name:   "problem details",
+			status: 400,
+			body: `{"type":"https://developer.doit.com/errors/validation_failed","title":"Validation failed","status":400,
+				"detail":"The request contains an invalid value.","instance":"https://api.doit.com/requests/req_1",
+				"code":"validation_failed","retryable":false,
+				"details":[{"field":"config.metrics[0].type","issue":"invalid_value"},{"issue":"too_many_filters"}]}`,
+			want: "API error 400 (validation_failed): The request contains an invalid value.; " +
+				"config.metrics[0].type: invalid_value; too_many_filters",
+			fields: []APIErrorField{{Field: "config.metrics[0].type", Issue: "invalid_value"}, {Issue: "too_many_filters"}},
+			reqID:  "https://api.doit.com/requests/req_1",
+		},
+		{
+			name:   "request id header wins",
+			status: 404,
+			header: http.Header{"Request-Id": {"req_2"}},
+			body:   `{"title":"Resource not found","status":404,"code":"not_found"}`,
+			want:   "API error 404 (not_found): Resource not found",
+			reqID:  "req_2",
+		},
+		{
+			name:   "legacy error body",
+			status: 400,
+			body:   `{"error":"THREE_LIMITS_NOT_ALLOWED: at most two limits are supported"}`,
+			want:   "API error 400: THREE_LIMITS_NOT_ALLOWED: at most two limits are supported",
+		},
+		{
+			name:   "plain text",
+			status: 500,
+			body:   "upstream connect error\n",
+			want:   "API error 500: upstream connect error",
+		},
+		{
+			name:   "empty body",
+			status: 524,
+			want:   "API error 524",
+		},
+		{
+			name:   "empty body with known status",
+			status: 403,
+			want:   "API error 403: Forbidden",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			header := tt.header
+			if header == nil {
+				header = http.Header{}
+			}
+			e := newAPIError(tt.status, header, []byte(tt.body))
+			if got := e.Error(); got != tt.want {
+				t.Errorf("Error() = %q, want %q", got, tt.want)
+			}
+			if fmt.Sprint(e.Fields) != fmt.Sprint(tt.fields) {
+				t.Errorf("Fields = %v, want %v", e.Fields, tt.fields)
+			}
+			if e.RequestID != tt.reqID {
+				t.Errorf("RequestID = %q, want %q", e.RequestID, tt.reqID)
+			}
+		})
+	}
+}
+
+func TestAPIFieldPath(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]path.Path{
+		"name":                      path.Root("name"),
+		"parentFolderId":            path.Root("parent_folder_id"),
+		"config.metrics[0].type":    path.Root("config").AtName("metrics").AtListIndex(0).AtName("type"),
+		"/config/metrics/1/type":    path.Root("config").AtName("metrics").AtListIndex(1).AtName("type"),
+		"$.recipientsSlackChannels": path.Root("recipients_slack_channels"),
+		"urlUI":                     path.Root("url_ui"),
+		"scopeIDs":                  path.Root("scope_ids"),
+		"URLPath":                   path.Root("url_path"),
+	}
+	for field, want := range tests {
+		got, ok := apiFieldPath(field)
+		if !ok || !got.Equal(want) {
+			t.Errorf("apiFieldPath(%q) = %s, %v; want %s", field, got, ok, want)
+		}
+	}
+	for _, field := range []string{"", "0.name", "[]"} {
+		if p, ok := apiFieldPath(field); ok {
+			t.Errorf("apiFieldPath(%q) = %s, want no path", field, p)
+		}
+	}
+}
+
+func TestAPIErrorDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fields become attribute errors", func(t *testing.T) {
+		t.Parallel()
+
+		err := fmt.Errorf("wrapped: %w", &APIError{
+			StatusCode: 400,
+			Code:       "validation_failed",
+			Message:    "Invalid report",
+			Fields: []APIErrorField{
+				{Field: "config.metrics[0].type", Issue: "invalid_value"},
+				{Field: "name", Issue: "too_long"},
+			},
+			RequestID: "req_1",
+		})
+		diags := apiErrorDiagnostics("Error Creating Report", "Could not create report", err)
+		if len(diags) != 2 {
+			t.Fatalf("got %d diagnostics, want 2: %v", len(diags), diags)
+		}
+		for i, want := range []path.Path{
+			path.Root("config").AtName("metrics").AtListIndex(0).AtName("type"),
+			path.Root("name"),
+		} {
+			d, ok := diags[i].(interface{ Path() path.Path })
+			if !ok || !d.Path().Equal(want) {
+				t.Errorf("diagnostic %d is not attached to %s: %v", i, want, diags[i])
+			}
+		}
+		detail := diags[0].Detail()
+		for _, want := range []string{"Could not create report: API error 400 (validation_failed): Invalid report", "Request ID: req_1"} {
+			if !strings.Contains(detail, want) {
+				t.Errorf("detail %q does not contain %q", detail, want)
+			}
+		}
+	})
+
+	t.Run("hints", func(t *testing.T) {
+		t.Parallel()
+
+		for _, tt := range []struct {
+			err  *APIError
+			want string
+		}{
+			{err: &APIError{StatusCode: 401}, want: "api_token_file"},
+			{err: &APIError{StatusCode: 403}, want: "customer_context"},
+			{err: &APIError{StatusCode: 400, Code: "tenant_id_mismatch"}, want: "customer_context"},
+		} {
+			diags := apiErrorDiagnostics("Error Reading Folder", "Could not read folder", tt.err)
+			if len(diags) != 1 || !strings.Contains(diags[0].Detail(), tt.want) {
+				t.Errorf("diagnostics for %v = %v, want a hint mentioning %s", tt.err, diags, tt.want)
+			}
+		}
+		diags := apiErrorDiagnostics("Error Reading Folder", "Could not read folder", &APIError{StatusCode: 400})
+		if strings.Contains(diags[0].Detail(), "customer_context") {
+			t.Errorf("400 without a tenant code got a hint: %q", diags[0].Detail())
+		}
+	})
+
+	t.Run("other errors", func(t *testing.T) {
+		t.Parallel()
+
+		diags := apiErrorDiagnostics("Error Reading Folder", "Could not read folder ID f1", context.DeadlineExceeded)
+		if len(diags) != 1 || diags[0].Detail() != "Could not read folder ID f1: context deadline exceeded" {
+			t.Errorf("diagnostics = %v", diags)
+		}
+	})
+}
+
+func TestDCIRetryClient_ReturnsAPIError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.Header().Set("Request-Id", "req_9")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = io.WriteString(w, `{"title":"Validation failed","status":400,"detail":"name is required",
+			"code":"validation_failed","details":[{"field":"name","issue":"required"}]}`)
+	}))
+	t.Cleanup(server.Close)
+
+	c := newTestRetryClient(5*time.Second, constantBackOff(time.Millisecond))
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL+"/analytics/v1/labels", strings.NewReader(`{}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.Do(req)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Do() error = %v, want *APIError", err)
+	}
+	if apiErr.StatusCode != 400 || apiErr.Code != "validation_failed" || apiErr.RequestID != "req_9" ||
+		len(apiErr.Fields) != 1 || apiErr.Fields[0].Field != "name" {
+		t.Errorf("APIError = %+v", apiErr)
+	}
+}
@@ -17,9 +17,7 @@ import (
 func (r *assetResource) populateState(ctx context.Context, state *assetResourceModel) diag.Diagnostics {
 	assetResp, err := r.client.GetAssetWithResponse(ctx, state.Id.ValueString())
 	if err != nil {
-		return diag.Diagnostics{
-			diag.NewErrorDiagnostic("Error Reading Asset", "Could not read asset ID "+state.Id.ValueString()+": "+err.Error()),
-		}
+		return apiErrorDiagnostics("Error Reading Asset", "Could not read asset ID "+state.Id.ValueString(), err)
 	}
 
 	if assetResp.StatusCode() == 404 {
@@ -26,7 +26,8 @@ type assetDataSource struct {
 
 type assetDataSourceModel struct {
 	datasource_asset.AssetModel
-	Timeouts timeouts.Value `tfsdk:"timeouts"`
+	CustomerContext types.String   `tfsdk:"customer_context"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (ds *assetDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -51,6 +52,7 @@ func (ds *assetDataSource) Configure(_ context.Context, req datasource.Configure
 func (ds *assetDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	s := datasource_asset.AssetDataSourceSchema(ctx)
 
+	s.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx)
 
 	resp.Schema = s
@@ -69,6 +71,7 @@ func (ds *assetDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	// If ID is unknown (depends on a resource not yet created), set all computed
@@ -13,15 +13,18 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 type (
 	assetResource struct {
-		client *models.ClientWithResponses
+		client   *models.ClientWithResponses
+		readOnly bool
 	}
 	assetResourceModel struct {
 		resource_asset.AssetModel
-		Timeouts timeouts.Value `tfsdk:"timeouts"`
+		CustomerContext types.String   `tfsdk:"customer_context"`
+		Timeouts        timeouts.Value `tfsdk:"timeouts"`
 	}
 )
 
@@ -43,16 +46,17 @@ func (r *assetResource) Configure(_ context.Context, req resource.ConfigureReque
 		return
 	}
 
-	client, ok := req.ProviderData.(*models.ClientWithResponses)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *models.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.readOnly = data.readOnly
 }
 
 func (r *assetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -60,6 +64,10 @@ func (r *assetResource) Metadata(_ context.Context, req resource.MetadataRequest
 }
 
 func (r *assetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, id, diags := importCustomerContext(ctx, req, resp, 1)
+	resp.Diagnostics.Append(diags...)
+	req.ID = id
+
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
@@ -111,6 +119,7 @@ func (r *assetResource) Schema(ctx context.Context, _ resource.SchemaRequest, re
 		"Destroying this resource only removes it from Terraform state; the asset continues to exist in DoiT."
 	s.MarkdownDescription = s.Description
 
+	s.Attributes["customer_context"] = customerContextResourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx, timeouts.Opts{
 		Create: true,
 		Read:   true,
@@ -145,6 +154,7 @@ func (r *assetResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
 
 	resp.Diagnostics.Append(r.populateState(ctx, &state)...)
@@ -162,6 +172,11 @@ func (r *assetResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *assetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_asset", "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan assetResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -175,6 +190,7 @@ func (r *assetResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	ctx = withCustomerContext(ctx, plan.CustomerContext)
 	defer cancel()
 
 	// Get the ID from state
@@ -194,10 +210,7 @@ func (r *assetResource) Update(ctx context.Context, req resource.UpdateRequest,
 	// Call PATCH
 	updateResp, err := r.client.IdOfAssetWithResponse(ctx, assetID, updateBody)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Updating Asset",
-			"Could not update asset, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Updating Asset", "Could not update asset", err)...)
 		return
 	}
 
@@ -212,10 +225,7 @@ func (r *assetResource) Update(ctx context.Context, req resource.UpdateRequest,
 	// The PATCH response doesn't return the full AssetItem, so GET the updated state
 	assetResp, err := r.client.GetAssetWithResponse(ctx, assetID)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading Asset After Update",
-			"Could not read asset after update: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Reading Asset After Update", "Could not read asset after update", err)...)
 		return
 	}
 
@@ -238,6 +248,11 @@ func (r *assetResource) Update(ctx context.Context, req resource.UpdateRequest,
 }
 
 func (r *assetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_asset", "delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var state assetResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -25,10 +25,11 @@ type avaDataSource struct {
 }
 
 type avaDataSourceModel struct {
-	Id       types.String   `tfsdk:"id"`
-	Question types.String   `tfsdk:"question"`
-	Answer   types.String   `tfsdk:"answer"`
-	Timeouts timeouts.Value `tfsdk:"timeouts"`
+	Id              types.String   `tfsdk:"id"`
+	Question        types.String   `tfsdk:"question"`
+	Answer          types.String   `tfsdk:"answer"`
+	CustomerContext types.String   `tfsdk:"customer_context"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (d *avaDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -61,7 +62,8 @@ func (d *avaDataSource) Schema(ctx context.Context, req datasource.SchemaRequest
 				MarkdownDescription: "The Ava response text. Present on success.",
 			},
 
-			"timeouts": timeouts.Attributes(ctx),
+			"customer_context": customerContextDataSourceAttribute(),
+			"timeouts":         timeouts.Attributes(ctx),
 		},
 	}
 }
@@ -97,6 +99,7 @@ func (d *avaDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	// If question is unknown (e.g., depends on a resource being created),
@@ -24,7 +24,8 @@ type billingExplainerDataSource struct {
 
 type billingExplainerDataSourceModel struct {
 	datasource_billing_explainer.BillingExplainerModel
-	Timeouts timeouts.Value `tfsdk:"timeouts"`
+	CustomerContext types.String   `tfsdk:"customer_context"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (ds *billingExplainerDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -49,6 +50,7 @@ func (ds *billingExplainerDataSource) Configure(_ context.Context, req datasourc
 func (ds *billingExplainerDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	s := datasource_billing_explainer.BillingExplainerDataSourceSchema(ctx)
 
+	s.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx)
 
 	resp.Schema = s
@@ -67,6 +69,7 @@ func (ds *billingExplainerDataSource) Read(ctx context.Context, req datasource.R
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
 
 	// If invoice_month is unknown (depends on a resource not yet created), set
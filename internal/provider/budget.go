@@ -8,6 +8,8 @@ import (
 	"github.com/doitintl/terraform-provider-doit/internal/provider/resource_budget"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -352,10 +354,7 @@ func (r *budgetResource) populateState(ctx context.Context, state *budgetResourc
 	// Get refreshed budget value from API
 	budgetResp, err := r.client.GetBudgetWithResponse(ctx, state.Id.ValueString())
 	if err != nil {
-		diags.AddError(
-			"Error Reading Budget",
-			"Could not read budget ID "+state.Id.ValueString()+": "+err.Error(),
-		)
+		diags.Append(apiErrorDiagnostics("Error Reading Budget", "Could not read budget ID "+state.Id.ValueString(), err)...)
 		return
 	}
 
@@ -387,6 +386,26 @@ func (r *budgetResource) populateState(ctx context.Context, state *budgetResourc
 	return mapBudgetToModel(ctx, resp, state)
 }
 
+// checkConcurrentModification refreshes the budget in prior from the API, as
+// Read does, and fails when it changed since the last refresh. See
+// diffConcurrentModification.
+func (r *budgetResource) checkConcurrentModification(ctx context.Context, prior tfsdk.State) diag.Diagnostics {
+	var remote budgetResourceModel
+	diags := prior.Get(ctx, &remote)
+	if diags.HasError() {
+		return diags
+	}
+	id := remote.Id.ValueString()
+
+	diags.Append(r.populateState(ctx, &remote)...)
+	// A deleted budget is left to the update request to report.
+	if diags.HasError() || remote.Id.IsNull() {
+		return diags
+	}
+	diags.Append(diffConcurrentModification(ctx, "budget", id, prior, &remote)...)
+	return diags
+}
+
 // mapBudgetToModel maps the full API response to the Terraform model.
 // This is used ONLY by Read and ImportState — Create/Update use overlayBudgetComputedFields instead.
 // This function contains sentinel restoration and alias normalization for the Read path.
@@ -555,6 +574,7 @@ func mapBudgetToModel(ctx context.Context, resp *models.BudgetAPI, state *budget
 
 		scopesList := make([]resource_budget.ScopesValue, len(resp.Scopes))
 		for i, scope := range resp.Scopes {
+			scopePath := path.Root("scopes").AtListIndex(i)
 			// Sentinel-restoration logic (mirrors report.go):
 			// The API strips legacy "[... N/A]" NullFallback sentinels from scope values
 			// and converts them to includeNull=true. We restore any stripped sentinels
@@ -570,6 +590,7 @@ func mapBudgetToModel(ctx context.Context, resp *models.BudgetAPI, state *budget
 				var stateVals []string
 				if d := existingScopeValues[i].ElementsAs(ctx, &stateVals, false); !d.HasError() {
 					mergedValues = mergeSentinelValues(apiValues, stateVals, apiIncludeNull)
+					explainRestoredSentinels(ctx, scopePath.AtName("values"), apiValues, mergedValues, apiIncludeNull)
 				}
 			}
 			var valuesVal types.List
@@ -589,9 +610,11 @@ func mapBudgetToModel(ctx context.Context, resp *models.BudgetAPI, state *budget
 			// E.g. user configures "allocation_rule", API returns "attribution" — preserve user's value.
 			if i < len(existingScopeTypes) {
 				scopeType = normalizeDimensionsType(scopeType, existingScopeTypes[i])
+				explainNormalizedType(ctx, scopePath.AtName("type"), string(scope.Type), scopeType)
 			}
 			if i < len(existingScopeIDs) {
 				scopeID = normalizeDimensionsType(scopeID, existingScopeIDs[i])
+				explainNormalizedType(ctx, scopePath.AtName("id"), scope.Id, scopeID)
 			}
 
 			// The API does not reliably echo includeNull — it returns false as a default
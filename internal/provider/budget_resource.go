@@ -12,17 +12,31 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 const budgetSchemaVersion = 1
 
 type (
 	budgetResource struct {
-		client *models.ClientWithResponses
+		client                       *models.ClientWithResponses
+		readOnly                     bool
+		detectConcurrentModification bool
+		defaultLabels                []string
+		defaults                     notificationDefaults
+		deletionProtection           bool
+		validateDimensions           bool
+		explainDrift                 bool
+		// recipientValidation and customerContext configure
+		// validateRecipients; see recipient_validation.go.
+		recipientValidation string
+		customerContext     string
 	}
 	budgetResourceModel struct {
 		resource_budget.BudgetModel
-		Timeouts timeouts.Value `tfsdk:"timeouts"`
+		DeletionProtection types.Bool     `tfsdk:"deletion_protection"`
+		CustomerContext    types.String   `tfsdk:"customer_context"`
+		Timeouts           timeouts.Value `tfsdk:"timeouts"`
 	}
 )
 
@@ -33,6 +47,7 @@ var (
 	_ resource.ResourceWithUpgradeState     = (*budgetResource)(nil)
 	_ resource.ResourceWithImportState      = (*budgetResource)(nil)
 	_ resource.ResourceWithConfigValidators = (*budgetResource)(nil)
+	_ resource.ResourceWithModifyPlan       = (*budgetResource)(nil)
 )
 
 // NewBudgetResource creates a new budget resource instance.
@@ -46,16 +61,25 @@ func (r *budgetResource) Configure(_ context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*models.ClientWithResponses)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *models.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.readOnly = data.readOnly
+	r.detectConcurrentModification = data.detectConcurrentModification
+	r.deletionProtection = data.deletionProtection
+	r.defaultLabels = data.defaultLabels
+	r.validateDimensions = data.validateDimensions
+	r.explainDrift = data.explainDrift
+	r.recipientValidation = data.recipientValidation
+	r.customerContext = data.customerContext
+	r.defaults = data.notificationDefaults
 }
 
 func (r *budgetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -63,7 +87,12 @@ func (r *budgetResource) Metadata(_ context.Context, req resource.MetadataReques
 }
 
 func (r *budgetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	ctx, id, diags := importCustomerContext(ctx, req, resp, 1)
+	resp.Diagnostics.Append(diags...)
+	req.ID = id
+
+	// Accepts either an ID or "name:<exact name>".
+	importStatePassthroughIDOrName(ctx, req, resp, "budget", nameLookup(r.client, listBudgets))
 }
 
 func (r *budgetResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -140,6 +169,9 @@ func (r *budgetResource) Schema(ctx context.Context, _ resource.SchemaRequest, r
 		s.Attributes["scopes"] = scopesAttr
 	}
 
+	s.Attributes["deletion_protection"] = deletionProtectionAttribute()
+
+	s.Attributes["customer_context"] = customerContextResourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx, timeouts.Opts{
 		Create: true,
 		Read:   true,
@@ -157,12 +189,49 @@ func (r *budgetResource) ConfigValidators(_ context.Context) []resource.ConfigVa
 		budgetRecipientsMinLengthValidator{},
 		budgetScopeMutuallyExclusiveValidator{},
 		budgetCollaboratorsOwnerValidator{},
+		budgetSeasonalAmountsValidator{},
 		// Warn when legacy [... N/A] NullFallback sentinels are used in scope values.
 		budgetScopeNAValidator{},
 	}
 }
 
+// ModifyPlan fills the provider's default notification settings into
+// attributes left out of configuration.
+func (r *budgetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return // destroy
+	}
+
+	recipients, diags := r.defaults.recipientsList(ctx)
+	resp.Diagnostics.Append(diags...)
+	slackChannels, diags := r.defaults.budgetSlackChannelsList(ctx)
+	resp.Diagnostics.Append(diags...)
+	collaborators, diags := r.defaults.budgetCollaboratorsList(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	applyListDefault(ctx, req, resp, path.Root("recipients"), recipients)
+	applyListDefault(ctx, req, resp, path.Root("recipients_slack_channels"), slackChannels)
+	applyListDefault(ctx, req, resp, path.Root("collaborators"), collaborators)
+
+	if r.validateDimensions {
+		resp.Diagnostics.Append(validateDimensionFilters(ctx, r.client, req, "id",
+			path.MatchRoot("scopes").AtAnyListIndex())...)
+	}
+
+	resp.Diagnostics.Append(validateRecipients(ctx, r.client, r.recipientValidation, r.customerContext, resp.Plan, req.State,
+		path.MatchRoot("recipients").AtAnyListIndex(),
+		path.MatchRoot("collaborators").AtAnyListIndex().AtName("email"))...)
+}
+
 func (r *budgetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_budget", "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan budgetResourceModel
 
 	// Read Terraform plan data into the model
@@ -177,6 +246,7 @@ func (r *budgetResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	ctx = withCustomerContext(ctx, plan.CustomerContext)
 	defer cancel()
 
 	// Convert model to API budget type
@@ -189,10 +259,7 @@ func (r *budgetResource) Create(ctx context.Context, req resource.CreateRequest,
 	// Create new budget via API
 	budgetResp, err := r.client.CreateBudgetWithResponse(ctx, budget)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Creating Budget",
-			"Could not create budget, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Creating Budget", "Could not create budget", err)...)
 		return
 	}
 
@@ -222,6 +289,8 @@ func (r *budgetResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	resp.Diagnostics.Append(assignDefaultLabels(ctx, r.client, r.defaultLabels, models.LabelAssignmentObjectObjectTypeBudget, plan.Id.ValueString())...)
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
@@ -241,7 +310,12 @@ func (r *budgetResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
+	var drift *driftExplanations
+	if r.explainDrift {
+		ctx, drift = withDriftExplanations(ctx)
+	}
 
 	// Populate state
 	resp.Diagnostics.Append(r.populateState(ctx, &state)...)
@@ -255,11 +329,18 @@ func (r *budgetResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
+	resp.Diagnostics.Append(drift.diagnostics()...)
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
 func (r *budgetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_budget", "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan budgetResourceModel
 
 	// Read Terraform plan data
@@ -274,6 +355,7 @@ func (r *budgetResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	ctx = withCustomerContext(ctx, plan.CustomerContext)
 	defer cancel()
 
 	// Convert model to API budget type
@@ -291,13 +373,17 @@ func (r *budgetResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 	budgetID := state.Id.ValueString()
 
+	if r.detectConcurrentModification {
+		resp.Diagnostics.Append(r.checkConcurrentModification(ctx, req.State)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// Update budget via API
 	updateResp, err := r.client.UpdateBudgetWithResponse(ctx, budgetID, budget)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Updating Budget",
-			"Could not update budget, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Updating Budget", "Could not update budget", err)...)
 		return
 	}
 
@@ -330,6 +416,11 @@ func (r *budgetResource) Update(ctx context.Context, req resource.UpdateRequest,
 }
 
 func (r *budgetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_budget", "delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var state budgetResourceModel
 
 	// Read Terraform prior state data into the model
@@ -338,21 +429,26 @@ func (r *budgetResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	resp.Diagnostics.Append(checkDeletionProtection(state.DeletionProtection, r.deletionProtection, "budget", state.Id.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	deleteTimeout, diags := state.Timeouts.Delete(ctx, DefaultDeleteTimeout)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
 
+	resp.Diagnostics.Append(unassignDefaultLabels(ctx, r.client, r.defaultLabels, models.LabelAssignmentObjectObjectTypeBudget, state.Id.ValueString())...)
+
 	// Delete budget via API
 	deleteResp, err := r.client.DeleteBudgetWithResponse(ctx, state.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Deleting DoiT Budget",
-			"Could not delete budget, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Deleting DoiT Budget", "Could not delete budget", err)...)
 		return
 	}
 
@@ -25,7 +25,8 @@ type budgetSuggestionsDataSource struct {
 
 type budgetSuggestionsDataSourceModel struct {
 	datasource_budget_suggestions.BudgetSuggestionsModel
-	Timeouts timeouts.Value `tfsdk:"timeouts"`
+	CustomerContext types.String   `tfsdk:"customer_context"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (d *budgetSuggestionsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -38,6 +39,7 @@ func (d *budgetSuggestionsDataSource) Schema(ctx context.Context, _ datasource.S
 	s.MarkdownDescription = "List the pending AI-generated budget suggestions for the account."
 	s.Description = "List the pending AI-generated budget suggestions for the account."
 
+	s.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx)
 
 	resp.Schema = s
@@ -74,6 +76,7 @@ func (d *budgetSuggestionsDataSource) Read(ctx context.Context, req datasource.R
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	apiResp, err := d.client.ListBudgetSuggestionsWithResponse(ctx)
@@ -473,3 +473,187 @@ func (v budgetScopeNAValidator) ValidateResource(ctx context.Context, req resour
 	}
 	warnNASentinels(ctx, basePath, valueLists, &resp.Diagnostics)
 }
+
+// budgetSeasonalAmountsValidator validates seasonal_amounts against the budget's
+// type, time_interval and periods, and against growth_per_period.
+type budgetSeasonalAmountsValidator struct{}
+
+var _ resource.ConfigValidator = budgetSeasonalAmountsValidator{}
+
+func (v budgetSeasonalAmountsValidator) Description(_ context.Context) string {
+	return "Validates that seasonal_amounts has one non-negative amount per budget period and is not combined with growth_per_period"
+}
+
+func (v budgetSeasonalAmountsValidator) MarkdownDescription(_ context.Context) string {
+	return "Validates that `seasonal_amounts` has one non-negative amount per budget period and is not combined with `growth_per_period`"
+}
+
+func (v budgetSeasonalAmountsValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var seasonalAmounts types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("seasonal_amounts"), &seasonalAmounts)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// An empty list is what the API returns for budgets without seasonal
+	// amounts, so it is treated like an unset attribute.
+	if seasonalAmounts.IsNull() || seasonalAmounts.IsUnknown() || len(seasonalAmounts.Elements()) == 0 {
+		return
+	}
+
+	amounts := make([]*float64, len(seasonalAmounts.Elements()))
+	for i, elem := range seasonalAmounts.Elements() {
+		amount, ok := elem.(types.Float64)
+		if !ok || amount.IsNull() || amount.IsUnknown() {
+			continue
+		}
+		amounts[i] = amount.ValueFloat64Pointer()
+	}
+	if i, err := validateBudgetSeasonalAmountsNonNegative(amounts); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("seasonal_amounts").AtListIndex(i),
+			"Invalid Seasonal Amount",
+			err.Error(),
+		)
+	}
+
+	var growthPerPeriod types.Float64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("growth_per_period"), &growthPerPeriod)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !growthPerPeriod.IsNull() && !growthPerPeriod.IsUnknown() {
+		if err := validateBudgetSeasonalGrowth(growthPerPeriod.ValueFloat64()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("growth_per_period"),
+				"Invalid Attribute Combination",
+				err.Error(),
+			)
+		}
+	}
+
+	var budgetType, timeInterval types.String
+	var startPeriod, endPeriod types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("type"), &budgetType)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("time_interval"), &timeInterval)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("start_period"), &startPeriod)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("end_period"), &endPeriod)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if budgetType.IsNull() || budgetType.IsUnknown() || timeInterval.IsNull() || timeInterval.IsUnknown() ||
+		startPeriod.IsUnknown() || endPeriod.IsUnknown() {
+		return
+	}
+
+	if err := validateBudgetSeasonalAmountsCount(budgetType.ValueString(), timeInterval.ValueString(),
+		startPeriod.ValueInt64Pointer(), endPeriod.ValueInt64Pointer(), len(amounts)); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("seasonal_amounts"),
+			"Invalid Seasonal Amounts",
+			err.Error(),
+		)
+	}
+}
+
+// validateBudgetSeasonalAmountsCount checks that there is one seasonal amount
+// per budget period. A recurring budget repeats its seasonal amounts every
+// year, so monthly, quarterly and yearly budgets need 12, 4 and 1 amounts;
+// the count of weekly and daily periods varies from year to year, so it is
+// not checked. A fixed budget needs one amount per period from start_period
+// up to end_period, which are nil when not configured.
+func validateBudgetSeasonalAmountsCount(budgetType, timeInterval string, startPeriodMs, endPeriodMs *int64, count int) error {
+	var want int
+	switch budgetType {
+	case "recurring":
+		switch timeInterval {
+		case "month":
+			want = 12
+		case "quarter":
+			want = 4
+		case "year":
+			want = 1
+		default:
+			return nil
+		}
+		if count != want {
+			return fmt.Errorf("a recurring budget with interval %q needs %d seasonal amounts, one for each %s of the year, but %d were given",
+				timeInterval, want, timeInterval, count)
+		}
+	case "fixed":
+		if startPeriodMs == nil || endPeriodMs == nil {
+			return nil
+		}
+		n, err := budgetPeriodCount(timeInterval, *startPeriodMs, *endPeriodMs)
+		if err != nil {
+			return err
+		}
+		if count != n {
+			return fmt.Errorf("a fixed budget with interval %q from %s to %s spans %d periods and needs one seasonal amount for each, but %d were given",
+				timeInterval, time.UnixMilli(*startPeriodMs).UTC().Format(time.RFC3339),
+				time.UnixMilli(*endPeriodMs).UTC().Format(time.RFC3339), n, count)
+		}
+	}
+	return nil
+}
+
+// budgetPeriodCount returns the number of periods of the interval that
+// overlap the range from startPeriodMs up to endPeriodMs.
+func budgetPeriodCount(timeInterval string, startPeriodMs, endPeriodMs int64) (int, error) {
+	start := time.UnixMilli(startPeriodMs).UTC()
+	end := time.UnixMilli(endPeriodMs).UTC()
+	if !end.After(start) {
+		return 0, fmt.Errorf("end_period %s must be after start_period %s",
+			end.Format(time.RFC3339), start.Format(time.RFC3339))
+	}
+
+	t := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	var next func(time.Time) time.Time
+	switch timeInterval {
+	case "day":
+		next = func(t time.Time) time.Time { return t.AddDate(0, 0, 1) }
+	case "week":
+		t = t.AddDate(0, 0, -(int(t.Weekday())+6)%7)
+		next = func(t time.Time) time.Time { return t.AddDate(0, 0, 7) }
+	case "month":
+		t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+		next = func(t time.Time) time.Time { return t.AddDate(0, 1, 0) }
+	case "quarter":
+		t = time.Date(t.Year(), (t.Month()-1)/3*3+1, 1, 0, 0, 0, 0, time.UTC)
+		next = func(t time.Time) time.Time { return t.AddDate(0, 3, 0) }
+	case "year":
+		t = time.Date(t.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+		next = func(t time.Time) time.Time { return t.AddDate(1, 0, 0) }
+	default:
+		return 0, validateBudgetTimeInterval(timeInterval)
+	}
+
+	n := 0
+	for ; t.Before(end); t = next(t) {
+		n++
+	}
+	return n, nil
+}
+
+// validateBudgetSeasonalAmountsNonNegative checks that no seasonal amount is
+// negative, returning the index of the first that is. Nil amounts are not
+// yet known and are skipped.
+func validateBudgetSeasonalAmountsNonNegative(amounts []*float64) (int, error) {
+	for i, amount := range amounts {
+		if amount != nil && *amount < 0 {
+			return i, fmt.Errorf("seasonal amounts cannot be negative. Provided: %g", *amount)
+		}
+	}
+	return 0, nil
+}
+
+// validateBudgetSeasonalGrowth checks that growth_per_period is not set
+// alongside seasonal amounts. The API takes each period's amount from
+// seasonal_amounts and ignores the growth percentage.
+func validateBudgetSeasonalGrowth(growthPerPeriod float64) error {
+	if growthPerPeriod != 0 {
+		return fmt.Errorf("growth_per_period (%g) has no effect when seasonal_amounts is set, because each period's amount is taken from seasonal_amounts. "+
+			"Remove growth_per_period, or remove seasonal_amounts to grow amount by %g%% per period", growthPerPeriod, growthPerPeriod)
+	}
+	return nil
+}
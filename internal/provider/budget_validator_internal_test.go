@@ -236,3 +236,87 @@ func TestBudgetScopeNAValidator_UnknownElement(t *testing.T) {
 		t.Fatalf("warnNASentinels crashed with budget scope unknown element: %v", diags)
 	}
 }
+
+func TestValidateBudgetSeasonalAmountsCount(t *testing.T) {
+	ms := func(year int, month time.Month, day int) *int64 {
+		return new(time.Date(year, month, day, 0, 0, 0, 0, time.UTC).UnixMilli())
+	}
+	tests := []struct {
+		name          string
+		budgetType    string
+		timeInterval  string
+		startPeriod   *int64
+		endPeriod     *int64
+		count         int
+		expectedError bool
+	}{
+		{name: "Recurring Month Valid", budgetType: "recurring", timeInterval: "month", count: 12},
+		{name: "Recurring Month Invalid", budgetType: "recurring", timeInterval: "month", count: 4, expectedError: true},
+		{name: "Recurring Quarter Valid", budgetType: "recurring", timeInterval: "quarter", count: 4},
+		{name: "Recurring Quarter Invalid", budgetType: "recurring", timeInterval: "quarter", count: 12, expectedError: true},
+		{name: "Recurring Year Valid", budgetType: "recurring", timeInterval: "year", count: 1},
+		{name: "Recurring Year Invalid", budgetType: "recurring", timeInterval: "year", count: 2, expectedError: true},
+		{name: "Recurring Week Unchecked", budgetType: "recurring", timeInterval: "week", count: 3},
+		{
+			name: "Fixed Month Valid", budgetType: "fixed", timeInterval: "month",
+			startPeriod: ms(2025, 1, 15), endPeriod: ms(2025, 4, 1), count: 3,
+		},
+		{
+			name: "Fixed Month Invalid", budgetType: "fixed", timeInterval: "month",
+			startPeriod: ms(2025, 1, 15), endPeriod: ms(2025, 4, 2), count: 3, expectedError: true,
+		},
+		{
+			name: "Fixed Quarter Valid", budgetType: "fixed", timeInterval: "quarter",
+			startPeriod: ms(2025, 2, 1), endPeriod: ms(2025, 12, 31), count: 4,
+		},
+		{
+			name: "Fixed Week Valid", budgetType: "fixed", timeInterval: "week",
+			startPeriod: ms(2025, 1, 1), endPeriod: ms(2025, 1, 13), count: 2,
+		},
+		{
+			name: "Fixed End Before Start", budgetType: "fixed", timeInterval: "day",
+			startPeriod: ms(2025, 1, 2), endPeriod: ms(2025, 1, 1), count: 1, expectedError: true,
+		},
+		{name: "Fixed Without Periods", budgetType: "fixed", timeInterval: "month", count: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateBudgetSeasonalAmountsCount(tt.budgetType, tt.timeInterval, tt.startPeriod, tt.endPeriod, tt.count)
+			if (err != nil) != tt.expectedError {
+				t.Errorf("validateBudgetSeasonalAmountsCount() error = %v, expectedError %v", err, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestValidateBudgetSeasonalAmountsNonNegative(t *testing.T) {
+	tests := []struct {
+		name          string
+		amounts       []*float64
+		expectedIndex int
+		expectedError bool
+	}{
+		{name: "All Valid", amounts: []*float64{new(100.0), new(0.0), nil}},
+		{name: "Negative", amounts: []*float64{new(100.0), nil, new(-1.0), new(-2.0)}, expectedIndex: 2, expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i, err := validateBudgetSeasonalAmountsNonNegative(tt.amounts)
+			if (err != nil) != tt.expectedError || i != tt.expectedIndex {
+				t.Errorf("validateBudgetSeasonalAmountsNonNegative() = %d, %v; want index %d, expectedError %v",
+					i, err, tt.expectedIndex, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestValidateBudgetSeasonalGrowth(t *testing.T) {
+	if err := validateBudgetSeasonalGrowth(0); err != nil {
+		t.Errorf("validateBudgetSeasonalGrowth(0) error = %v", err)
+	}
+	if err := validateBudgetSeasonalGrowth(5); err == nil {
+		t.Error("validateBudgetSeasonalGrowth(5) accepted growth alongside seasonal amounts")
+	}
+}
@@ -0,0 +1,240 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Circuit breaker defaults, used when the provider's circuit_breaker {} block
+// leaves them unset.
+const (
+	// defaultBreakerThreshold is how many consecutive outage responses, across
+	// every operation, open the breaker.
+	defaultBreakerThreshold = 10
+
+	// defaultBreakerCoolDown is how long an open breaker fails requests
+	// before letting a probe through.
+	defaultBreakerCoolDown = 30 * time.Second
+)
+
+// breakerPath is the provider block circuit breaker settings are reported
+// against.
+var breakerPath = path.Root("circuit_breaker")
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// circuitBreaker stops an apply from retrying against an API that is down.
+// Without it, every resource retries on its own until its operation timeout,
+// so an outage during an apply of hundreds of resources takes an hour to fail.
+//
+// It is shared by every request of one DCIRetryClient. threshold consecutive
+// outage responses (see isOutageStatus) open it, and while open every
+// attempt fails immediately with a *CircuitOpenError. After coolDown, one
+// probe request is let through (half-open): any other response closes the
+// breaker, and another outage response opens it for a new cool-down.
+type circuitBreaker struct {
+	mu sync.Mutex
+
+	threshold int
+	coolDown  time.Duration
+
+	state breakerState
+	// failures counts consecutive outage responses while closed.
+	failures int
+	// lastStatus is the outage response that last counted against the breaker.
+	lastStatus int
+	openedAt   time.Time
+	// probing is set while the half-open probe is in flight.
+	probing bool
+
+	// now is time.Now outside tests.
+	now func() time.Time
+}
+
+func newCircuitBreaker(threshold int, coolDown time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		threshold: threshold,
+		coolDown:  coolDown,
+		now:       time.Now,
+	}
+}
+
+// CircuitOpenError is returned for requests the open circuit breaker refused
+// to send.
+type CircuitOpenError struct {
+	// Failures is the number of consecutive outage responses that opened the
+	// breaker.
+	Failures int
+	// LastStatus is the last outage response received.
+	LastStatus int
+	// RetryAt is when the breaker next lets a probe request through.
+	RetryAt time.Time
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("the DoiT API appears to be unavailable: %d consecutive requests found the API unavailable "+
+		"(last status %d), so remaining requests fail without being sent. "+
+		"Retry the operation once the API has recovered; the provider probes it again at %s",
+		e.Failures, e.LastStatus, e.RetryAt.Format(time.RFC3339))
+}
+
+// allow returns an error when a request must not be sent. probe reports
+// whether the request is the half-open probe, which must be followed by a
+// call to record or release.
+func (b *circuitBreaker) allow() (probe bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if b.now().Sub(b.openedAt) < b.coolDown {
+			return false, b.openError()
+		}
+		b.state = breakerHalfOpen
+	case breakerHalfOpen:
+		if b.probing {
+			return false, b.openError()
+		}
+	default:
+		return false, nil
+	}
+	b.probing = true
+	return true, nil
+}
+
+// openError describes the open breaker. Callers hold mu.
+func (b *circuitBreaker) openError() *CircuitOpenError {
+	return &CircuitOpenError{
+		Failures:   b.failures,
+		LastStatus: b.lastStatus,
+		RetryAt:    b.openedAt.Add(b.coolDown),
+	}
+}
+
+// isOutageStatus reports whether status means the API, or the gateway in
+// front of it, is unavailable: 502, 503, 504 or Cloudflare's 524. Other
+// server errors, such as a 500 for one malformed request or a 501, come from
+// an API that is up, and must not fail unrelated operations.
+func isOutageStatus(status int) bool {
+	switch status {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout, 524:
+		return true
+	default:
+		return false
+	}
+}
+
+// record accounts for a response with the given status. It reports whether
+// this response opened the breaker, so the caller can log the transition
+// once.
+func (b *circuitBreaker) record(status int) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !isOutageStatus(status) {
+		b.state = breakerClosed
+		b.failures = 0
+		b.probing = false
+		return false
+	}
+
+	b.lastStatus = status
+	switch b.state {
+	case breakerHalfOpen:
+		// The probe failed: stay open for another cool-down.
+		b.failures++
+		b.state = breakerOpen
+		b.openedAt = b.now()
+		b.probing = false
+		return false
+	case breakerOpen:
+		// A response to a request sent before the breaker opened.
+		return false
+	default:
+		b.failures++
+		if b.failures < b.threshold {
+			return false
+		}
+		b.state = breakerOpen
+		b.openedAt = b.now()
+		return true
+	}
+}
+
+// release gives up the half-open probe without a response, e.g. after a
+// network error or cancellation, so the next request probes instead.
+func (b *circuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+// circuitBreakerModel maps the provider's circuit_breaker {} block.
+type circuitBreakerModel struct {
+	Enabled          types.Bool   `tfsdk:"enabled"`
+	FailureThreshold types.Int64  `tfsdk:"failure_threshold"`
+	CoolDown         types.String `tfsdk:"cool_down"`
+}
+
+// circuitBreakerFromConfig resolves the provider's circuit_breaker {} block.
+// It returns nil when the breaker is disabled. The breaker is opt-in: a null
+// block disables it, while an empty block enables it with the default
+// settings, so failing every operation of a run is never a surprise.
+func circuitBreakerFromConfig(ctx context.Context, block types.Object) (*circuitBreaker, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if block.IsNull() {
+		return nil, diags
+	}
+
+	var m circuitBreakerModel
+	diags.Append(block.As(ctx, &m, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true})...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	if block.IsUnknown() || m.Enabled.IsUnknown() || m.FailureThreshold.IsUnknown() || m.CoolDown.IsUnknown() {
+		diags.AddAttributeError(
+			breakerPath,
+			"Unknown Circuit Breaker Configuration",
+			"The provider cannot be configured because part of the circuit_breaker block is not known until apply. "+
+				"Set the values statically in the configuration.",
+		)
+		return nil, diags
+	}
+	if !m.Enabled.IsNull() && !m.Enabled.ValueBool() {
+		return nil, diags
+	}
+
+	threshold := defaultBreakerThreshold
+	if !m.FailureThreshold.IsNull() {
+		threshold = int(m.FailureThreshold.ValueInt64())
+	}
+	coolDown := defaultBreakerCoolDown
+	if !m.CoolDown.IsNull() {
+		d, err := time.ParseDuration(m.CoolDown.ValueString())
+		if err != nil || d <= 0 {
+			diags.AddAttributeError(
+				breakerPath.AtName("cool_down"),
+				"Invalid Circuit Breaker Cool-Down",
+				fmt.Sprintf("circuit_breaker.cool_down must be a positive duration, e.g. \"30s\" or \"2m\", got %q.", m.CoolDown.ValueString()),
+			)
+			return nil, diags
+		}
+		coolDown = d
+	}
+	return newCircuitBreaker(threshold, coolDown), diags
+}
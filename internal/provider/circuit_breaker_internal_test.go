@@ -0,0 +1,250 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func newTestBreaker(threshold int, coolDown time.Duration) (*circuitBreaker, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	b := newCircuitBreaker(threshold, coolDown)
+	b.now = clock.now
+	return b, clock
+}
+
+func TestCircuitBreaker_OpensAfterConsecutiveServerErrors(t *testing.T) {
+	t.Parallel()
+
+	b, _ := newTestBreaker(3, time.Minute)
+	for _, status := range []int{503, 502, 200, 503, 524} {
+		if b.record(status) {
+			t.Fatalf("breaker opened after status %d; a success should have reset the count", status)
+		}
+	}
+	if _, err := b.allow(); err != nil {
+		t.Fatalf("allow() error = %v before the threshold", err)
+	}
+	if !b.record(504) {
+		t.Fatal("record() did not report opening on the third consecutive server error")
+	}
+
+	_, err := b.allow()
+	var openErr *CircuitOpenError
+	if !errors.As(err, &openErr) {
+		t.Fatalf("allow() error = %v, want *CircuitOpenError", err)
+	}
+	if openErr.Failures != 3 || openErr.LastStatus != 504 {
+		t.Errorf("CircuitOpenError = %+v, want 3 failures, last status 504", openErr)
+	}
+}
+
+// TestCircuitBreaker_IgnoresNonOutageServerErrors verifies that server
+// errors caused by one request, such as a 500 for an invalid payload, do not
+// count toward the threshold and reset the count like a success.
+func TestCircuitBreaker_IgnoresNonOutageServerErrors(t *testing.T) {
+	t.Parallel()
+
+	b, _ := newTestBreaker(2, time.Minute)
+	for _, status := range []int{500, 500, 501, 503, 500, 503} {
+		if b.record(status) {
+			t.Fatalf("breaker opened after status %d; only consecutive outage responses count", status)
+		}
+	}
+	if _, err := b.allow(); err != nil {
+		t.Fatalf("allow() error = %v, want the breaker closed", err)
+	}
+}
+
+func TestCircuitBreaker_HalfOpenProbe(t *testing.T) {
+	t.Parallel()
+
+	b, clock := newTestBreaker(1, time.Minute)
+	b.record(503)
+
+	clock.t = clock.t.Add(59 * time.Second)
+	if _, err := b.allow(); err == nil {
+		t.Fatal("allow() succeeded during the cool-down")
+	}
+
+	// After the cool-down exactly one probe goes through.
+	clock.t = clock.t.Add(time.Second)
+	if probe, err := b.allow(); err != nil || !probe {
+		t.Fatalf("allow() = %v, %v; want the probe", probe, err)
+	}
+	if _, err := b.allow(); err == nil {
+		t.Fatal("second request allowed while the probe is in flight")
+	}
+
+	// A failed probe opens the breaker for another cool-down.
+	b.record(503)
+	if _, err := b.allow(); err == nil {
+		t.Fatal("allow() succeeded after a failed probe")
+	}
+
+	// A probe without a response lets the next request probe.
+	clock.t = clock.t.Add(time.Minute)
+	if probe, _ := b.allow(); !probe {
+		t.Fatal("no probe after the second cool-down")
+	}
+	b.release()
+	if probe, err := b.allow(); err != nil || !probe {
+		t.Fatalf("allow() after release = %v, %v; want a new probe", probe, err)
+	}
+
+	// A successful probe closes the breaker.
+	b.record(200)
+	for range 3 {
+		if probe, err := b.allow(); err != nil || probe {
+			t.Fatalf("allow() after recovery = %v, %v; want closed", probe, err)
+		}
+	}
+}
+
+func TestDCIRetryClient_CircuitBreakerFailsFast(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	var healthy atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		if healthy.Load() {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	c := newTestRetryClient(5*time.Second, constantBackOff(time.Millisecond))
+	b, clock := newTestBreaker(3, time.Minute)
+	c.breaker = b
+
+	get := func() error {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+"/analytics/v1/reports", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := c.Do(req)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	// The retry loop stops as soon as the breaker opens instead of running
+	// until the 5s timeout.
+	var openErr *CircuitOpenError
+	if err := get(); !errors.As(err, &openErr) {
+		t.Fatalf("Do() error = %v, want *CircuitOpenError", err)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("server received %d requests, want 3", got)
+	}
+
+	// Later operations fail without reaching the server.
+	if err := get(); !errors.As(err, &openErr) {
+		t.Fatalf("Do() error = %v, want *CircuitOpenError", err)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("server received %d requests while open, want 3", got)
+	}
+
+	healthy.Store(true)
+	clock.t = clock.t.Add(time.Minute)
+	if err := get(); err != nil {
+		t.Fatalf("Do() after recovery error = %v", err)
+	}
+	if err := get(); err != nil {
+		t.Fatalf("Do() after the probe error = %v", err)
+	}
+}
+
+func TestCircuitBreakerFromConfig(t *testing.T) {
+	t.Parallel()
+
+	attrTypes := map[string]attr.Type{
+		"enabled":           types.BoolType,
+		"failure_threshold": types.Int64Type,
+		"cool_down":         types.StringType,
+	}
+	block := func(enabled types.Bool, threshold types.Int64, coolDown types.String) types.Object {
+		return types.ObjectValueMust(attrTypes, map[string]attr.Value{
+			"enabled":           enabled,
+			"failure_threshold": threshold,
+			"cool_down":         coolDown,
+		})
+	}
+
+	tests := []struct {
+		name          string
+		block         types.Object
+		wantNil       bool
+		wantThreshold int
+		wantCoolDown  time.Duration
+		wantErr       string
+	}{
+		{
+			name:    "absent",
+			block:   types.ObjectNull(attrTypes),
+			wantNil: true,
+		},
+		{
+			name:          "empty block",
+			block:         block(types.BoolNull(), types.Int64Null(), types.StringNull()),
+			wantThreshold: defaultBreakerThreshold,
+			wantCoolDown:  defaultBreakerCoolDown,
+		},
+		{
+			name:          "custom",
+			block:         block(types.BoolNull(), types.Int64Value(5), types.StringValue("2m")),
+			wantThreshold: 5,
+			wantCoolDown:  2 * time.Minute,
+		},
+		{
+			name:    "disabled",
+			block:   block(types.BoolValue(false), types.Int64Null(), types.StringNull()),
+			wantNil: true,
+		},
+		{
+			name:    "invalid cool-down",
+			block:   block(types.BoolNull(), types.Int64Null(), types.StringValue("soon")),
+			wantErr: "must be a positive duration",
+		},
+		{
+			name:    "unknown",
+			block:   block(types.BoolNull(), types.Int64Unknown(), types.StringNull()),
+			wantErr: "not known until apply",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			b, diags := circuitBreakerFromConfig(context.Background(), tt.block)
+			if tt.wantErr != "" {
+				if !diags.HasError() || !strings.Contains(diags.Errors()[0].Detail(), tt.wantErr) {
+					t.Fatalf("diags = %v, want an error containing %q", diags, tt.wantErr)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if (b == nil) != tt.wantNil {
+				t.Fatalf("breaker = %v, wantNil %v", b, tt.wantNil)
+			}
+			if b != nil && (b.threshold != tt.wantThreshold || b.coolDown != tt.wantCoolDown) {
+				t.Errorf("breaker = %d/%s, want %d/%s", b.threshold, b.coolDown, tt.wantThreshold, tt.wantCoolDown)
+			}
+		})
+	}
+}
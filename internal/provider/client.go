@@ -15,6 +15,7 @@ import (
 	"github.com/cenkalti/backoff/v5"
 	"github.com/doitintl/terraform-provider-doit/internal/provider/models"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/oauth2"
 )
 
@@ -45,13 +46,15 @@ import (
 //   - 504 (Gateway Timeout): Temporary timeout
 //
 // All other 4xx/5xx errors are treated as permanent failures (no retry). This
-// deliberately includes 524 — see httpStatusCloudflareTimeout.
+// deliberately includes 524 — see httpStatusCloudflareTimeout. The provider's
+// retry {} block can add statuses per HTTP method and tune the backoff; see
+// RetryPolicy.
 //
 // # NOT Suitable For
 //
 // Do NOT use this client for:
 //   - Non-DCI APIs that expect standard 404 handling
-//   - APIs where 500 should be retried (we don't retry 500)
+//   - APIs where 500 should be retried (we don't retry 500 unless configured)
 //   - APIs with different retry semantics
 //
 // If you need a general-purpose retry client, use go-retryablehttp instead.
@@ -59,7 +62,7 @@ type DCIRetryClient struct {
 	client *http.Client
 
 	// newBackOff builds the retry policy for a single Do call. When nil,
-	// newRetryBackOff is used. Tests inject a fast policy so they do not sleep
+	// the policy's newBackOff is used. Tests inject a fast policy so they do not sleep
 	// for real — the backoff library's timer hook is unexported, so this is the
 	// only way to control retry timing.
 	//
@@ -70,6 +73,43 @@ type DCIRetryClient struct {
 	// instance would have concurrent Do calls resetting and advancing each
 	// other's intervals.
 	newBackOff func() backoff.BackOff
+
+	// policy is the retry policy from the provider's retry {} block. Nil
+	// means DefaultRetryPolicy, so a bare DCIRetryClient literal behaves
+	// exactly as before the block existed.
+	policy *RetryPolicy
+
+	// limiter paces every attempt, retries included, across all concurrent
+	// operations. Nil means no client-side limit; see rate_limiter.go.
+	limiter *rateLimiter
+
+	// cache serves repeated reference-data GETs from memory. Nil disables it;
+	// see response_cache.go.
+	cache *responseCache
+
+	// tracer records a span per attempt. Nil disables tracing; see
+	// tracing.go.
+	tracer trace.Tracer
+
+	// wireLog logs redacted request and response bodies. Nil disables it;
+	// see wire_log.go.
+	wireLog *wireLogger
+
+	// breaker fails requests fast during a sustained API outage. Nil
+	// disables it; see circuit_breaker.go.
+	breaker *circuitBreaker
+
+	// readOnly rejects requests that could change data, as a safety net
+	// behind the resources' own read_only checks; see read_only.go.
+	readOnly bool
+}
+
+// retryPolicy returns the effective retry policy.
+func (c *DCIRetryClient) retryPolicy() RetryPolicy {
+	if c.policy == nil {
+		return DefaultRetryPolicy()
+	}
+	return *c.policy
 }
 
 // httpStatusCloudflareTimeout is Cloudflare's non-standard 524 "A Timeout
@@ -100,7 +140,8 @@ const (
 	// It is deliberately tied to retryMaxInterval — we never wait longer than
 	// our own policy's ceiling — which also keeps it well inside the smallest
 	// operation default, so a capped wait still leaves budget for the retry it
-	// was waiting for.
+	// was waiting for. A configured RetryPolicy.MaxInterval moves both
+	// together, and validateRetryPolicy checks the same budget at runtime.
 	maxRetryAfter = retryMaxInterval
 )
 
@@ -110,18 +151,6 @@ const (
 // than uint.
 const _ = uint64(DefaultReadTimeout - maxRetryAfter - minRetryHeadroom)
 
-// newRetryBackOff builds the default exponential retry policy.
-//
-// It returns the concrete type rather than backoff.BackOff so tests can assert
-// on the configured fields directly.
-func newRetryBackOff() *backoff.ExponentialBackOff {
-	b := backoff.NewExponentialBackOff()
-	b.InitialInterval = retryInitialInterval
-	b.Multiplier = retryMultiplier
-	b.MaxInterval = retryMaxInterval
-	return b
-}
-
 // parseRetryAfter interprets an HTTP Retry-After header value, returning the
 // duration to wait and whether the header was usable.
 //
@@ -137,9 +166,11 @@ func newRetryBackOff() *backoff.ExponentialBackOff {
 // cadence that never grows. Falling back to exponential backoff instead keeps
 // the retries spreading out.
 //
-// An honored value is clamped to [retryInitialInterval, maxRetryAfter], so a
-// date a few milliseconds out cannot produce a near-immediate retry either.
-func parseRetryAfter(header string, now time.Time) (time.Duration, bool) {
+// An honored value is clamped to [floor, ceiling] — the policy's
+// InitialInterval and MaxInterval, retryInitialInterval and maxRetryAfter by
+// default — so a date a few milliseconds out cannot produce a near-immediate
+// retry either.
+func parseRetryAfter(header string, now time.Time, floor, ceiling time.Duration) (time.Duration, bool) {
 	header = strings.TrimSpace(header)
 	if header == "" {
 		return 0, false
@@ -154,8 +185,8 @@ func parseRetryAfter(header string, now time.Time) (time.Duration, bool) {
 		if seconds <= 0 {
 			return 0, false
 		}
-		if seconds >= int(maxRetryAfter/time.Second) {
-			return maxRetryAfter, true
+		if seconds >= int(ceiling/time.Second) {
+			return ceiling, true
 		}
 		wait = time.Duration(seconds) * time.Second
 	} else {
@@ -169,7 +200,7 @@ func parseRetryAfter(header string, now time.Time) (time.Duration, bool) {
 	if wait <= 0 {
 		return 0, false
 	}
-	return min(max(wait, retryInitialInterval), maxRetryAfter), true
+	return min(max(wait, floor), ceiling), true
 }
 
 // Do executes an HTTP request with retry logic for transient errors.
@@ -185,17 +216,47 @@ func parseRetryAfter(header string, now time.Time) (time.Duration, bool) {
 // |-------------|----------|
 // | 200, 201, 202, 204 | Success - return response |
 // | 404 | Pass through - NOT an error (for Terraform resource semantics) |
-// | 429 | Retry with Retry-After or exponential backoff |
+// | 429 | Retry with Retry-After or exponential backoff; lowers the shared rate limit |
 // | 502, 503, 504 | Retry with exponential backoff |
 // | 524 | Permanent error - no retry (Cloudflare edge timeout) |
-// | Other 4xx/5xx | Permanent error - no retry |
+// | Other 4xx/5xx | Permanent error, unless listed for the method in RetryPolicy.ExtraRetryableStatuses |
 //
 // # Timeout
 //
 // The retry loop has no elapsed-time limit of its own (MaxElapsedTime is 0). It
 // defers entirely to the deadline on the request's context, which is the
-// Terraform operation timeout — see timeouts.go.
+// Terraform operation timeout — see timeouts.go. RetryPolicy.MaxAttempts can
+// additionally cap the number of attempts.
+//
+// # Rate Limiting
+//
+// When the provider sets max_requests_per_second, every attempt first waits for
+// a token from the limiter shared by all operations. Waiting counts against the
+// operation timeout like any other delay.
+//
+// # Response Cache
+//
+// When enabled, reference-data GETs are answered from the response cache and
+// concurrent identical ones share a single request; see responseCache.
+//
+// # Read-Only Mode
+//
+// When the provider sets read_only, requests that could change data fail
+// before being sent, and are not retried; see allowedWhenReadOnly.
 func (c *DCIRetryClient) Do(req *http.Request) (*http.Response, error) {
+	if c.readOnly && !allowedWhenReadOnly(req) {
+		return nil, fmt.Errorf("%w: refusing %s %s", errReadOnly, req.Method, req.URL.Path)
+	}
+	if c.cache != nil {
+		return c.cache.Do(req, c.doWithRetry)
+	}
+	return c.doWithRetry(req)
+}
+
+// doWithRetry implements Do without the response cache.
+func (c *DCIRetryClient) doWithRetry(req *http.Request) (*http.Response, error) {
+	policy := c.retryPolicy()
+
 	// Preserve the original body for retries.
 	// If the request has a body, we need to be able to re-read it on retries.
 	var bodyBytes []byte
@@ -210,17 +271,74 @@ func (c *DCIRetryClient) Do(req *http.Request) (*http.Response, error) {
 		}
 	}
 
-	operation := func() (*http.Response, error) {
+	// attempt and backoffWait describe the attempt in progress for its
+	// trace span; backoff.Retry calls operation sequentially.
+	var (
+		attempt     int
+		backoffWait time.Duration
+	)
+
+	operation := func() (_ *http.Response, err error) {
+		attempt++
+		var (
+			status        int
+			rateLimitWait time.Duration
+		)
+		if c.tracer != nil {
+			span := startAttemptSpan(c.tracer, req, attempt, backoffWait)
+			defer func() { endAttemptSpan(span, status, rateLimitWait, err) }()
+		}
+
 		// Reset the body for each retry attempt
 		if bodyBytes != nil {
 			req.Body = io.NopCloser(bytes.NewReader(bodyBytes))
 			req.ContentLength = int64(len(bodyBytes))
 		}
 
+		if c.breaker != nil {
+			probe, err := c.breaker.allow()
+			if err != nil {
+				return nil, backoff.Permanent(err)
+			}
+			if probe {
+				// A probe that gets no response must not block the next one.
+				defer func() {
+					if status == 0 {
+						c.breaker.release()
+					}
+				}()
+			}
+		}
+
+		if c.limiter != nil {
+			start := time.Now()
+			if err := c.limiter.Wait(req.Context()); err != nil {
+				return nil, backoff.Permanent(err)
+			}
+			rateLimitWait = time.Since(start)
+		}
+
+		if c.wireLog != nil {
+			c.wireLog.logRequest(req.Context(), req, bodyBytes, attempt)
+		}
+
 		resp, err := c.client.Do(req) //nolint:gosec // G704: host is operator-controlled provider config, paths are generated by oapi-codegen client
 		if err != nil {
 			return nil, err
 		}
+		status = resp.StatusCode
+
+		if c.wireLog != nil {
+			c.wireLog.logResponse(req.Context(), req, resp)
+		}
+
+		if c.breaker != nil && c.breaker.record(resp.StatusCode) {
+			tflog.Warn(req.Context(), "Circuit breaker opened after consecutive outage responses, failing remaining requests fast", map[string]any{
+				"url":       req.URL.String(),
+				"status":    resp.StatusCode,
+				"cool_down": c.breaker.coolDown.String(),
+			})
+		}
 
 		// Retryable status codes:
 		// - 429: Too Many Requests (rate limiting)
@@ -235,7 +353,17 @@ func (c *DCIRetryClient) Do(req *http.Request) (*http.Response, error) {
 				log.Printf("[WARN] Error closing response body: %v", closeErr)
 			}
 
-			if wait, ok := parseRetryAfter(retryAfter, time.Now()); ok {
+			// Slow every operation down, not just this one: the 429 is a
+			// signal about the shared request rate.
+			if c.limiter != nil {
+				if rate, lowered := c.limiter.throttled(); lowered {
+					tflog.Debug(req.Context(), "Rate limited, lowering client request rate", map[string]any{
+						"requests_per_second": rate,
+					})
+				}
+			}
+
+			if wait, ok := parseRetryAfter(retryAfter, time.Now(), policy.InitialInterval, policy.MaxInterval); ok {
 				tflog.Debug(req.Context(), "Rate limited, honoring Retry-After", map[string]any{
 					"url":         req.URL.String(),
 					"retry_after": retryAfter,
@@ -262,9 +390,26 @@ func (c *DCIRetryClient) Do(req *http.Request) (*http.Response, error) {
 			// Note: 404 is NOT an error here. Resource handlers interpret it contextually:
 			// - Read: externally deleted → remove from state
 			// - Delete: already gone → success
+			if c.limiter != nil {
+				c.limiter.succeeded()
+			}
 			return resp, nil
 
 		default:
+			// Statuses the user opted into retrying for this method. Validation
+			// keeps 404 and 524 out of this list; see validateRetryPolicy.
+			if policy.isExtraRetryable(req.Method, resp.StatusCode) {
+				if closeErr := resp.Body.Close(); closeErr != nil {
+					log.Printf("[WARN] Error closing response body: %v", closeErr)
+				}
+				tflog.Debug(req.Context(), "Retrying configured status", map[string]any{
+					"url":    req.URL.String(),
+					"method": req.Method,
+					"status": resp.StatusCode,
+				})
+				return nil, fmt.Errorf("configured retryable error: %d", resp.StatusCode)
+			}
+
 			// All other status codes are considered permanent errors
 			// This includes:
 			// - 4xx client errors (400, 401, 403, etc.)
@@ -280,9 +425,9 @@ func (c *DCIRetryClient) Do(req *http.Request) (*http.Response, error) {
 					return nil, backoff.Permanent(fmt.Errorf("non-retryable error: %d, failed to read body: %w", resp.StatusCode, readErr))
 				}
 				if closeErr != nil {
-					return nil, backoff.Permanent(fmt.Errorf("non-retryable error: %d, body: %s, failed to close body: %w", resp.StatusCode, string(respBodyBytes), closeErr))
+					log.Printf("[WARN] Error closing response body: %v", closeErr)
 				}
-				return nil, backoff.Permanent(fmt.Errorf("non-retryable error: %d, body: %s", resp.StatusCode, string(respBodyBytes)))
+				return nil, backoff.Permanent(newAPIError(resp.StatusCode, resp.Header, respBodyBytes))
 			}
 			// 2xx and 3xx codes that aren't explicitly handled above
 			return resp, nil
@@ -293,16 +438,103 @@ func (c *DCIRetryClient) Do(req *http.Request) (*http.Response, error) {
 	// client is shared across concurrent Terraform operations.
 	newBackOff := c.newBackOff
 	if newBackOff == nil {
-		newBackOff = func() backoff.BackOff { return newRetryBackOff() }
+		newBackOff = func() backoff.BackOff { return policy.newBackOff() }
 	}
 
 	// Retry with exponential backoff. MaxElapsedTime is disabled (0) so the
 	// retry loop defers entirely to the provided context's deadline
 	// (e.g., Terraform's timeouts {} block).
-	return backoff.Retry(req.Context(), operation,
+	opts := append([]backoff.RetryOption{
 		backoff.WithBackOff(newBackOff()),
 		backoff.WithMaxElapsedTime(0),
-	)
+		backoff.WithNotify(func(_ error, wait time.Duration) { backoffWait = wait }),
+	}, policy.retryOptions()...)
+	return backoff.Retry(req.Context(), operation, opts...)
+}
+
+// clientConfig collects what ClientOptions set before NewClient builds the
+// API client.
+type clientConfig struct {
+	retryClient *DCIRetryClient
+
+	// tokenSource replaces the static apiToken when set.
+	tokenSource oauth2.TokenSource
+}
+
+// ClientOption configures optional NewClient behavior.
+type ClientOption func(*clientConfig)
+
+// WithRetryPolicy replaces DefaultRetryPolicy. The policy should already have
+// passed validateRetryPolicy.
+func WithRetryPolicy(p RetryPolicy) ClientOption {
+	return func(c *clientConfig) {
+		c.retryClient.policy = &p
+	}
+}
+
+// WithRateLimit limits requests to requestsPerSecond, with bursts of up to
+// burst requests, across every operation sharing the client. A 429 lowers the
+// rate and successful responses raise it back.
+func WithRateLimit(requestsPerSecond float64, burst int) ClientOption {
+	return func(c *clientConfig) {
+		c.retryClient.limiter = newRateLimiter(requestsPerSecond, burst)
+	}
+}
+
+// WithResponseCache enables the reference-data response cache.
+func WithResponseCache() ClientOption {
+	return func(c *clientConfig) {
+		c.retryClient.cache = newResponseCache()
+	}
+}
+
+// WithTokenSource obtains the API token from ts for every request instead of
+// using the static apiToken, so a rotated token is picked up without
+// reconfiguring the provider. See token_source.go.
+func WithTokenSource(ts oauth2.TokenSource) ClientOption {
+	return func(c *clientConfig) {
+		c.tokenSource = ts
+	}
+}
+
+// WithTransport sends requests through t instead of http.DefaultTransport.
+// See transport.go.
+func WithTransport(t http.RoundTripper) ClientOption {
+	return func(c *clientConfig) {
+		c.retryClient.client.Transport = t
+	}
+}
+
+// WithTracer records a span for every request attempt. See tracing.go.
+func WithTracer(t trace.Tracer) ClientOption {
+	return func(c *clientConfig) {
+		c.retryClient.tracer = t
+	}
+}
+
+// WithWireLogging logs every request and response, redacted, with bodies
+// truncated to maxBody bytes. See wire_log.go.
+func WithWireLogging(maxBody int) ClientOption {
+	return func(c *clientConfig) {
+		c.retryClient.wireLog = &wireLogger{maxBody: maxBody}
+	}
+}
+
+// WithCircuitBreaker fails requests fast once threshold consecutive server
+// errors are received, probing the API again after coolDown. See
+// circuit_breaker.go.
+func WithCircuitBreaker(threshold int, coolDown time.Duration) ClientOption {
+	return func(c *clientConfig) {
+		c.retryClient.breaker = newCircuitBreaker(threshold, coolDown)
+	}
+}
+
+// WithReadOnly rejects every request that could change data. See
+// read_only.go.
+func WithReadOnly() ClientOption {
+	return func(c *clientConfig) {
+		c.retryClient.readOnly = true
+	}
 }
 
 // NewClient creates a new API client with retry logic.
@@ -314,16 +546,23 @@ func (c *DCIRetryClient) Do(req *http.Request) (*http.Response, error) {
 //
 // The TF_APPEND_USER_AGENT environment variable is also respected, allowing
 // users to append custom identifiers (e.g., CI system, org name).
-func NewClient(ctx context.Context, host, apiToken, customerContext, terraformVersion, providerVersion string, requestTimeout time.Duration) (*models.ClientWithResponses, error) {
+func NewClient(ctx context.Context, host, apiToken, customerContext, terraformVersion, providerVersion string, requestTimeout time.Duration, opts ...ClientOption) (*models.ClientWithResponses, error) {
 	retryClient := &DCIRetryClient{
 		client: &http.Client{
 			Timeout: requestTimeout,
 		},
 	}
+	cfg := clientConfig{retryClient: retryClient}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
 
-	ts := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: apiToken},
-	)
+	ts := cfg.tokenSource
+	if ts == nil {
+		ts = oauth2.StaticTokenSource(
+			&oauth2.Token{AccessToken: apiToken},
+		)
+	}
 
 	userAgent := fmt.Sprintf("Terraform/%s terraform-provider-doit/%s", terraformVersion, providerVersion)
 	if add := strings.TrimSpace(os.Getenv("TF_APPEND_USER_AGENT")); add != "" {
@@ -332,17 +571,21 @@ func NewClient(ctx context.Context, host, apiToken, customerContext, terraformVe
 
 	client, err := models.NewClientWithResponses(host,
 		models.WithHTTPClient(retryClient),
-		models.WithRequestEditorFn(func(_ context.Context, req *http.Request) error {
+		models.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
 			token, err := ts.Token()
 			if err != nil {
 				return err
 			}
 			token.SetAuthHeader(req)
 			req.Header.Set("User-Agent", userAgent)
-			if customerContext != "" {
-				// DoiT employees scope requests to a customer via the
-				// X-Tenant-Id header. This replaced the former
-				// customerContext query parameter.
+			// DoiT employees scope requests to a customer via the
+			// X-Tenant-Id header. This replaced the former customerContext
+			// query parameter. A resource or data source with its own
+			// customer_context overrides the provider's; see
+			// customer_context.go.
+			if tenant, ok := customerContextFromContext(ctx); ok {
+				req.Header.Set("X-Tenant-Id", tenant)
+			} else if customerContext != "" {
 				req.Header.Set("X-Tenant-Id", customerContext)
 			}
 			return nil
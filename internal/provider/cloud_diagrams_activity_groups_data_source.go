@@ -32,7 +32,8 @@ type cloudDiagramsActivityGroupsDataSource struct {
 // cloudDiagramsActivityGroupsDataSourceModel is the Terraform state model.
 type cloudDiagramsActivityGroupsDataSourceModel struct {
 	ds.CloudDiagramsActivityGroupsModel
-	Timeouts timeouts.Value `tfsdk:"timeouts"`
+	CustomerContext types.String   `tfsdk:"customer_context"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (d *cloudDiagramsActivityGroupsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -41,6 +42,7 @@ func (d *cloudDiagramsActivityGroupsDataSource) Metadata(_ context.Context, req
 
 func (d *cloudDiagramsActivityGroupsDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	s := ds.CloudDiagramsActivityGroupsDataSourceSchema(ctx)
+	s.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx)
 	s.Description = "Retrieves snapshot activity groups for a Cloud Diagram layer."
 	s.MarkdownDescription = "Retrieves snapshot activity groups for a Cloud Diagram layer."
@@ -78,6 +80,7 @@ func (d *cloudDiagramsActivityGroupsDataSource) Read(ctx context.Context, req da
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	if !req.Config.Raw.IsFullyKnown() {
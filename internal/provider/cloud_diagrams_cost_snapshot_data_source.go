@@ -30,7 +30,8 @@ type cloudDiagramsCostSnapshotDataSource struct {
 
 type cloudDiagramsCostSnapshotDataSourceModel struct {
 	datasource_cloud_diagrams_cost_snapshot.CloudDiagramsCostSnapshotModel
-	Timeouts timeouts.Value `tfsdk:"timeouts"`
+	CustomerContext types.String   `tfsdk:"customer_context"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (d *cloudDiagramsCostSnapshotDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -40,6 +41,7 @@ func (d *cloudDiagramsCostSnapshotDataSource) Metadata(_ context.Context, req da
 func (d *cloudDiagramsCostSnapshotDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	genSchema := datasource_cloud_diagrams_cost_snapshot.CloudDiagramsCostSnapshotDataSourceSchema(ctx)
 
+	genSchema.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	genSchema.Attributes["timeouts"] = timeouts.Attributes(ctx)
 
 	genSchema.Description = "Returns a bounded cost snapshot for a Cloud Diagram layer over a date window."
@@ -79,6 +81,7 @@ func (d *cloudDiagramsCostSnapshotDataSource) Read(ctx context.Context, req data
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	if !req.Config.Raw.IsFullyKnown() {
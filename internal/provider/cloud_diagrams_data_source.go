@@ -34,10 +34,11 @@ type cloudDiagramsDataSource struct {
 // cloudDiagramsDataSourceModel is the Terraform state model.
 // The CloudDiagrams field uses the generated tfsdk tag "cloud_diagrams" to match the generated schema.
 type cloudDiagramsDataSourceModel struct {
-	Id            types.String   `tfsdk:"id"`
-	Resources     types.List     `tfsdk:"resources"`
-	CloudDiagrams types.Set      `tfsdk:"cloud_diagrams"`
-	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+	Id              types.String   `tfsdk:"id"`
+	Resources       types.List     `tfsdk:"resources"`
+	CloudDiagrams   types.Set      `tfsdk:"cloud_diagrams"`
+	CustomerContext types.String   `tfsdk:"customer_context"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (d *cloudDiagramsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -62,6 +63,7 @@ func (d *cloudDiagramsDataSource) Schema(ctx context.Context, _ datasource.Schem
 		MarkdownDescription: "Resource IDs to find diagrams for.",
 	}
 
+	genSchema.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	genSchema.Attributes["timeouts"] = timeouts.Attributes(ctx)
 
 	resp.Schema = genSchema
@@ -98,6 +100,7 @@ func (d *cloudDiagramsDataSource) Read(ctx context.Context, req datasource.ReadR
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	// If the config contains any unknown values (e.g., a list element like
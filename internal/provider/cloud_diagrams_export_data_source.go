@@ -34,7 +34,8 @@ type cloudDiagramsExportDataSource struct {
 // cloudDiagramsExportDataSourceModel is the Terraform state model.
 type cloudDiagramsExportDataSourceModel struct {
 	ds.CloudDiagramsExportModel
-	Timeouts timeouts.Value `tfsdk:"timeouts"`
+	CustomerContext types.String   `tfsdk:"customer_context"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (d *cloudDiagramsExportDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -43,6 +44,7 @@ func (d *cloudDiagramsExportDataSource) Metadata(_ context.Context, req datasour
 
 func (d *cloudDiagramsExportDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	s := ds.CloudDiagramsExportDataSourceSchema(ctx)
+	s.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx)
 	s.Description = "Exports the full content of a Cloud Diagram layer as a structured document with anonymized component IDs."
 	s.MarkdownDescription = "Exports the full content of a Cloud Diagram layer as a structured document with anonymized component IDs."
@@ -80,6 +82,7 @@ func (d *cloudDiagramsExportDataSource) Read(ctx context.Context, req datasource
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	if !req.Config.Raw.IsFullyKnown() {
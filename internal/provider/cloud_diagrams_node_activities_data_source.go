@@ -37,6 +37,7 @@ type cloudDiagramsNodeActivitiesDataSourceModel struct {
 	Limit                       types.Int64    `tfsdk:"limit"`
 	Offset                      types.Int64    `tfsdk:"offset"`
 	CloudDiagramsNodeActivities types.Set      `tfsdk:"cloud_diagrams_node_activities"`
+	CustomerContext             types.String   `tfsdk:"customer_context"`
 	Timeouts                    timeouts.Value `tfsdk:"timeouts"`
 }
 
@@ -54,6 +55,7 @@ func (d *cloudDiagramsNodeActivitiesDataSource) Schema(ctx context.Context, _ da
 		MarkdownDescription: "A deterministic hash of the query parameters, used as the data source identifier.",
 	}
 
+	genSchema.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	genSchema.Attributes["timeouts"] = timeouts.Attributes(ctx)
 
 	genSchema.Description = "Retrieves individual activity records for a specific Cloud Diagram component node."
@@ -93,6 +95,7 @@ func (d *cloudDiagramsNodeActivitiesDataSource) Read(ctx context.Context, req da
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	// If the config contains any unknown values, return all computed attributes as unknown.
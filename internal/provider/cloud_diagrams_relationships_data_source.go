@@ -30,7 +30,8 @@ type cloudDiagramsRelationshipsDataSource struct {
 // cloudDiagramsRelationshipsDataSourceModel is the Terraform state model.
 type cloudDiagramsRelationshipsDataSourceModel struct {
 	ds.CloudDiagramsRelationshipsModel
-	Timeouts timeouts.Value `tfsdk:"timeouts"`
+	CustomerContext types.String   `tfsdk:"customer_context"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (d *cloudDiagramsRelationshipsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -39,6 +40,7 @@ func (d *cloudDiagramsRelationshipsDataSource) Metadata(_ context.Context, req d
 
 func (d *cloudDiagramsRelationshipsDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	s := ds.CloudDiagramsRelationshipsDataSourceSchema(ctx)
+	s.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx)
 	s.Description = "Retrieves resource relationships for a Cloud Diagram component by traversing the diagram graph."
 	s.MarkdownDescription = "Retrieves resource relationships for a Cloud Diagram component by traversing the diagram graph."
@@ -76,6 +78,7 @@ func (d *cloudDiagramsRelationshipsDataSource) Read(ctx context.Context, req dat
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	// Handle unknown inputs during planning.
@@ -39,10 +39,11 @@ type cloudDiagramsSchemesDataSource struct {
 // It wraps the generated model and adds fields the generator cannot produce.
 type cloudDiagramsSchemesDataSourceModel struct {
 	datasource_cloud_diagrams_schemes.CloudDiagramsSchemesModel
-	Id        types.String   `tfsdk:"id"`
-	SchemeIds types.List     `tfsdk:"scheme_ids"`
-	LayerIds  types.List     `tfsdk:"layer_ids"`
-	Timeouts  timeouts.Value `tfsdk:"timeouts"`
+	Id              types.String   `tfsdk:"id"`
+	SchemeIds       types.List     `tfsdk:"scheme_ids"`
+	LayerIds        types.List     `tfsdk:"layer_ids"`
+	CustomerContext types.String   `tfsdk:"customer_context"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (d *cloudDiagramsSchemesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -73,6 +74,7 @@ func (d *cloudDiagramsSchemesDataSource) Schema(ctx context.Context, _ datasourc
 		MarkdownDescription: "IDs of layers to load. When omitted, returns all layers for the selected diagrams.",
 	}
 
+	genSchema.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	genSchema.Attributes["timeouts"] = timeouts.Attributes(ctx)
 
 	genSchema.Description = "Retrieves Cloud Diagram data including diagrams, layers, and components."
@@ -112,6 +114,7 @@ func (d *cloudDiagramsSchemesDataSource) Read(ctx context.Context, req datasourc
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	// If the config contains any unknown values, return all computed attributes as unknown.
@@ -34,15 +34,16 @@ type cloudDiagramsSearchDataSource struct {
 
 // cloudDiagramsSearchDataSourceModel is the Terraform state model.
 type cloudDiagramsSearchDataSourceModel struct {
-	Id        types.String   `tfsdk:"id"`
-	Query     types.String   `tfsdk:"query"`
-	SsId      types.String   `tfsdk:"ss_id"`
-	From      types.Int64    `tfsdk:"from"`
-	Size      types.Int64    `tfsdk:"size"`
-	Scheme    types.List     `tfsdk:"scheme"`
-	Component types.List     `tfsdk:"component"`
-	Prop      types.List     `tfsdk:"prop"`
-	Timeouts  timeouts.Value `tfsdk:"timeouts"`
+	Id              types.String   `tfsdk:"id"`
+	Query           types.String   `tfsdk:"query"`
+	SsId            types.String   `tfsdk:"ss_id"`
+	From            types.Int64    `tfsdk:"from"`
+	Size            types.Int64    `tfsdk:"size"`
+	Scheme          types.List     `tfsdk:"scheme"`
+	Component       types.List     `tfsdk:"component"`
+	Prop            types.List     `tfsdk:"prop"`
+	CustomerContext types.String   `tfsdk:"customer_context"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (d *cloudDiagramsSearchDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -87,6 +88,7 @@ func (d *cloudDiagramsSearchDataSource) Schema(ctx context.Context, _ datasource
 		},
 	}
 
+	genSchema.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	genSchema.Attributes["timeouts"] = timeouts.Attributes(ctx)
 
 	resp.Schema = genSchema
@@ -126,6 +128,7 @@ func (d *cloudDiagramsSearchDataSource) Read(ctx context.Context, req datasource
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	// If the config contains any unknown values (e.g., query depends on an
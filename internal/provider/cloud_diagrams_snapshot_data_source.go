@@ -29,12 +29,13 @@ type cloudDiagramsSnapshotDataSource struct {
 
 // cloudDiagramsSnapshotDataSourceModel is the Terraform state model.
 type cloudDiagramsSnapshotDataSourceModel struct {
-	Id         types.String   `tfsdk:"id"`
-	SnapshotId types.String   `tfsdk:"snapshot_id"`
-	Name       types.String   `tfsdk:"name"`
-	CreatedAt  types.String   `tfsdk:"created_at"`
-	PrevState  types.String   `tfsdk:"prev_state"`
-	Timeouts   timeouts.Value `tfsdk:"timeouts"`
+	Id              types.String   `tfsdk:"id"`
+	SnapshotId      types.String   `tfsdk:"snapshot_id"`
+	Name            types.String   `tfsdk:"name"`
+	CreatedAt       types.String   `tfsdk:"created_at"`
+	PrevState       types.String   `tfsdk:"prev_state"`
+	CustomerContext types.String   `tfsdk:"customer_context"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (d *cloudDiagramsSnapshotDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -49,6 +50,7 @@ func (d *cloudDiagramsSnapshotDataSource) Schema(ctx context.Context, _ datasour
 	// tags (tags must start with a letter).
 	delete(genSchema.Attributes, "_id")
 
+	genSchema.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	genSchema.Attributes["timeouts"] = timeouts.Attributes(ctx)
 
 	genSchema.Description = "Retrieves a single snapshot of a Cloud Diagram layer."
@@ -88,6 +90,7 @@ func (d *cloudDiagramsSnapshotDataSource) Read(ctx context.Context, req datasour
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	// If the config contains any unknown values, return all computed attributes as unknown.
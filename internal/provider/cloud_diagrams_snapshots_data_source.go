@@ -31,7 +31,8 @@ type cloudDiagramsSnapshotsDataSource struct {
 // cloudDiagramsSnapshotsDataSourceModel is the Terraform state model.
 type cloudDiagramsSnapshotsDataSourceModel struct {
 	ds.CloudDiagramsSnapshotsModel
-	Timeouts timeouts.Value `tfsdk:"timeouts"`
+	CustomerContext types.String   `tfsdk:"customer_context"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (d *cloudDiagramsSnapshotsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -40,6 +41,7 @@ func (d *cloudDiagramsSnapshotsDataSource) Metadata(_ context.Context, req datas
 
 func (d *cloudDiagramsSnapshotsDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	s := ds.CloudDiagramsSnapshotsDataSourceSchema(ctx)
+	s.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx)
 	resp.Schema = s
 }
@@ -75,6 +77,7 @@ func (d *cloudDiagramsSnapshotsDataSource) Read(ctx context.Context, req datasou
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	// If the config contains any unknown values (e.g., id, limit, offset, or sort is unknown during plan),
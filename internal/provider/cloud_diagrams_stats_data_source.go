@@ -37,6 +37,7 @@ type cloudDiagramsStatsDataSourceModel struct {
 	Start              types.String   `tfsdk:"start"`
 	End                types.String   `tfsdk:"end"`
 	CloudDiagramsStats types.Set      `tfsdk:"cloud_diagrams_stats"`
+	CustomerContext    types.String   `tfsdk:"customer_context"`
 	Timeouts           timeouts.Value `tfsdk:"timeouts"`
 }
 
@@ -64,6 +65,7 @@ func (d *cloudDiagramsStatsDataSource) Schema(ctx context.Context, _ datasource.
 		genSchema.Attributes["end"] = endAttr
 	}
 
+	genSchema.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	genSchema.Attributes["timeouts"] = timeouts.Attributes(ctx)
 
 	resp.Schema = genSchema
@@ -100,6 +102,7 @@ func (d *cloudDiagramsStatsDataSource) Read(ctx context.Context, req datasource.
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	// If the config contains any unknown values, return all computed attributes as unknown.
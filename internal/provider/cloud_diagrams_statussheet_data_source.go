@@ -37,23 +37,24 @@ type cloudDiagramsStatussheetDataSource struct {
 
 // cloudDiagramsStatussheetDataSourceModel is the Terraform state model.
 type cloudDiagramsStatussheetDataSourceModel struct {
-	Id            types.String   `tfsdk:"id"`
-	P             types.String   `tfsdk:"p"`
-	NodeIds       types.List     `tfsdk:"node_ids"`
-	ElementIds    types.List     `tfsdk:"element_ids"`
-	GroupIds      types.List     `tfsdk:"group_ids"`
-	LinkIds       types.List     `tfsdk:"link_ids"`
-	AttachmentIds types.List     `tfsdk:"attachment_ids"`
-	CombinerIds   types.List     `tfsdk:"combiner_ids"`
-	NoteIds       types.List     `tfsdk:"note_ids"`
-	Node          types.Map      `tfsdk:"node"`
-	Element       types.Map      `tfsdk:"element"`
-	Group         types.Map      `tfsdk:"group"`
-	Link          types.Map      `tfsdk:"link"`
-	Attachment    types.Map      `tfsdk:"attachment"`
-	Combiner      types.Map      `tfsdk:"combiner"`
-	Note          types.Map      `tfsdk:"note"`
-	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+	Id              types.String   `tfsdk:"id"`
+	P               types.String   `tfsdk:"p"`
+	NodeIds         types.List     `tfsdk:"node_ids"`
+	ElementIds      types.List     `tfsdk:"element_ids"`
+	GroupIds        types.List     `tfsdk:"group_ids"`
+	LinkIds         types.List     `tfsdk:"link_ids"`
+	AttachmentIds   types.List     `tfsdk:"attachment_ids"`
+	CombinerIds     types.List     `tfsdk:"combiner_ids"`
+	NoteIds         types.List     `tfsdk:"note_ids"`
+	Node            types.Map      `tfsdk:"node"`
+	Element         types.Map      `tfsdk:"element"`
+	Group           types.Map      `tfsdk:"group"`
+	Link            types.Map      `tfsdk:"link"`
+	Attachment      types.Map      `tfsdk:"attachment"`
+	Combiner        types.Map      `tfsdk:"combiner"`
+	Note            types.Map      `tfsdk:"note"`
+	CustomerContext types.String   `tfsdk:"customer_context"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (d *cloudDiagramsStatussheetDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -94,6 +95,7 @@ func (d *cloudDiagramsStatussheetDataSource) Schema(ctx context.Context, _ datas
 		}
 	}
 
+	genSchema.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	genSchema.Attributes["timeouts"] = timeouts.Attributes(ctx)
 
 	genSchema.Description = "Retrieves the components of a specific Cloud Diagram layer. At least one component ID list must be provided."
@@ -139,6 +141,7 @@ func (d *cloudDiagramsStatussheetDataSource) Read(ctx context.Context, req datas
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	// If the config contains any unknown values, return all computed attributes as unknown.
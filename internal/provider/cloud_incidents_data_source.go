@@ -25,7 +25,8 @@ type cloudIncidentsDataSource struct {
 
 type cloudIncidentsDataSourceModel struct {
 	datasource_cloud_incidents.CloudIncidentsModel
-	Timeouts timeouts.Value `tfsdk:"timeouts"`
+	CustomerContext types.String   `tfsdk:"customer_context"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (d *cloudIncidentsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -35,6 +36,7 @@ func (d *cloudIncidentsDataSource) Metadata(ctx context.Context, req datasource.
 func (d *cloudIncidentsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	s := datasource_cloud_incidents.CloudIncidentsDataSourceSchema(ctx)
 
+	s.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx)
 
 	resp.Schema = s
@@ -71,6 +73,7 @@ func (d *cloudIncidentsDataSource) Read(ctx context.Context, req datasource.Read
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	// If any filter/pagination input is unknown, return unknown list
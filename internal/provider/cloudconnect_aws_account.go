@@ -18,10 +18,7 @@ import (
 func (r *cloudconnectAwsAccountResource) populateState(ctx context.Context, state *cloudconnectAwsAccountResourceModel) diag.Diagnostics {
 	accountResp, err := r.client.GetAwsAccountWithResponse(ctx, state.AccountId.ValueString())
 	if err != nil {
-		return diag.Diagnostics{
-			diag.NewErrorDiagnostic("Error Reading CloudConnect AWS Account",
-				"Could not read CloudConnect AWS account ID "+state.AccountId.ValueString()+": "+err.Error()),
-		}
+		return apiErrorDiagnostics("Error Reading CloudConnect AWS Account", "Could not read CloudConnect AWS account ID "+state.AccountId.ValueString(), err)
 	}
 
 	if accountResp.StatusCode() == 404 {
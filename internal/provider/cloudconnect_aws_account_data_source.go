@@ -28,7 +28,8 @@ type (
 	}
 	cloudconnectAwsAccountDataSourceModel struct {
 		datasource_cloudconnect_aws_account.CloudconnectAwsAccountModel
-		Timeouts timeouts.Value `tfsdk:"timeouts"`
+		CustomerContext types.String   `tfsdk:"customer_context"`
+		Timeouts        timeouts.Value `tfsdk:"timeouts"`
 	}
 )
 
@@ -42,6 +43,7 @@ func (d *cloudconnectAwsAccountDataSource) Schema(ctx context.Context, _ datasou
 	s.MarkdownDescription = "Retrieve a CloudConnect AWS account by its AWS account ID."
 	s.Description = "Retrieve a CloudConnect AWS account by its AWS account ID."
 
+	s.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx)
 
 	resp.Schema = s
@@ -78,6 +80,7 @@ func (d *cloudconnectAwsAccountDataSource) Read(ctx context.Context, req datasou
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	if data.AccountId.IsUnknown() {
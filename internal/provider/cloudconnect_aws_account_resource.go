@@ -12,15 +12,18 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 type (
 	cloudconnectAwsAccountResource struct {
-		client *models.ClientWithResponses
+		client   *models.ClientWithResponses
+		readOnly bool
 	}
 	cloudconnectAwsAccountResourceModel struct {
 		resource_cloudconnect_aws_account.CloudconnectAwsAccountModel
-		Timeouts timeouts.Value `tfsdk:"timeouts"`
+		CustomerContext types.String   `tfsdk:"customer_context"`
+		Timeouts        timeouts.Value `tfsdk:"timeouts"`
 	}
 )
 
@@ -43,16 +46,17 @@ func (r *cloudconnectAwsAccountResource) Configure(_ context.Context, req resour
 		return
 	}
 
-	client, ok := req.ProviderData.(*models.ClientWithResponses)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *models.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.readOnly = data.readOnly
 }
 
 func (r *cloudconnectAwsAccountResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -60,6 +64,10 @@ func (r *cloudconnectAwsAccountResource) Metadata(_ context.Context, req resourc
 }
 
 func (r *cloudconnectAwsAccountResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, id, diags := importCustomerContext(ctx, req, resp, 1)
+	resp.Diagnostics.Append(diags...)
+	req.ID = id
+
 	resource.ImportStatePassthroughID(ctx, path.Root("account_id"), req, resp)
 }
 
@@ -106,6 +114,7 @@ func (r *cloudconnectAwsAccountResource) Schema(ctx context.Context, _ resource.
 		s.Attributes["s3bucket_region"] = attr
 	}
 
+	s.Attributes["customer_context"] = customerContextResourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx, timeouts.Opts{
 		Create: true,
 		Read:   true,
@@ -123,6 +132,11 @@ func (r *cloudconnectAwsAccountResource) ConfigValidators(_ context.Context) []r
 }
 
 func (r *cloudconnectAwsAccountResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_cloudconnect_aws_account", "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan cloudconnectAwsAccountResourceModel
 
 	// Read Terraform plan data into the model
@@ -137,6 +151,7 @@ func (r *cloudconnectAwsAccountResource) Create(ctx context.Context, req resourc
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	ctx = withCustomerContext(ctx, plan.CustomerContext)
 	defer cancel()
 
 	// Build API request body from plan.
@@ -163,10 +178,7 @@ func (r *cloudconnectAwsAccountResource) Create(ctx context.Context, req resourc
 	// Create new AWS account role via API.
 	createResp, err := r.client.CreateAccountRoleWithResponse(ctx, apiReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Creating CloudConnect AWS Account",
-			"Could not create CloudConnect AWS account, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Creating CloudConnect AWS Account", "Could not create CloudConnect AWS account", err)...)
 		return
 	}
 
@@ -211,6 +223,7 @@ func (r *cloudconnectAwsAccountResource) Read(ctx context.Context, req resource.
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
 
 	diags = r.populateState(ctx, &state)
@@ -230,6 +243,11 @@ func (r *cloudconnectAwsAccountResource) Read(ctx context.Context, req resource.
 }
 
 func (r *cloudconnectAwsAccountResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_cloudconnect_aws_account", "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan cloudconnectAwsAccountResourceModel
 
 	// Read Terraform plan data.
@@ -244,6 +262,7 @@ func (r *cloudconnectAwsAccountResource) Update(ctx context.Context, req resourc
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	ctx = withCustomerContext(ctx, plan.CustomerContext)
 	defer cancel()
 
 	// Build API request body — Update sends enabledFeatures and optional s3bucket fields.
@@ -267,10 +286,7 @@ func (r *cloudconnectAwsAccountResource) Update(ctx context.Context, req resourc
 	// Update AWS account feature via API.
 	updateResp, err := r.client.UpdateAwsFeatureWithResponse(ctx, plan.AccountId.ValueString(), apiReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Updating CloudConnect AWS Account",
-			"Could not update CloudConnect AWS account, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Updating CloudConnect AWS Account", "Could not update CloudConnect AWS account", err)...)
 		return
 	}
 
@@ -301,6 +317,11 @@ func (r *cloudconnectAwsAccountResource) Update(ctx context.Context, req resourc
 }
 
 func (r *cloudconnectAwsAccountResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_cloudconnect_aws_account", "delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var state cloudconnectAwsAccountResourceModel
 
 	// Read Terraform prior state data into the model.
@@ -315,15 +336,13 @@ func (r *cloudconnectAwsAccountResource) Delete(ctx context.Context, req resourc
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
 
 	// Delete AWS account role via API.
 	deleteResp, err := r.client.DeleteAccountRoleWithResponse(ctx, state.AccountId.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Deleting CloudConnect AWS Account",
-			"Could not delete CloudConnect AWS account, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Deleting CloudConnect AWS Account", "Could not delete CloudConnect AWS account", err)...)
 		return
 	}
 
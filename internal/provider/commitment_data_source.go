@@ -40,6 +40,7 @@ type commitmentDataSourceModel struct {
 	TotalForecastValue     types.Float64  `tfsdk:"total_forecast_value"`
 	TotalMarketplaceSpend  types.Float64  `tfsdk:"total_marketplace_spend"`
 	UpdateTime             types.Int64    `tfsdk:"update_time"`
+	CustomerContext        types.String   `tfsdk:"customer_context"`
 	Timeouts               timeouts.Value `tfsdk:"timeouts"`
 }
 
@@ -63,6 +64,7 @@ func (ds *commitmentDataSource) Configure(_ context.Context, req datasource.Conf
 func (ds *commitmentDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	s := datasource_commitment.CommitmentDataSourceSchema(ctx)
 
+	s.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx)
 
 	resp.Schema = s
@@ -83,6 +85,7 @@ func (ds *commitmentDataSource) Read(ctx context.Context, req datasource.ReadReq
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
 
 	// If ID is unknown (depends on a resource not yet created), set all computed
@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// maxDiffValueLength truncates each value shown in a concurrent modification
+// diff, as a budget scope or alert filter can hold hundreds of entries.
+const maxDiffValueLength = 200
+
+// diffConcurrentModification compares prior, the state Update was planned
+// from, with remote, the same model refreshed from the API just before the
+// update. When their update_time differs, someone changed the object after
+// the last refresh and applying the plan would overwrite that change, so an
+// error listing the attributes that differ is returned.
+//
+// remote must be refreshed the way Read does it, starting from prior, so
+// that values the provider maps back to their configured form do not show up
+// as changes.
+func diffConcurrentModification(ctx context.Context, kind, id string, prior tfsdk.State, remote any) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	refreshed := tfsdk.State{Schema: prior.Schema, Raw: tftypes.NewValue(prior.Schema.Type().TerraformType(ctx), nil)}
+	diags.Append(refreshed.Set(ctx, remote)...)
+	var priorTime, remoteTime types.Int64
+	diags.Append(prior.GetAttribute(ctx, path.Root("update_time"), &priorTime)...)
+	diags.Append(refreshed.GetAttribute(ctx, path.Root("update_time"), &remoteTime)...)
+	if diags.HasError() {
+		return diags
+	}
+	diags.Append(diffModifiedObject(ctx, kind, id, prior, refreshed, priorTime, remoteTime)...)
+	return diags
+}
+
+// diffModifiedObject is diffConcurrentModification for update times that
+// are not an update_time attribute, such as the report's, which is kept in
+// private state. refreshed is prior refreshed from the API.
+func diffModifiedObject(ctx context.Context, kind, id string, prior, refreshed tfsdk.State, priorTime, remoteTime types.Int64) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if priorTime.IsNull() || priorTime.Equal(remoteTime) {
+		return diags
+	}
+
+	var names []string
+	for name := range prior.Schema.GetAttributes() {
+		names = append(names, name)
+	}
+	for name := range prior.Schema.GetBlocks() {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var changes []string
+	for _, name := range names {
+		if name == "update_time" {
+			continue
+		}
+		var before, after attr.Value
+		diags.Append(prior.GetAttribute(ctx, path.Root(name), &before)...)
+		diags.Append(refreshed.GetAttribute(ctx, path.Root(name), &after)...)
+		if diags.HasError() {
+			return diags
+		}
+		if !before.Equal(after) {
+			changes = append(changes, fmt.Sprintf("  %s: %s -> %s", name, diffValue(before), diffValue(after)))
+		}
+	}
+
+	changed := "No attribute managed by Terraform differs, so the change was to settings the provider does not manage."
+	if len(changes) > 0 {
+		changed = "Changed on the server since the last refresh:\n" + strings.Join(changes, "\n")
+	}
+	diags.AddError(
+		"Object Modified Outside Terraform",
+		fmt.Sprintf("The %s %s was updated at %s, after Terraform last read it (updated at %s). "+
+			"Applying this plan would overwrite that change.\n\n%s\n\n"+
+			"Run terraform plan again to review the changes, and update the configuration to keep them. "+
+			"Unset the provider's detect_concurrent_modification to apply anyway.",
+			kind, id, formatUpdateTime(remoteTime), formatUpdateTime(priorTime), changed),
+	)
+	return diags
+}
+
+// formatUpdateTime formats an update_time, in milliseconds since the epoch,
+// as an RFC 3339 timestamp.
+func formatUpdateTime(v types.Int64) string {
+	if v.IsNull() || v.IsUnknown() {
+		return v.String()
+	}
+	return time.UnixMilli(v.ValueInt64()).UTC().Format(time.RFC3339)
+}
+
+// diffValue formats v for a concurrent modification diff.
+func diffValue(v attr.Value) string {
+	s := v.String()
+	if len(s) > maxDiffValueLength {
+		s = s[:maxDiffValueLength] + "..."
+	}
+	return s
+}
@@ -0,0 +1,182 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestDiffConcurrentModification(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	type model struct {
+		Id         types.String `tfsdk:"id"`
+		Name       types.String `tfsdk:"name"`
+		Amount     types.Int64  `tfsdk:"amount"`
+		UpdateTime types.Int64  `tfsdk:"update_time"`
+	}
+	sch := schema.Schema{Attributes: map[string]schema.Attribute{
+		"id":          schema.StringAttribute{Computed: true},
+		"name":        schema.StringAttribute{Required: true},
+		"amount":      schema.Int64Attribute{Required: true},
+		"update_time": schema.Int64Attribute{Computed: true},
+	}}
+	prior := tfsdk.State{Schema: sch, Raw: tftypes.NewValue(sch.Type().TerraformType(ctx), nil)}
+	if diags := prior.Set(ctx, &model{
+		Id:         types.StringValue("b1"),
+		Name:       types.StringValue("team"),
+		Amount:     types.Int64Value(100),
+		UpdateTime: types.Int64Value(1767225600000),
+	}); diags.HasError() {
+		t.Fatalf("setting state: %v", diags)
+	}
+
+	t.Run("unchanged", func(t *testing.T) {
+		t.Parallel()
+		remote := model{
+			Id:         types.StringValue("b1"),
+			Name:       types.StringValue("team"),
+			Amount:     types.Int64Value(100),
+			UpdateTime: types.Int64Value(1767225600000),
+		}
+		if diags := diffConcurrentModification(ctx, "budget", "b1", prior, &remote); len(diags) != 0 {
+			t.Errorf("unexpected diagnostics: %v", diags)
+		}
+	})
+
+	t.Run("modified", func(t *testing.T) {
+		t.Parallel()
+		remote := model{
+			Id:         types.StringValue("b1"),
+			Name:       types.StringValue("team"),
+			Amount:     types.Int64Value(250),
+			UpdateTime: types.Int64Value(1767229200000),
+		}
+		diags := diffConcurrentModification(ctx, "budget", "b1", prior, &remote)
+		if diags.ErrorsCount() != 1 || diags[0].Summary() != "Object Modified Outside Terraform" {
+			t.Fatalf("diagnostics = %v, want one error", diags)
+		}
+		detail := diags[0].Detail()
+		for _, want := range []string{
+			"The budget b1 was updated at 2026-01-01T01:00:00Z, after Terraform last read it (updated at 2026-01-01T00:00:00Z)",
+			"  amount: 100 -> 250",
+		} {
+			if !strings.Contains(detail, want) {
+				t.Errorf("detail %q does not contain %q", detail, want)
+			}
+		}
+		if strings.Contains(detail, "name:") {
+			t.Errorf("detail %q lists an unchanged attribute", detail)
+		}
+	})
+}
+
+// memPrivateState is an in-memory privateState.
+type memPrivateState map[string][]byte
+
+func (m memPrivateState) GetKey(_ context.Context, key string) ([]byte, diag.Diagnostics) {
+	return m[key], nil
+}
+
+func (m memPrivateState) SetKey(_ context.Context, key string, value []byte) diag.Diagnostics {
+	if len(value) == 0 {
+		delete(m, key)
+		return nil
+	}
+	m[key] = value
+	return nil
+}
+
+// TestReportResource_CheckConcurrentModification verifies that the report's
+// update time, kept in private state, is compared with the API's before an
+// update, and that a changed report, here also renamed, fails with the
+// attributes that differ. The update time must come from the reports list:
+// GET /reports/{id} runs the report's query.
+func TestReportResource_CheckConcurrentModification(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	var name, description atomic.Value
+	name.Store("team")
+	description.Store("old")
+	var updateTime atomic.Int64
+	updateTime.Store(1767225600000)
+	r := &reportResource{client: newImportTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/analytics/v1/reports":
+			reports := fmt.Sprintf(`{"id": "r2", "reportName": "team costs", "updateTime": 1}, {"id": "r1", "reportName": %q, "updateTime": %d}`,
+				name.Load(), updateTime.Load())
+			if filter := req.URL.Query().Get("nameContains"); filter != "" && !strings.Contains(name.Load().(string), filter) {
+				reports = `{"id": "r2", "reportName": "team costs", "updateTime": 1}`
+			}
+			_, _ = io.WriteString(w, `{"reports": [`+reports+`]}`)
+		case "/analytics/v1/reports/r1/config":
+			_, _ = fmt.Fprintf(w, `{"id": "r1", "name": %q, "description": %q}`, name.Load(), description.Load())
+		default:
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	prior := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	var model reportResourceModel
+	diags := prior.SetAttribute(ctx, path.Root("id"), types.StringValue("r1"))
+	diags.Append(prior.Get(ctx, &model)...)
+	diags.Append(r.populateState(ctx, &model)...)
+	if diags.HasError() {
+		t.Fatalf("reading report: %v", diags)
+	}
+	if diags := prior.Set(ctx, &model); diags.HasError() {
+		t.Fatalf("setting state: %v", diags)
+	}
+
+	private := memPrivateState{}
+	if diags := r.saveUpdateTime(ctx, "r1", "team", private); diags.HasError() {
+		t.Fatalf("saving update time: %v", diags)
+	}
+	priorTime, diags := savedUpdateTime(ctx, private)
+	if diags.HasError() || priorTime.ValueInt64() != 1767225600000 {
+		t.Fatalf("saved update time = %v, %v; want 1767225600000", priorTime, diags)
+	}
+
+	if diags := r.checkConcurrentModification(ctx, prior, priorTime); len(diags) != 0 {
+		t.Errorf("unchanged report: unexpected diagnostics: %v", diags)
+	}
+	if diags := r.checkConcurrentModification(ctx, prior, types.Int64Null()); len(diags) != 0 {
+		t.Errorf("no saved update time: unexpected diagnostics: %v", diags)
+	}
+
+	name.Store("finance")
+	description.Store("new")
+	updateTime.Store(1767229200000)
+	diags = r.checkConcurrentModification(ctx, prior, priorTime)
+	if diags.ErrorsCount() != 1 || diags[0].Summary() != "Object Modified Outside Terraform" {
+		t.Fatalf("diagnostics = %v, want one error", diags)
+	}
+	detail := diags[0].Detail()
+	for _, want := range []string{
+		"The report r1 was updated at 2026-01-01T01:00:00Z",
+		`description: "old" -> "new"`,
+		`name: "team" -> "finance"`,
+	} {
+		if !strings.Contains(detail, want) {
+			t.Errorf("detail %q does not contain %q", detail, want)
+		}
+	}
+}
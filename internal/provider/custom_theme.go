@@ -19,9 +19,7 @@ import (
 func (r *customThemeResource) populateState(ctx context.Context, state *customThemeResourceModel) diag.Diagnostics {
 	themeResp, err := r.client.GetCustomThemeWithResponse(ctx, state.Id.ValueString())
 	if err != nil {
-		return diag.Diagnostics{
-			diag.NewErrorDiagnostic("Error Reading Custom Theme", "Could not read custom theme ID "+state.Id.ValueString()+": "+err.Error()),
-		}
+		return apiErrorDiagnostics("Error Reading Custom Theme", "Could not read custom theme ID "+state.Id.ValueString(), err)
 	}
 
 	if themeResp.StatusCode() == 404 {
@@ -27,7 +27,8 @@ type (
 	}
 	customThemeDataSourceModel struct {
 		datasource_custom_theme.CustomThemeModel
-		Timeouts timeouts.Value `tfsdk:"timeouts"`
+		CustomerContext types.String   `tfsdk:"customer_context"`
+		Timeouts        timeouts.Value `tfsdk:"timeouts"`
 	}
 )
 
@@ -41,6 +42,7 @@ func (d *customThemeDataSource) Schema(ctx context.Context, _ datasource.SchemaR
 	s.Description = "Retrieve a custom color theme by its ID."
 	s.MarkdownDescription = s.Description
 
+	s.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx)
 
 	resp.Schema = s
@@ -77,6 +79,7 @@ func (d *customThemeDataSource) Read(ctx context.Context, req datasource.ReadReq
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	// If ID is unknown (depends on a resource not yet created), set all computed
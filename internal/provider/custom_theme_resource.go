@@ -10,19 +10,24 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 type (
 	customThemeResource struct {
-		client *models.ClientWithResponses
+		client   *models.ClientWithResponses
+		readOnly bool
 	}
 	customThemeResourceModel struct {
 		resource_custom_theme.CustomThemeModel
-		Timeouts timeouts.Value `tfsdk:"timeouts"`
+		AdoptExisting   types.Bool     `tfsdk:"adopt_existing"`
+		CustomerContext types.String   `tfsdk:"customer_context"`
+		Timeouts        timeouts.Value `tfsdk:"timeouts"`
 	}
 )
 
@@ -44,16 +49,17 @@ func (r *customThemeResource) Configure(_ context.Context, req resource.Configur
 		return
 	}
 
-	client, ok := req.ProviderData.(*models.ClientWithResponses)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *models.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.readOnly = data.readOnly
 }
 
 func (r *customThemeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -61,6 +67,10 @@ func (r *customThemeResource) Metadata(_ context.Context, req resource.MetadataR
 }
 
 func (r *customThemeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, id, diags := importCustomerContext(ctx, req, resp, 1)
+	resp.Diagnostics.Append(diags...)
+	req.ID = id
+
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
@@ -105,6 +115,8 @@ func (r *customThemeResource) Schema(ctx context.Context, _ resource.SchemaReque
 		"Custom themes define light and dark mode color palettes that can be applied to reports."
 	s.MarkdownDescription = s.Description
 
+	s.Attributes["adopt_existing"] = adoptExistingAttribute("custom theme", "the same name")
+	s.Attributes["customer_context"] = customerContextResourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx, timeouts.Opts{
 		Create: true,
 		Read:   true,
@@ -116,6 +128,11 @@ func (r *customThemeResource) Schema(ctx context.Context, _ resource.SchemaReque
 }
 
 func (r *customThemeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_custom_theme", "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan customThemeResourceModel
 
 	// Read Terraform plan data into the model
@@ -130,8 +147,32 @@ func (r *customThemeResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	ctx = withCustomerContext(ctx, plan.CustomerContext)
 	defer cancel()
 
+	if plan.AdoptExisting.ValueBool() {
+		existingID, adoptDiags := findAdoptable(ctx, "custom theme", plan.Name.ValueString(), nameLookup(r.client, listCustomThemes))
+		resp.Diagnostics.Append(adoptDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if existingID != "" {
+			updated, updateDiags := r.update(ctx, existingID, &plan)
+			resp.Diagnostics.Append(updateDiags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			// Plan-first state pattern: overlay Computed-only fields from API response.
+			resp.Diagnostics.Append(overlayCustomThemeComputedFields(ctx, updated, &plan)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+			return
+		}
+	}
+
 	// Convert model to API request type
 	apiReq, reqDiags := plan.toCreateRequest(ctx)
 	resp.Diagnostics.Append(reqDiags...)
@@ -142,10 +183,7 @@ func (r *customThemeResource) Create(ctx context.Context, req resource.CreateReq
 	// Create new custom theme via API
 	themeResp, err := r.client.CreateCustomThemeWithResponse(ctx, apiReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Creating Custom Theme",
-			"Could not create custom theme, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Creating Custom Theme", "Could not create custom theme", err)...)
 		return
 	}
 
@@ -190,6 +228,7 @@ func (r *customThemeResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
 
 	// Get refreshed custom theme from API
@@ -209,6 +248,11 @@ func (r *customThemeResource) Read(ctx context.Context, req resource.ReadRequest
 }
 
 func (r *customThemeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_custom_theme", "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan customThemeResourceModel
 
 	// Read Terraform plan data
@@ -223,6 +267,7 @@ func (r *customThemeResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	ctx = withCustomerContext(ctx, plan.CustomerContext)
 	defer cancel()
 
 	// Get the ID from the state
@@ -233,50 +278,67 @@ func (r *customThemeResource) Update(ctx context.Context, req resource.UpdateReq
 	}
 	themeID := state.Id.ValueString()
 
-	// Convert model to API request type
-	apiReq, reqDiags := plan.toUpdateRequest(ctx)
-	resp.Diagnostics.Append(reqDiags...)
+	updated, updateDiags := r.update(ctx, themeID, &plan)
+	resp.Diagnostics.Append(updateDiags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Plan-first state pattern: overlay Computed-only fields from API response.
+	resp.Diagnostics.Append(overlayCustomThemeComputedFields(ctx, updated, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// update sends plan as an update of the custom theme with ID themeID and
+// returns the updated object, for Update and for Create when adopt_existing
+// takes over an existing custom theme.
+func (r *customThemeResource) update(ctx context.Context, themeID string, plan *customThemeResourceModel) (*models.CustomTheme, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	// Convert model to API request type
+	apiReq, reqDiags := plan.toUpdateRequest(ctx)
+	diags.Append(reqDiags...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
 	// Update custom theme via API
 	updateResp, err := r.client.UpdateCustomThemeWithResponse(ctx, themeID, apiReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Updating Custom Theme",
-			"Could not update custom theme, unexpected error: "+err.Error(),
-		)
-		return
+		diags.Append(apiErrorDiagnostics("Error Updating Custom Theme", "Could not update custom theme", err)...)
+		return nil, diags
 	}
 
 	if updateResp.StatusCode() != 200 {
-		resp.Diagnostics.AddError(
+		diags.AddError(
 			"Error Updating Custom Theme",
 			fmt.Sprintf("Could not update custom theme, status: %d, body: %s", updateResp.StatusCode(), string(updateResp.Body)),
 		)
-		return
+		return nil, diags
 	}
 
 	if updateResp.JSON200 == nil {
-		resp.Diagnostics.AddError(
+		diags.AddError(
 			"Error Updating Custom Theme",
 			"Received empty response body",
 		)
-		return
+		return nil, diags
 	}
 
-	// Plan-first state pattern: overlay Computed-only fields from API response.
-	resp.Diagnostics.Append(overlayCustomThemeComputedFields(ctx, updateResp.JSON200, &plan)...)
+	return updateResp.JSON200, diags
+}
+
+func (r *customThemeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_custom_theme", "delete")...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Save updated data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
-}
-
-func (r *customThemeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var state customThemeResourceModel
 
 	// Read Terraform prior state data into the model
@@ -291,15 +353,13 @@ func (r *customThemeResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
 
 	// Delete custom theme via API
 	deleteResp, err := r.client.DeleteCustomThemeWithResponse(ctx, state.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Deleting DoiT Custom Theme",
-			"Could not delete custom theme, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Deleting DoiT Custom Theme", "Could not delete custom theme", err)...)
 		return
 	}
 
@@ -27,7 +27,8 @@ type (
 	}
 	customThemesDataSourceModel struct {
 		datasource_custom_themes.CustomThemesModel
-		Timeouts timeouts.Value `tfsdk:"timeouts"`
+		CustomerContext types.String   `tfsdk:"customer_context"`
+		Timeouts        timeouts.Value `tfsdk:"timeouts"`
 	}
 )
 
@@ -41,6 +42,7 @@ func (d *customThemesDataSource) Schema(ctx context.Context, _ datasource.Schema
 	s.Description = "List all custom color themes."
 	s.MarkdownDescription = s.Description
 
+	s.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx)
 
 	resp.Schema = s
@@ -77,6 +79,7 @@ func (d *customThemesDataSource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	// List API has no inputs to check for unknown — no pagination params.
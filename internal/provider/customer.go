@@ -32,10 +32,7 @@ func (r *customerResource) populateState(ctx context.Context, state *customerRes
 
 	customerResp, err := r.client.GetCustomerWithResponse(ctx, customerID)
 	if err != nil {
-		diags.AddError(
-			"Error Reading Customer",
-			"Could not read customer, unexpected error: "+err.Error(),
-		)
+		diags.Append(apiErrorDiagnostics("Error Reading Customer", "Could not read customer", err)...)
 		return diags
 	}
 
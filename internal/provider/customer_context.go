@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Every resource and data source has an optional customer_context attribute
+// that overrides the provider's customer_context for that object's requests,
+// so one provider block can manage many tenants. The value travels on the
+// request context and the client's request editor turns it into the
+// X-Tenant-Id header (see NewClient).
+
+// customerContextKey is the context key for a per-object customer context.
+type customerContextKey struct{}
+
+// withCustomerContext returns ctx carrying the object's customer_context. A
+// null, unknown or empty value leaves ctx unchanged, so the provider's
+// customer_context applies.
+func withCustomerContext(ctx context.Context, customerContext types.String) context.Context {
+	if customerContext.IsNull() || customerContext.IsUnknown() || customerContext.ValueString() == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, customerContextKey{}, customerContext.ValueString())
+}
+
+// customerContextFromContext returns the customer context set by
+// withCustomerContext, if any.
+func customerContextFromContext(ctx context.Context) (string, bool) {
+	v, ok := ctx.Value(customerContextKey{}).(string)
+	return v, ok
+}
+
+const customerContextDescription = "Customer context for this object's API requests, overriding the provider's " +
+	"`customer_context`. Lets a single provider block manage objects in several tenants. " +
+	"For DoiT employees only."
+
+// customerContextResourceAttribute is the customer_context attribute shared
+// by every resource. An object cannot move between tenants, so changing it
+// forces replacement.
+func customerContextResourceAttribute() rschema.StringAttribute {
+	return rschema.StringAttribute{
+		Optional:            true,
+		Description:         strings.ReplaceAll(customerContextDescription, "`", "") + " Changing this forces a new resource.",
+		MarkdownDescription: customerContextDescription + " Changing this forces a new resource.",
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+		Validators: []validator.String{
+			stringvalidator.LengthAtLeast(1),
+		},
+	}
+}
+
+// customerContextDataSourceAttribute is the customer_context attribute shared
+// by every data source.
+func customerContextDataSourceAttribute() dschema.StringAttribute {
+	return dschema.StringAttribute{
+		Optional:            true,
+		Description:         strings.ReplaceAll(customerContextDescription, "`", ""),
+		MarkdownDescription: customerContextDescription,
+		Validators: []validator.String{
+			stringvalidator.LengthAtLeast(1),
+		},
+	}
+}
+
+// splitImportTenant splits the optional "<customer context>/" prefix off an
+// import ID. idSegments is the number of "/"-separated segments in the
+// resource's own import ID format (1 for a plain ID, 2 for "a/b"), so the
+// prefix is recognized only when the ID has exactly one segment more.
+// "name:<exact name>" IDs may contain "/" in the name: a prefix is taken only
+// from before the "name:" marker.
+func splitImportTenant(id string, idSegments int) (tenant, rest string) {
+	if strings.HasPrefix(id, importByNamePrefix) {
+		return "", id
+	}
+	before, after, ok := strings.Cut(id, "/")
+	if !ok || before == "" {
+		return "", id
+	}
+	if strings.HasPrefix(after, importByNamePrefix) || strings.Count(id, "/") == idSegments {
+		return before, after
+	}
+	return "", id
+}
+
+// importCustomerContext handles the "<customer context>/" import prefix: it
+// records the customer context in the imported state and returns the
+// remaining ID, with ctx scoped to the tenant for any lookups the import
+// makes.
+func importCustomerContext(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse, idSegments int) (context.Context, string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	tenant, id := splitImportTenant(req.ID, idSegments)
+	if tenant == "" {
+		return ctx, id, diags
+	}
+	v := types.StringValue(tenant)
+	diags.Append(resp.State.SetAttribute(ctx, path.Root("customer_context"), v)...)
+	return withCustomerContext(ctx, v), id, diags
+}
@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSplitImportTenant(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		id         string
+		idSegments int
+		wantTenant string
+		wantRest   string
+	}{
+		{name: "plain id", id: "abc123", idSegments: 1, wantRest: "abc123"},
+		{name: "tenant and id", id: "acme/abc123", idSegments: 1, wantTenant: "acme", wantRest: "abc123"},
+		{name: "name lookup", id: "name:Team/Spend", idSegments: 1, wantRest: "name:Team/Spend"},
+		{name: "tenant and name lookup", id: "acme/name:Team/Spend", idSegments: 1, wantTenant: "acme", wantRest: "name:Team/Spend"},
+		{name: "two-segment id", id: "aws/123", idSegments: 2, wantRest: "aws/123"},
+		{name: "tenant and two-segment id", id: "acme/aws/123", idSegments: 2, wantTenant: "acme", wantRest: "aws/123"},
+		{name: "too many segments", id: "a/b/c", idSegments: 1, wantRest: "a/b/c"},
+		{name: "empty tenant", id: "/abc123", idSegments: 1, wantRest: "/abc123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tenant, rest := splitImportTenant(tt.id, tt.idSegments)
+			if tenant != tt.wantTenant || rest != tt.wantRest {
+				t.Errorf("splitImportTenant(%q, %d) = (%q, %q), want (%q, %q)",
+					tt.id, tt.idSegments, tenant, rest, tt.wantTenant, tt.wantRest)
+			}
+		})
+	}
+}
+
+func TestWithCustomerContext_IgnoresEmptyValues(t *testing.T) {
+	t.Parallel()
+
+	for _, v := range []types.String{types.StringNull(), types.StringUnknown(), types.StringValue("")} {
+		if got, ok := customerContextFromContext(withCustomerContext(context.Background(), v)); ok {
+			t.Errorf("withCustomerContext(%s) set customer context %q, want none", v, got)
+		}
+	}
+}
+
+// tenantRecorder is a test server that records the X-Tenant-Id header of
+// every request other than token validation.
+type tenantRecorder struct {
+	mu      sync.Mutex
+	tenants []string
+}
+
+func newTenantRecorderServer(t *testing.T, body string) (*tenantRecorder, *httptest.Server) {
+	t.Helper()
+	rec := &tenantRecorder{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/auth/v1/validate") {
+			rec.mu.Lock()
+			rec.tenants = append(rec.tenants, r.Header.Get("X-Tenant-Id"))
+			rec.mu.Unlock()
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, body)
+	}))
+	t.Cleanup(server.Close)
+	return rec, server
+}
+
+func TestNewClient_CustomerContextOverride(t *testing.T) {
+	t.Parallel()
+
+	rec, server := newTenantRecorderServer(t, `{}`)
+	client, err := NewClient(context.Background(), server.URL, "test-token", "provider-tenant", "1.9.0", "1.0.0", DefaultRequestTimeout)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	ctx := context.Background()
+	if _, err := client.ListRolesWithResponse(ctx); err != nil {
+		t.Fatalf("request error = %v", err)
+	}
+	if _, err := client.ListRolesWithResponse(withCustomerContext(ctx, types.StringValue("object-tenant"))); err != nil {
+		t.Fatalf("request error = %v", err)
+	}
+
+	want := []string{"provider-tenant", "object-tenant"}
+	if fmt.Sprint(rec.tenants) != fmt.Sprint(want) {
+		t.Errorf("X-Tenant-Id headers = %q, want %q", rec.tenants, want)
+	}
+}
+
+func TestImportState_CustomerContextPrefix(t *testing.T) {
+	t.Parallel()
+
+	rec, server := newTenantRecorderServer(t, `{"reports": [{"id": "r1", "reportName": "Monthly Spend"}]}`)
+	client, err := NewClient(context.Background(), server.URL, "test-token", "provider-tenant", "1.9.0", "1.0.0", DefaultRequestTimeout)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	tests := []struct {
+		importID    string
+		wantID      string
+		wantTenant  string
+		wantLookups []string
+	}{
+		{importID: "acme/r1", wantID: "r1", wantTenant: "acme"},
+		{importID: "r1", wantID: "r1"},
+		{importID: "acme/name:Monthly Spend", wantID: "r1", wantTenant: "acme", wantLookups: []string{"acme"}},
+	}
+
+	for _, tt := range tests {
+		rec.mu.Lock()
+		rec.tenants = nil
+		rec.mu.Unlock()
+
+		resp := runImportState(t, &reportResource{client: client}, tt.importID)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("%s: unexpected error: %v", tt.importID, resp.Diagnostics)
+		}
+		if got := importedID(t, resp); got != tt.wantID {
+			t.Errorf("%s: id = %q, want %q", tt.importID, got, tt.wantID)
+		}
+
+		var tenant types.String
+		if diags := resp.State.GetAttribute(context.Background(), path.Root("customer_context"), &tenant); diags.HasError() {
+			t.Fatalf("failed to read customer_context from state: %v", diags)
+		}
+		if tenant.ValueString() != tt.wantTenant {
+			t.Errorf("%s: customer_context = %q, want %q", tt.importID, tenant.ValueString(), tt.wantTenant)
+		}
+		if fmt.Sprint(rec.tenants) != fmt.Sprint(tt.wantLookups) {
+			t.Errorf("%s: lookup X-Tenant-Id headers = %q, want %q", tt.importID, rec.tenants, tt.wantLookups)
+		}
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
@@ -26,12 +27,14 @@ func NewCustomerResource() resource.Resource {
 
 // customerResource defines the resource implementation.
 type customerResource struct {
-	client *models.ClientWithResponses
+	client   *models.ClientWithResponses
+	readOnly bool
 }
 
 type customerResourceModel struct {
 	resource_customer.CustomerModel
-	Timeouts timeouts.Value `tfsdk:"timeouts"`
+	CustomerContext types.String   `tfsdk:"customer_context"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *customerResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -106,6 +109,7 @@ func (r *customerResource) Schema(ctx context.Context, _ resource.SchemaRequest,
 		"Destroying this resource only removes it from Terraform state; the customer settings continue to exist in DoiT."
 	s.MarkdownDescription = s.Description
 
+	s.Attributes["customer_context"] = customerContextResourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx, timeouts.Opts{
 		Read:   true,
 		Update: true,
@@ -140,6 +144,7 @@ func (r *customerResource) Read(ctx context.Context, req resource.ReadRequest, r
 	}
 
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
 
 	resp.Diagnostics.Append(r.populateState(ctx, &state)...)
@@ -156,6 +161,11 @@ func (r *customerResource) Read(ctx context.Context, req resource.ReadRequest, r
 }
 
 func (r *customerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_customer", "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan customerResourceModel
 	var state customerResourceModel
 	var config customerResourceModel
@@ -174,6 +184,7 @@ func (r *customerResource) Update(ctx context.Context, req resource.UpdateReques
 	}
 
 	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	ctx = withCustomerContext(ctx, plan.CustomerContext)
 	defer cancel()
 
 	customerID := state.Id.ValueString()
@@ -197,10 +208,7 @@ func (r *customerResource) Update(ctx context.Context, req resource.UpdateReques
 
 	updateResp, err := r.client.UpdateCustomerWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, customerID, apiReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Updating Customer",
-			"Could not update customer, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Updating Customer", "Could not update customer", err)...)
 		return
 	}
 
@@ -214,10 +222,7 @@ func (r *customerResource) Update(ctx context.Context, req resource.UpdateReques
 
 	customerResp, err := r.client.GetCustomerWithResponse(ctx, customerID)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading Customer After Update",
-			"Could not read customer after update: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Reading Customer After Update", "Could not read customer after update", err)...)
 		return
 	}
 
@@ -238,6 +243,11 @@ func (r *customerResource) Update(ctx context.Context, req resource.UpdateReques
 }
 
 func (r *customerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_customer", "delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var state customerResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -254,6 +264,10 @@ func (r *customerResource) Delete(ctx context.Context, req resource.DeleteReques
 }
 
 func (r *customerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, id, diags := importCustomerContext(ctx, req, resp, 1)
+	resp.Diagnostics.Append(diags...)
+	req.ID = id
+
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
@@ -262,14 +276,15 @@ func (r *customerResource) Configure(_ context.Context, req resource.ConfigureRe
 		return
 	}
 
-	client, ok := req.ProviderData.(*models.ClientWithResponses)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *models.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.readOnly = data.readOnly
 }
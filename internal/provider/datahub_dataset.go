@@ -15,9 +15,7 @@ import (
 func (r *datahubDatasetResource) populateState(ctx context.Context, state *datahubDatasetResourceModel) diag.Diagnostics {
 	datasetResp, err := r.client.GetDatahubDatasetWithResponse(ctx, state.Name.ValueString())
 	if err != nil {
-		return diag.Diagnostics{
-			diag.NewErrorDiagnostic("Error Reading DataHub Dataset", "Could not read dataset "+state.Name.ValueString()+": "+err.Error()),
-		}
+		return apiErrorDiagnostics("Error Reading DataHub Dataset", "Could not read dataset "+state.Name.ValueString(), err)
 	}
 
 	if datasetResp.StatusCode() == 404 {
@@ -24,7 +24,8 @@ type datahubDatasetDataSource struct {
 
 type datahubDatasetDataSourceModel struct {
 	datasource_datahub_dataset.DatahubDatasetModel
-	Timeouts timeouts.Value `tfsdk:"timeouts"`
+	CustomerContext types.String   `tfsdk:"customer_context"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (ds *datahubDatasetDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -49,6 +50,7 @@ func (ds *datahubDatasetDataSource) Configure(_ context.Context, req datasource.
 func (ds *datahubDatasetDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	s := datasource_datahub_dataset.DatahubDatasetDataSourceSchema(ctx)
 
+	s.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx)
 
 	resp.Schema = s
@@ -69,6 +71,7 @@ func (ds *datahubDatasetDataSource) Read(ctx context.Context, req datasource.Rea
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
 
 	// If name is unknown (depends on a resource not yet created), set all computed
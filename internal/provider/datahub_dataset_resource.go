@@ -7,19 +7,26 @@ import (
 	"github.com/doitintl/terraform-provider-doit/internal/provider/models"
 	"github.com/doitintl/terraform-provider-doit/internal/provider/resource_datahub_dataset"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 type (
 	datahubDatasetResource struct {
-		client *models.ClientWithResponses
+		client             *models.ClientWithResponses
+		readOnly           bool
+		deletionProtection bool
 	}
 	datahubDatasetResourceModel struct {
 		resource_datahub_dataset.DatahubDatasetModel
-		Timeouts timeouts.Value `tfsdk:"timeouts"`
+		DeletionProtection types.Bool     `tfsdk:"deletion_protection"`
+		AdoptExisting      types.Bool     `tfsdk:"adopt_existing"`
+		CustomerContext    types.String   `tfsdk:"customer_context"`
+		Timeouts           timeouts.Value `tfsdk:"timeouts"`
 	}
 )
 
@@ -38,16 +45,18 @@ func (r *datahubDatasetResource) Configure(_ context.Context, req resource.Confi
 		return
 	}
 
-	client, ok := req.ProviderData.(*models.ClientWithResponses)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *models.ClientWithResponses, got: %T.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.readOnly = data.readOnly
+	r.deletionProtection = data.deletionProtection
 }
 
 func (r *datahubDatasetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -55,6 +64,10 @@ func (r *datahubDatasetResource) Metadata(_ context.Context, req resource.Metada
 }
 
 func (r *datahubDatasetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, id, diags := importCustomerContext(ctx, req, resp, 1)
+	resp.Diagnostics.Append(diags...)
+	req.ID = id
+
 	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
 }
 
@@ -76,6 +89,10 @@ func (r *datahubDatasetResource) Schema(ctx context.Context, _ resource.SchemaRe
 		s.Attributes["description"] = attr
 	}
 
+	s.Attributes["deletion_protection"] = deletionProtectionAttribute()
+
+	s.Attributes["adopt_existing"] = adoptExistingAttribute("DataHub dataset", "the same name")
+	s.Attributes["customer_context"] = customerContextResourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx, timeouts.Opts{
 		Create: true,
 		Read:   true,
@@ -87,6 +104,11 @@ func (r *datahubDatasetResource) Schema(ctx context.Context, _ resource.SchemaRe
 }
 
 func (r *datahubDatasetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_datahub_dataset", "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan datahubDatasetResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -100,16 +122,34 @@ func (r *datahubDatasetResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	ctx = withCustomerContext(ctx, plan.CustomerContext)
 	defer cancel()
 
+	if plan.AdoptExisting.ValueBool() {
+		existingID, adoptDiags := findAdoptable(ctx, "DataHub dataset", plan.Name.ValueString(), datahubDatasetLookup(r.client))
+		resp.Diagnostics.Append(adoptDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if existingID != "" {
+			updated, updateDiags := r.update(ctx, existingID, &plan)
+			resp.Diagnostics.Append(updateDiags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			// Plan-first state pattern: overlay Computed-only fields from API response.
+			overlayDatahubDatasetComputedFields(updated.Name, updated.Description, nullableToPointer(updated.Records), updated.UpdatedBy, updated.LastUpdated, &plan)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+			return
+		}
+	}
+
 	apiReq := plan.toCreateRequest()
 
 	createResp, err := r.client.CreateDatahubDatasetWithResponse(ctx, apiReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Creating DataHub Dataset",
-			"Could not create dataset, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Creating DataHub Dataset", "Could not create dataset", err)...)
 		return
 	}
 
@@ -148,6 +188,7 @@ func (r *datahubDatasetResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
 
 	diags = r.populateState(ctx, &state)
@@ -166,6 +207,11 @@ func (r *datahubDatasetResource) Read(ctx context.Context, req resource.ReadRequ
 }
 
 func (r *datahubDatasetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_datahub_dataset", "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan datahubDatasetResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -179,6 +225,7 @@ func (r *datahubDatasetResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	ctx = withCustomerContext(ctx, plan.CustomerContext)
 	defer cancel()
 
 	var state datahubDatasetResourceModel
@@ -187,39 +234,57 @@ func (r *datahubDatasetResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
+	updated, updateDiags := r.update(ctx, state.Name.ValueString(), &plan)
+	resp.Diagnostics.Append(updateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Plan-first state pattern: overlay Computed-only fields from API response.
+	overlayDatahubDatasetComputedFields(updated.Name, updated.Description, nullableToPointer(updated.Records), updated.UpdatedBy, updated.LastUpdated, &plan)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// update sends plan as an update of the dataset named name and returns the
+// updated object, for Update and for Create when adopt_existing takes over an
+// existing DataHub dataset.
+func (r *datahubDatasetResource) update(ctx context.Context, name string, plan *datahubDatasetResourceModel) (*models.UpdateDatahubDataset200Response, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
 	apiReq := plan.toUpdateRequest()
 
-	updateResp, err := r.client.UpdateDatahubDatasetWithResponse(ctx, state.Name.ValueString(), apiReq)
+	updateResp, err := r.client.UpdateDatahubDatasetWithResponse(ctx, name, apiReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Updating DataHub Dataset",
-			"Could not update dataset, unexpected error: "+err.Error(),
-		)
-		return
+		diags.Append(apiErrorDiagnostics("Error Updating DataHub Dataset", "Could not update dataset", err)...)
+		return nil, diags
 	}
 
 	if updateResp.StatusCode() != 200 {
-		resp.Diagnostics.AddError(
+		diags.AddError(
 			"Error Updating DataHub Dataset",
 			fmt.Sprintf("Could not update dataset, status: %d, body: %s", updateResp.StatusCode(), string(updateResp.Body)),
 		)
-		return
+		return nil, diags
 	}
 
 	if updateResp.JSON200 == nil {
-		resp.Diagnostics.AddError(
+		diags.AddError(
 			"Error Updating DataHub Dataset",
 			"Received empty response body",
 		)
-		return
+		return nil, diags
 	}
 
-	overlayDatahubDatasetComputedFields(updateResp.JSON200.Name, updateResp.JSON200.Description, nullableToPointer(updateResp.JSON200.Records), updateResp.JSON200.UpdatedBy, updateResp.JSON200.LastUpdated, &plan)
-
-	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	return updateResp.JSON200, diags
 }
 
 func (r *datahubDatasetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_datahub_dataset", "delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var state datahubDatasetResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -227,20 +292,23 @@ func (r *datahubDatasetResource) Delete(ctx context.Context, req resource.Delete
 		return
 	}
 
+	resp.Diagnostics.Append(checkDeletionProtection(state.DeletionProtection, r.deletionProtection, "DataHub dataset", state.Name.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	deleteTimeout, diags := state.Timeouts.Delete(ctx, DefaultDeleteTimeout)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
 
 	deleteResp, err := r.client.DeleteDatahubDatasetWithResponse(ctx, state.Name.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Deleting DataHub Dataset",
-			"Could not delete dataset, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Deleting DataHub Dataset", "Could not delete dataset", err)...)
 		return
 	}
 
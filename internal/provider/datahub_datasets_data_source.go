@@ -25,7 +25,8 @@ type datahubDatasetsDataSource struct {
 
 type datahubDatasetsDataSourceModel struct {
 	datasource_datahub_datasets.DatahubDatasetsModel
-	Timeouts timeouts.Value `tfsdk:"timeouts"`
+	CustomerContext types.String   `tfsdk:"customer_context"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (d *datahubDatasetsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -50,6 +51,7 @@ func (d *datahubDatasetsDataSource) Configure(_ context.Context, req datasource.
 func (d *datahubDatasetsDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	s := datasource_datahub_datasets.DatahubDatasetsDataSourceSchema(ctx)
 
+	s.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx)
 
 	resp.Schema = s
@@ -70,6 +72,7 @@ func (d *datahubDatasetsDataSource) Read(ctx context.Context, req datasource.Rea
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	// The list endpoint has no parameters — no pagination, no filters.
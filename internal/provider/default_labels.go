@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/doitintl/terraform-provider-doit/internal/provider/models"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// The provider's default_labels are applied through the label assignment API
+// rather than the objects' own request bodies, so the same code covers
+// budgets, alerts and allocations, which have no labels attribute.
+//
+// Assignment happens once, after Create; objects are not relabeled when
+// default_labels changes later. That is also what lets doit_label_assignments
+// take precedence: it is authoritative for the labels it manages, so it
+// removes a default assignment it does not declare, and nothing re-adds it.
+//
+// Reports and annotations additionally expose labels as an attribute. There,
+// default labels are filtered out of state unless the user configured them.
+// Update requests carry back only the default labels the object still has,
+// so a PATCH neither strips them nor re-adds one that was removed.
+
+// assignDefaultLabels assigns a newly created object to each default label.
+// The object already exists at this point, so failures are warnings: returning
+// an error would taint a resource that was created successfully.
+func assignDefaultLabels(ctx context.Context, client *models.ClientWithResponses, defaultLabels []string, objectType models.LabelAssignmentObjectObjectType, objectID string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	assignment := []models.LabelAssignmentObject{{ObjectId: objectID, ObjectType: objectType}}
+
+	for _, labelID := range defaultLabels {
+		apiResp, err := client.AssignObjectsToLabelWithResponse(ctx, labelID, models.AssignObjectsToLabelJSONRequestBody{
+			Add: &assignment,
+		})
+		if err == nil && apiResp.StatusCode() != 200 {
+			err = fmt.Errorf("status: %d, body: %s", apiResp.StatusCode(), string(apiResp.Body))
+		}
+		if err != nil {
+			diags.AddWarning(
+				"Default Label Not Assigned",
+				fmt.Sprintf("The %s %s was created, but could not be assigned default label %s: %s", objectType, objectID, labelID, err),
+			)
+			continue
+		}
+		tflog.Debug(ctx, "Assigned default label", map[string]any{
+			"label_id":    labelID,
+			"object_type": string(objectType),
+			"object_id":   objectID,
+		})
+	}
+
+	return diags
+}
+
+// unassignDefaultLabels removes an object from each default label before it
+// is deleted. A label that no longer exists (404) is treated as success, and
+// other failures are warnings so they never block the delete itself.
+func unassignDefaultLabels(ctx context.Context, client *models.ClientWithResponses, defaultLabels []string, objectType models.LabelAssignmentObjectObjectType, objectID string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	assignment := []models.LabelAssignmentObject{{ObjectId: objectID, ObjectType: objectType}}
+
+	for _, labelID := range defaultLabels {
+		apiResp, err := client.AssignObjectsToLabelWithResponse(ctx, labelID, models.AssignObjectsToLabelJSONRequestBody{
+			Remove: &assignment,
+		})
+		if err == nil && apiResp.StatusCode() != 200 && apiResp.StatusCode() != 204 && apiResp.StatusCode() != 404 {
+			err = fmt.Errorf("status: %d, body: %s", apiResp.StatusCode(), string(apiResp.Body))
+		}
+		if err != nil {
+			diags.AddWarning(
+				"Default Label Not Unassigned",
+				fmt.Sprintf("Could not remove the %s %s from default label %s: %s", objectType, objectID, labelID, err),
+			)
+		}
+	}
+
+	return diags
+}
+
+// assignedLabelsFunc returns the IDs of the labels an object currently has,
+// as read from the API.
+type assignedLabelsFunc func(ctx context.Context) ([]string, error)
+
+// withDefaultLabels returns labels with each default label the object
+// currently has, according to assigned, appended if missing. Default labels
+// the object lacks are not added: it was removed by doit_label_assignments or
+// outside Terraform, or the object predates default_labels, and defaults are
+// only applied at Create.
+//
+// Null and unknown lists are returned unchanged without calling assigned:
+// the request then omits labels, and the API leaves the object's labels,
+// defaults included, alone.
+func withDefaultLabels(ctx context.Context, labels types.List, defaultLabels []string, assigned assignedLabelsFunc) (types.List, diag.Diagnostics) {
+	if len(defaultLabels) == 0 || labels.IsNull() || labels.IsUnknown() {
+		return labels, nil
+	}
+
+	var ids []string
+	diags := labels.ElementsAs(ctx, &ids, false)
+	if diags.HasError() {
+		return labels, diags
+	}
+	current, err := assigned(ctx)
+	if err != nil {
+		diags.Append(apiErrorDiagnostics("Error Reading Default Labels",
+			"Could not read the labels currently assigned, which are needed to keep default labels on update", err)...)
+		return labels, diags
+	}
+	for _, labelID := range defaultLabels {
+		if slices.Contains(current, labelID) && !slices.Contains(ids, labelID) {
+			ids = append(ids, labelID)
+		}
+	}
+
+	merged, d := types.ListValueFrom(ctx, types.StringType, ids)
+	diags.Append(d...)
+	return merged, diags
+}
+
+// withoutDefaultLabels drops default labels from labels read back from the
+// API, keeping any that also appear in keep — the labels the user configured
+// (the prior state on Read, the plan on Update). Without this, every report or
+// annotation carrying a default label would show a diff against its config.
+func withoutDefaultLabels(ctx context.Context, labels, keep types.List, defaultLabels []string) (types.List, diag.Diagnostics) {
+	if len(defaultLabels) == 0 || labels.IsNull() || labels.IsUnknown() {
+		return labels, nil
+	}
+
+	var ids, kept []string
+	diags := labels.ElementsAs(ctx, &ids, false)
+	if !keep.IsNull() && !keep.IsUnknown() {
+		diags.Append(keep.ElementsAs(ctx, &kept, false)...)
+	}
+	if diags.HasError() {
+		return labels, diags
+	}
+
+	filtered := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if slices.Contains(defaultLabels, id) && !slices.Contains(kept, id) {
+			continue
+		}
+		filtered = append(filtered, id)
+	}
+	if len(filtered) == len(ids) {
+		return labels, diags
+	}
+
+	result, d := types.ListValueFrom(ctx, types.StringType, filtered)
+	diags.Append(d...)
+	return result, diags
+}
+
+// reportLabels reads the labels of report id through its config, as
+// GET /reports/{id} runs the report query.
+func reportLabels(client *models.ClientWithResponses, id string) assignedLabelsFunc {
+	return func(ctx context.Context) ([]string, error) {
+		apiResp, err := client.GetReportConfigWithResponse(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if apiResp.StatusCode() != 200 || apiResp.JSON200 == nil {
+			return nil, listStatusError(apiResp.StatusCode(), apiResp.Body)
+		}
+		if apiResp.JSON200.Labels == nil {
+			return nil, nil
+		}
+		return *apiResp.JSON200.Labels, nil
+	}
+}
+
+// annotationLabels reads the labels of annotation id.
+func annotationLabels(client *models.ClientWithResponses, id string) assignedLabelsFunc {
+	return func(ctx context.Context) ([]string, error) {
+		apiResp, err := client.GetAnnotationWithResponse(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if apiResp.StatusCode() != 200 || apiResp.JSON200 == nil {
+			return nil, listStatusError(apiResp.StatusCode(), apiResp.Body)
+		}
+		var ids []string
+		if apiResp.JSON200.Labels != nil {
+			for _, label := range *apiResp.JSON200.Labels {
+				ids = append(ids, label.Id)
+			}
+		}
+		return ids, nil
+	}
+}
@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/doitintl/terraform-provider-doit/internal/provider/models"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestAssignDefaultLabels(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu       sync.Mutex
+		assigned []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		labelID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/analytics/v1/labels/"), "/assignments")
+		if labelID == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var body models.AssignObjectsToLabelJSONRequestBody
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Add == nil || len(*body.Add) != 1 {
+			t.Errorf("unexpected assignment body for %s: %v", labelID, err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		obj := (*body.Add)[0]
+		mu.Lock()
+		assigned = append(assigned, labelID+"/"+string(obj.ObjectType)+"/"+obj.ObjectId)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := models.NewClientWithResponses(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	diags := assignDefaultLabels(context.Background(), client, []string{"team", "missing"}, models.LabelAssignmentObjectObjectTypeBudget, "b1")
+
+	if want := []string{"team/budget/b1"}; !slices.Equal(assigned, want) {
+		t.Errorf("assigned = %v, want %v", assigned, want)
+	}
+	// A failed assignment must not fail the create: it is reported as a warning.
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if diags.WarningsCount() != 1 {
+		t.Errorf("expected 1 warning for the missing label, got %d: %v", diags.WarningsCount(), diags)
+	}
+}
+
+func TestWithDefaultLabels(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	assigned := func(ids ...string) assignedLabelsFunc {
+		return func(context.Context) ([]string, error) { return ids, nil }
+	}
+
+	got, diags := withDefaultLabels(ctx, listOf(t, "mine", "team"), []string{"team", "cost-center"}, assigned("mine", "team", "cost-center"))
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	want := []string{"mine", "team", "cost-center"}
+	if ids := stringsOf(t, got); !slices.Equal(ids, want) {
+		t.Errorf("withDefaultLabels() = %v, want %v", ids, want)
+	}
+
+	// A default label the object no longer has, e.g. removed by
+	// doit_label_assignments, is not added back.
+	got, diags = withDefaultLabels(ctx, listOf(t, "mine"), []string{"team", "cost-center"}, assigned("mine", "team"))
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if ids, want := stringsOf(t, got), []string{"mine", "team"}; !slices.Equal(ids, want) {
+		t.Errorf("withDefaultLabels() = %v, want %v", ids, want)
+	}
+
+	// Without knowing the current labels, the PATCH could strip defaults.
+	failing := func(context.Context) ([]string, error) { return nil, errors.New("boom") }
+	if _, diags := withDefaultLabels(ctx, listOf(t, "mine"), []string{"team"}, failing); !diags.HasError() {
+		t.Error("withDefaultLabels() with a failed lookup: want an error")
+	}
+
+	// An omitted labels attribute is left alone so the request does not touch
+	// the object's labels at all.
+	if got, diags := withDefaultLabels(ctx, types.ListNull(types.StringType), []string{"team"}, failing); diags.HasError() || !got.IsNull() {
+		t.Errorf("withDefaultLabels(null) = %v, want null", got)
+	}
+}
+
+func TestWithoutDefaultLabels(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		fromAPI    []string
+		configured types.List
+		want       []string
+	}{
+		{
+			name:       "default label removed",
+			fromAPI:    []string{"mine", "team"},
+			configured: listOf(t, "mine"),
+			want:       []string{"mine"},
+		},
+		{
+			name:       "default label kept when configured",
+			fromAPI:    []string{"mine", "team"},
+			configured: listOf(t, "mine", "team"),
+			want:       []string{"mine", "team"},
+		},
+		{
+			name:       "import strips all defaults",
+			fromAPI:    []string{"team", "other"},
+			configured: types.ListNull(types.StringType),
+			want:       []string{"other"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, diags := withoutDefaultLabels(ctx, listOf(t, tt.fromAPI...), tt.configured, []string{"team"})
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if ids := stringsOf(t, got); !slices.Equal(ids, tt.want) {
+				t.Errorf("withoutDefaultLabels() = %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
+
+func listOf(t *testing.T, ids ...string) types.List {
+	t.Helper()
+	l, diags := types.ListValueFrom(context.Background(), types.StringType, ids)
+	if diags.HasError() {
+		t.Fatalf("building list: %v", diags)
+	}
+	return l
+}
+
+func stringsOf(t *testing.T, l types.List) []string {
+	t.Helper()
+	var ids []string
+	if diags := l.ElementsAs(context.Background(), &ids, false); diags.HasError() {
+		t.Fatalf("reading list: %v", diags)
+	}
+	return ids
+}
@@ -0,0 +1,256 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/doitintl/terraform-provider-doit/internal/provider/resource_budget"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// defaultSlackChannelModel maps one element of the provider's
+// default_slack_channels attribute.
+type defaultSlackChannelModel struct {
+	Id         types.String `tfsdk:"id"`
+	CustomerId types.String `tfsdk:"customer_id"`
+	Name       types.String `tfsdk:"name"`
+	Shared     types.Bool   `tfsdk:"shared"`
+	Type       types.String `tfsdk:"type"`
+	Workspace  types.String `tfsdk:"workspace"`
+}
+
+// defaultCollaboratorModel maps one element of the provider's
+// default_collaborators attribute.
+type defaultCollaboratorModel struct {
+	Email types.String `tfsdk:"email"`
+	Role  types.String `tfsdk:"role"`
+}
+
+// notificationDefaults holds the provider-level recipients, Slack channels and
+// collaborators that budgets and alerts fall back to when their own attribute
+// is not set in configuration.
+//
+// The defaults are written into the plan by ModifyPlan, so Create and Update
+// send them like any configured value and the overlay functions resolve the
+// rest (e.g. Slack channel metadata the user did not spell out) from the API
+// response. Nothing here runs on Read.
+type notificationDefaults struct {
+	recipients    []string
+	slackChannels []defaultSlackChannelModel
+	collaborators []defaultCollaboratorModel
+}
+
+// recipientsList returns the default recipients as a plan value, or a null
+// list when none are configured.
+func (d notificationDefaults) recipientsList(ctx context.Context) (types.List, diag.Diagnostics) {
+	if len(d.recipients) == 0 {
+		return types.ListNull(types.StringType), nil
+	}
+	return types.ListValueFrom(ctx, types.StringType, d.recipients)
+}
+
+// budgetSlackChannelsList returns the default Slack channels as a
+// doit_budget recipients_slack_channels plan value. Fields left out of the
+// provider configuration are unknown, so overlayBudgetSlackChannel fills them
+// from the API response.
+func (d notificationDefaults) budgetSlackChannelsList(ctx context.Context) (types.List, diag.Diagnostics) {
+	elemType := resource_budget.RecipientsSlackChannelsValue{}.Type(ctx)
+	if len(d.slackChannels) == 0 {
+		return types.ListNull(elemType), nil
+	}
+
+	var diags diag.Diagnostics
+	elems := make([]attr.Value, len(d.slackChannels))
+	for i, ch := range d.slackChannels {
+		v, elemDiags := resource_budget.NewRecipientsSlackChannelsValue(
+			resource_budget.RecipientsSlackChannelsValue{}.AttributeTypes(ctx),
+			map[string]attr.Value{
+				"id":          ch.Id,
+				"customer_id": stringOrUnknown(ch.CustomerId),
+				"name":        stringOrUnknown(ch.Name),
+				"shared":      boolOrUnknown(ch.Shared),
+				"type":        stringOrUnknown(ch.Type),
+				"workspace":   stringOrUnknown(ch.Workspace),
+			},
+		)
+		diags.Append(elemDiags...)
+		elems[i] = v
+	}
+	if diags.HasError() {
+		return types.ListNull(elemType), diags
+	}
+
+	list, listDiags := types.ListValue(elemType, elems)
+	diags.Append(listDiags...)
+	return list, diags
+}
+
+// budgetCollaboratorsList returns the default collaborators as a doit_budget
+// collaborators plan value.
+func (d notificationDefaults) budgetCollaboratorsList(ctx context.Context) (types.List, diag.Diagnostics) {
+	elemType := resource_budget.CollaboratorsValue{}.Type(ctx)
+	if len(d.collaborators) == 0 {
+		return types.ListNull(elemType), nil
+	}
+
+	var diags diag.Diagnostics
+	elems := make([]attr.Value, len(d.collaborators))
+	for i, c := range d.collaborators {
+		v, elemDiags := resource_budget.NewCollaboratorsValue(
+			resource_budget.CollaboratorsValue{}.AttributeTypes(ctx),
+			map[string]attr.Value{
+				"email": c.Email,
+				"role":  c.Role,
+			},
+		)
+		diags.Append(elemDiags...)
+		elems[i] = v
+	}
+	if diags.HasError() {
+		return types.ListNull(elemType), diags
+	}
+
+	list, listDiags := types.ListValue(elemType, elems)
+	diags.Append(listDiags...)
+	return list, diags
+}
+
+// applyListDefault writes defaults into the plan at p when the attribute is
+// not set in configuration. Call from ModifyPlan after guarding destroy.
+//
+// If the prior state already satisfies the defaults, the state value is
+// planned instead: it carries the API-resolved fields the defaults leave
+// unknown, so an unchanged default does not show up as "known after apply" on
+// every plan.
+func applyListDefault(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, p path.Path, defaults types.List) {
+	if defaults.IsNull() {
+		return
+	}
+
+	var configVal types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, p, &configVal)...)
+	if resp.Diagnostics.HasError() || !configVal.IsNull() {
+		return
+	}
+
+	planned := defaults
+	if !req.State.Raw.IsNull() {
+		var stateVal types.List
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, p, &stateVal)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if listSatisfiesDefaults(ctx, stateVal, defaults) {
+			planned = stateVal
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, p, planned)...)
+}
+
+// listSatisfiesDefaults reports whether state holds the same elements as
+// defaults, in order. For object elements only the attributes the defaults
+// know are compared; unknown ones are the API's to fill in.
+func listSatisfiesDefaults(ctx context.Context, state, defaults types.List) bool {
+	if state.IsNull() || state.IsUnknown() || len(state.Elements()) != len(defaults.Elements()) {
+		return false
+	}
+
+	stateElems := state.Elements()
+	for i, want := range defaults.Elements() {
+		wantObj, wantIsObj := want.(basetypes.ObjectValuable)
+		gotObj, gotIsObj := stateElems[i].(basetypes.ObjectValuable)
+		if !wantIsObj || !gotIsObj {
+			if !want.Equal(stateElems[i]) {
+				return false
+			}
+			continue
+		}
+
+		wantVal, d := wantObj.ToObjectValue(ctx)
+		if d.HasError() {
+			return false
+		}
+		gotVal, d := gotObj.ToObjectValue(ctx)
+		if d.HasError() {
+			return false
+		}
+		gotAttrs := gotVal.Attributes()
+		for name, v := range wantVal.Attributes() {
+			if v.IsUnknown() {
+				continue
+			}
+			if !v.Equal(gotAttrs[name]) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func stringOrUnknown(v types.String) types.String {
+	if v.IsNull() {
+		return types.StringUnknown()
+	}
+	return v
+}
+
+func boolOrUnknown(v types.Bool) types.Bool {
+	if v.IsNull() {
+		return types.BoolUnknown()
+	}
+	return v
+}
+
+// defaultCollaboratorsOwnerValidator requires exactly one owner in the
+// provider's default_collaborators, mirroring the check doit_budget applies to
+// its own collaborators (budgetCollaboratorsOwnerValidator). A default that
+// the API would reject on every budget is better caught once, here.
+type defaultCollaboratorsOwnerValidator struct{}
+
+var _ validator.List = defaultCollaboratorsOwnerValidator{}
+
+func (v defaultCollaboratorsOwnerValidator) Description(_ context.Context) string {
+	return "Validates that the list contains exactly one collaborator with role owner"
+}
+
+func (v defaultCollaboratorsOwnerValidator) MarkdownDescription(_ context.Context) string {
+	return "Validates that the list contains exactly one collaborator with role `owner`"
+}
+
+func (v defaultCollaboratorsOwnerValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var collaborators []defaultCollaboratorModel
+	resp.Diagnostics.Append(req.ConfigValue.ElementsAs(ctx, &collaborators, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	owners := 0
+	for _, c := range collaborators {
+		if c.Role.IsUnknown() {
+			return
+		}
+		if c.Role.ValueString() == "owner" {
+			owners++
+		}
+	}
+
+	if owners != 1 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Exactly One Owner Required",
+			fmt.Sprintf("default_collaborators must contain exactly one collaborator with role 'owner', found %d.", owners),
+		)
+	}
+}
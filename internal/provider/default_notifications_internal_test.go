@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/doitintl/terraform-provider-doit/internal/provider/resource_budget"
+)
+
+// TestAlertResource_ModifyPlan_DefaultRecipients covers when the provider's
+// default_notification_recipients replace an alert's planned recipients.
+func TestAlertResource_ModifyPlan_DefaultRecipients(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	(&alertResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	sch := schemaResp.Schema
+	nullRaw := tftypes.NewValue(sch.Type().TerraformType(ctx), nil)
+
+	r := &alertResource{defaults: notificationDefaults{recipients: []string{"finops@example.com"}}}
+
+	tests := []struct {
+		name       string
+		configured []string // nil: recipients omitted from config
+		state      []string // nil: resource is being created
+		planned    types.List
+		want       []string
+	}{
+		{
+			name:    "create without recipients uses defaults",
+			planned: types.ListUnknown(types.StringType),
+			want:    []string{"finops@example.com"},
+		},
+		{
+			name:       "configured recipients win",
+			configured: []string{"me@example.com"},
+			planned:    listOf(t, "me@example.com"),
+			want:       []string{"me@example.com"},
+		},
+		{
+			name:    "state differing from defaults is updated",
+			state:   []string{"old@example.com"},
+			planned: listOf(t, "old@example.com"),
+			want:    []string{"finops@example.com"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := tfsdk.Config{Raw: nullRaw, Schema: sch}
+			if tt.configured != nil {
+				setConfigRecipients(t, &config, listOf(t, tt.configured...))
+			} else {
+				setConfigRecipients(t, &config, types.ListNull(types.StringType))
+			}
+			state := tfsdk.State{Raw: nullRaw, Schema: sch}
+			if tt.state != nil {
+				if diags := state.SetAttribute(ctx, path.Root("recipients"), listOf(t, tt.state...)); diags.HasError() {
+					t.Fatalf("setting state: %v", diags)
+				}
+			}
+			plan := tfsdk.Plan{Raw: nullRaw, Schema: sch}
+			if diags := plan.SetAttribute(ctx, path.Root("recipients"), tt.planned); diags.HasError() {
+				t.Fatalf("setting plan: %v", diags)
+			}
+
+			resp := resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, resource.ModifyPlanRequest{Config: config, State: state, Plan: plan}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var got types.List
+			resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("recipients"), &got)...)
+			if ids := stringsOf(t, got); !slices.Equal(ids, tt.want) {
+				t.Errorf("planned recipients = %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
+
+// TestListSatisfiesDefaults_SlackChannels checks that fields the provider
+// default leaves to the API do not make an otherwise matching state differ.
+func TestListSatisfiesDefaults_SlackChannels(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	defaults, diags := notificationDefaults{slackChannels: []defaultSlackChannelModel{{
+		Id:         types.StringValue("C123"),
+		CustomerId: types.StringNull(),
+		Name:       types.StringNull(),
+		Shared:     types.BoolNull(),
+		Type:       types.StringNull(),
+		Workspace:  types.StringNull(),
+	}}}.budgetSlackChannelsList(ctx)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	stateFor := func(id string) types.List {
+		v, d := resource_budget.NewRecipientsSlackChannelsValue(
+			resource_budget.RecipientsSlackChannelsValue{}.AttributeTypes(ctx),
+			map[string]attr.Value{
+				"id":          types.StringValue(id),
+				"customer_id": types.StringValue("cust"),
+				"name":        types.StringValue("#finops"),
+				"shared":      types.BoolValue(false),
+				"type":        types.StringValue("public"),
+				"workspace":   types.StringValue("T1"),
+			},
+		)
+		if d.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", d)
+		}
+		l, d := types.ListValue(resource_budget.RecipientsSlackChannelsValue{}.Type(ctx), []attr.Value{v})
+		if d.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", d)
+		}
+		return l
+	}
+
+	if !listSatisfiesDefaults(ctx, stateFor("C123"), defaults) {
+		t.Error("state with the default channel and API-filled metadata should satisfy the defaults")
+	}
+	if listSatisfiesDefaults(ctx, stateFor("C999"), defaults) {
+		t.Error("state with a different channel should not satisfy the defaults")
+	}
+}
+
+func setConfigRecipients(t *testing.T, config *tfsdk.Config, recipients types.List) {
+	t.Helper()
+	ctx := context.Background()
+	// tfsdk.Config has no SetAttribute; build it through a plan and copy the raw value.
+	plan := tfsdk.Plan{Raw: config.Raw, Schema: config.Schema}
+	if diags := plan.SetAttribute(ctx, path.Root("recipients"), recipients); diags.HasError() {
+		t.Fatalf("setting config: %v", diags)
+	}
+	config.Raw = plan.Raw
+}
@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// deletionProtectionAttribute is the deletion_protection attribute shared by
+// doit_report, doit_allocation, doit_budget, doit_folder and
+// doit_datahub_dataset. It is provider-side only: nothing is sent to the API,
+// so the value in state is always exactly what was configured.
+//
+// It is deliberately Optional without Computed. When unset, the provider's
+// deletion_protection setting applies at delete time, which keeps a provider
+// default from showing up as a diff on every existing resource.
+func deletionProtectionAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Optional: true,
+		Description: "Whether Terraform is prevented from deleting this object. While true, destroying or " +
+			"replacing the resource fails; set it to false and apply before removing the resource. " +
+			"Defaults to the provider's deletion_protection setting.",
+		MarkdownDescription: "Whether Terraform is prevented from deleting this object. While `true`, destroying or " +
+			"replacing the resource fails; set it to `false` and apply before removing the resource. " +
+			"Defaults to the provider's `deletion_protection` setting.",
+	}
+}
+
+// checkDeletionProtection returns an error diagnostic when the object in state
+// is protected. Call at the start of Delete, before any API request.
+func checkDeletionProtection(protection types.Bool, providerDefault bool, kind, id string) diag.Diagnostics {
+	protected := providerDefault
+	if !protection.IsNull() && !protection.IsUnknown() {
+		protected = protection.ValueBool()
+	}
+	if !protected {
+		return nil
+	}
+
+	var diags diag.Diagnostics
+	source := "deletion_protection is true"
+	if protection.IsNull() {
+		source = "deletion_protection is not set, and the provider's deletion_protection is true"
+	}
+	diags.AddAttributeError(
+		path.Root("deletion_protection"),
+		"Deletion Protection Enabled",
+		fmt.Sprintf("Cannot delete %s %s: %s. Set deletion_protection = false on the resource and apply "+
+			"that change first, then destroy or replace it.", kind, id, source),
+	)
+	return diags
+}
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/doitintl/terraform-provider-doit/internal/provider/models"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestCheckDeletionProtection(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		protection      types.Bool
+		providerDefault bool
+		wantError       bool
+	}{
+		{"unset without provider default", types.BoolNull(), false, false},
+		{"unset with provider default", types.BoolNull(), true, true},
+		{"enabled", types.BoolValue(true), false, true},
+		{"disabled overrides provider default", types.BoolValue(false), true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			diags := checkDeletionProtection(tt.protection, tt.providerDefault, "report", "r1")
+			if diags.HasError() != tt.wantError {
+				t.Errorf("checkDeletionProtection() error = %v, want %v: %v", diags.HasError(), tt.wantError, diags)
+			}
+		})
+	}
+}
+
+// TestFolderResource_Delete_Protected confirms a protected folder is never
+// deleted through the API.
+func TestFolderResource_Delete_Protected(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	var deletes atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			deletes.Add(1)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := models.NewClientWithResponses(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	r := &folderResource{client: client}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	state := tfsdk.State{Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil), Schema: schemaResp.Schema}
+	for p, v := range map[string]any{
+		"id":                  types.StringValue("f1"),
+		"deletion_protection": types.BoolValue(true),
+	} {
+		if diags := state.SetAttribute(ctx, path.Root(p), v); diags.HasError() {
+			t.Fatalf("setting %s: %v", p, diags)
+		}
+	}
+
+	resp := resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, &resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Error("expected an error deleting a protected folder")
+	}
+	if n := deletes.Load(); n != 0 {
+		t.Errorf("expected no DELETE requests, got %d", n)
+	}
+}
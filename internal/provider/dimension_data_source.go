@@ -25,7 +25,8 @@ type dimensionDataSource struct {
 
 type dimensionDataSourceModel struct {
 	datasource_dimension.DimensionModel
-	Timeouts timeouts.Value `tfsdk:"timeouts"`
+	CustomerContext types.String   `tfsdk:"customer_context"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (d *dimensionDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -50,6 +51,7 @@ func (d *dimensionDataSource) Configure(_ context.Context, req datasource.Config
 func (d *dimensionDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	s := datasource_dimension.DimensionDataSourceSchema(ctx)
 
+	s.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx)
 
 	resp.Schema = s
@@ -68,6 +70,7 @@ func (d *dimensionDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
 
 	// If required inputs are unknown (depend on resources not yet created),
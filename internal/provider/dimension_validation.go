@@ -0,0 +1,362 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/doitintl/terraform-provider-doit/internal/provider/models"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// maxDimensionSuggestions bounds the did-you-mean list of an unknown
+// dimension key or value.
+const maxDimensionSuggestions = 3
+
+// dimensionValidationExemptTypes are dimension types whose filters name
+// allocations or dates rather than billing data. The dimension endpoints do
+// not list them, so they are left to the API.
+var dimensionValidationExemptTypes = map[string]bool{
+	"datetime":          true,
+	"allocation":        true,
+	"allocation_rule":   true,
+	"attribution":       true,
+	"attribution_group": true,
+}
+
+// dimensionFilter is one planned scope, filter or allocation component.
+type dimensionFilter struct {
+	Type   types.String
+	Key    types.String
+	Mode   types.String
+	Values types.List
+}
+
+// dimensionValidator checks planned dimension filters against
+// /analytics/v1/dimension and /analytics/v1/dimensions when the provider's
+// validate_dimensions_online is set. Typos in dimension keys and values are
+// otherwise only caught by the API during apply, or not at all: a filter on a
+// value that does not exist silently matches nothing.
+type dimensionValidator struct {
+	client *models.ClientWithResponses
+
+	// dimensions caches GET /analytics/v1/dimension results for one plan,
+	// keyed by type and key; nil marks a dimension the API does not know.
+	dimensions map[[2]string]*models.DimensionsExternalAPIGetResponse
+	// catalog caches the dimension list used for key suggestions.
+	catalog []models.DimensionExternalAPIListItem
+}
+
+// validateDimensionFilters validates the dimension filters planned at the
+// list element paths matched by each expression, e.g.
+// path.MatchRoot("scopes").AtAnyListIndex(). keyAttr names the attribute
+// holding the dimension key: "id" for scopes and filters, "key" for
+// allocation components.
+//
+// Unknown keys and values are reported as errors against the offending
+// attribute. Filters unchanged from state are not checked again, so a
+// dimension that disappears after apply does not block unrelated changes.
+// When the API cannot be reached, validation is skipped with a warning.
+func validateDimensionFilters(ctx context.Context, client *models.ClientWithResponses, req resource.ModifyPlanRequest, keyAttr string, exprs ...path.Expression) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if req.Plan.Raw.IsNull() {
+		return diags
+	}
+
+	var customerContext types.String
+	diags.Append(req.Plan.GetAttribute(ctx, path.Root("customer_context"), &customerContext)...)
+	if diags.HasError() {
+		return diags
+	}
+	ctx = withCustomerContext(ctx, customerContext)
+
+	v := &dimensionValidator{
+		client:     client,
+		dimensions: map[[2]string]*models.DimensionsExternalAPIGetResponse{},
+	}
+	for _, expr := range exprs {
+		paths, d := req.Plan.PathMatches(ctx, expr)
+		diags.Append(d...)
+		if d.HasError() {
+			return diags
+		}
+		for _, p := range paths {
+			// PathMatches also returns null or unknown parents of the
+			// expression; only list elements are filters.
+			if !expr.Matches(p) {
+				continue
+			}
+			filter, d := readDimensionFilter(ctx, req.Plan, p, keyAttr)
+			diags.Append(d...)
+			if d.HasError() {
+				return diags
+			}
+			if !req.State.Raw.IsNull() {
+				if prior, d := readDimensionFilter(ctx, req.State, p, keyAttr); !d.HasError() && prior.equal(filter) {
+					continue
+				}
+			}
+			d, ok := v.validate(ctx, p, keyAttr, filter)
+			diags.Append(d...)
+			if !ok {
+				return diags
+			}
+		}
+	}
+	return diags
+}
+
+// attributeGetter is implemented by tfsdk.Plan and tfsdk.State.
+type attributeGetter interface {
+	GetAttribute(ctx context.Context, p path.Path, target any) diag.Diagnostics
+}
+
+// readDimensionFilter reads the filter at the list element path p.
+func readDimensionFilter(ctx context.Context, data attributeGetter, p path.Path, keyAttr string) (dimensionFilter, diag.Diagnostics) {
+	var f dimensionFilter
+	var diags diag.Diagnostics
+	diags.Append(data.GetAttribute(ctx, p.AtName("type"), &f.Type)...)
+	diags.Append(data.GetAttribute(ctx, p.AtName(keyAttr), &f.Key)...)
+	diags.Append(data.GetAttribute(ctx, p.AtName("mode"), &f.Mode)...)
+	diags.Append(data.GetAttribute(ctx, p.AtName("values"), &f.Values)...)
+	return f, diags
+}
+
+func (f dimensionFilter) equal(o dimensionFilter) bool {
+	return f.Type.Equal(o.Type) && f.Key.Equal(o.Key) && f.Mode.Equal(o.Mode) && f.Values.Equal(o.Values)
+}
+
+// validate checks one filter at path p. ok is false when the API could not
+// be queried and validation should stop.
+func (v *dimensionValidator) validate(ctx context.Context, p path.Path, keyAttr string, f dimensionFilter) (diags diag.Diagnostics, ok bool) {
+	if f.Type.IsNull() || f.Type.IsUnknown() || f.Key.IsNull() || f.Key.IsUnknown() {
+		return diags, true
+	}
+	dimType, key := f.Type.ValueString(), f.Key.ValueString()
+	if dimensionValidationExemptTypes[dimType] || key == "" {
+		return diags, true
+	}
+
+	dim, err := v.dimension(ctx, dimType, key)
+	if err != nil {
+		diags.Append(dimensionValidationSkipped(err))
+		return diags, false
+	}
+
+	if dim == nil {
+		detail, err := v.unknownKeyDetail(ctx, dimType, key)
+		if err != nil {
+			diags.Append(dimensionValidationSkipped(err))
+			return diags, false
+		}
+		diags.AddAttributeError(p.AtName(keyAttr), "Unknown Dimension", detail)
+		return diags, true
+	}
+
+	// Only exact matches can be checked against the known values.
+	if !f.Mode.IsNull() && !f.Mode.IsUnknown() && f.Mode.ValueString() != "is" {
+		return diags, true
+	}
+	if f.Values.IsNull() || f.Values.IsUnknown() || dim.Values == nil || len(*dim.Values) == 0 {
+		return diags, true
+	}
+
+	known := make(map[string]bool, len(*dim.Values))
+	candidates := make([]string, 0, len(*dim.Values))
+	for _, val := range *dim.Values {
+		if val.Value != nil && !known[*val.Value] {
+			known[*val.Value] = true
+			candidates = append(candidates, *val.Value)
+		}
+	}
+
+	var values []types.String
+	diags.Append(f.Values.ElementsAs(ctx, &values, false)...)
+	for i, val := range values {
+		if val.IsNull() || val.IsUnknown() || known[val.ValueString()] {
+			continue
+		}
+		// Legacy "[Service N/A]" sentinels are flagged by the resources' own
+		// validators.
+		if isNAFallback(val.ValueString()) {
+			continue
+		}
+		diags.AddAttributeError(
+			p.AtName("values").AtListIndex(i),
+			"Unknown Dimension Value",
+			fmt.Sprintf("%q is not a value of the %s dimension %q.%s", val.ValueString(), dimType, key,
+				didYouMean(closestMatches(val.ValueString(), candidates, maxDimensionSuggestions))),
+		)
+	}
+	return diags, true
+}
+
+// dimensionValidationSkipped reports that the dimensions could not be read.
+// It is a warning: an API hiccup must not block a plan the API itself would
+// accept.
+func dimensionValidationSkipped(err error) diag.Diagnostic {
+	return diag.NewWarningDiagnostic(
+		"Dimension Validation Skipped",
+		"validate_dimensions_online is set, but the dimensions could not be read from the DoiT API, "+
+			"so dimension keys and values were not checked: "+err.Error(),
+	)
+}
+
+// dimension returns the dimension with the given type and key, or nil when
+// the API does not know it.
+func (v *dimensionValidator) dimension(ctx context.Context, dimType, key string) (*models.DimensionsExternalAPIGetResponse, error) {
+	cacheKey := [2]string{dimType, key}
+	if dim, ok := v.dimensions[cacheKey]; ok {
+		return dim, nil
+	}
+
+	resp, err := v.client.GetDimensionsWithResponse(ctx, &models.GetDimensionsParams{
+		Type: models.DimensionsTypes(dimType),
+		Id:   key,
+	})
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case resp.StatusCode() == http.StatusNotFound:
+		v.dimensions[cacheKey] = nil
+		return nil, nil
+	case resp.StatusCode() != http.StatusOK || resp.JSON200 == nil:
+		return nil, fmt.Errorf("unexpected status %d reading dimension %s/%s", resp.StatusCode(), dimType, key)
+	}
+	v.dimensions[cacheKey] = resp.JSON200
+	return resp.JSON200, nil
+}
+
+// unknownKeyDetail explains an unknown dimension key, pointing out a
+// dimension with the same key but another type, or similar keys of the
+// requested type.
+func (v *dimensionValidator) unknownKeyDetail(ctx context.Context, dimType, key string) (string, error) {
+	catalog, err := v.listDimensions(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var candidates []string
+	for _, d := range catalog {
+		if d.Id == nil || d.Type == nil {
+			continue
+		}
+		t := string(*d.Type)
+		if *d.Id == key && !sameDimensionType(t, dimType) {
+			return fmt.Sprintf("The dimension %q exists with type %q, not %q.", key, t, dimType), nil
+		}
+		if sameDimensionType(t, dimType) {
+			candidates = append(candidates, *d.Id)
+		}
+	}
+	return fmt.Sprintf("No %s dimension with key %q exists for this account.%s", dimType, key,
+		didYouMean(closestMatches(key, candidates, maxDimensionSuggestions))), nil
+}
+
+// listDimensions returns every dimension of the account, fetched once.
+func (v *dimensionValidator) listDimensions(ctx context.Context) ([]models.DimensionExternalAPIListItem, error) {
+	if v.catalog != nil {
+		return v.catalog, nil
+	}
+
+	catalog := []models.DimensionExternalAPIListItem{}
+	params := &models.ListDimensionsParams{}
+	for {
+		resp, err := v.client.ListDimensionsWithResponse(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode() != http.StatusOK || resp.JSON200 == nil {
+			return nil, fmt.Errorf("unexpected status %d listing dimensions", resp.StatusCode())
+		}
+		if resp.JSON200.Dimensions != nil {
+			catalog = append(catalog, *resp.JSON200.Dimensions...)
+		}
+		if resp.JSON200.PageToken == nil || *resp.JSON200.PageToken == "" {
+			break
+		}
+		params.PageToken = resp.JSON200.PageToken
+	}
+	v.catalog = catalog
+	return catalog, nil
+}
+
+// sameDimensionType reports whether two dimension types are equal or
+// aliases of each other (see dimensionsTypeAliases).
+func sameDimensionType(a, b string) bool {
+	return a == b || dimensionsTypeAliases[a] == b
+}
+
+// didYouMean formats suggestions as a sentence to append to a diagnostic.
+func didYouMean(suggestions []string) string {
+	if len(suggestions) == 0 {
+		return ""
+	}
+	quoted := make([]string, len(suggestions))
+	for i, s := range suggestions {
+		quoted[i] = fmt.Sprintf("%q", s)
+	}
+	return " Did you mean " + strings.Join(quoted, ", ") + "?"
+}
+
+// closestMatches returns up to n candidates closest to target, ignoring
+// case. Candidates more edits away than a quarter of target's length, or 2
+// for short targets, are not suggested.
+func closestMatches(target string, candidates []string, n int) []string {
+	target = strings.ToLower(target)
+	maxDist := max(2, len([]rune(target))/4)
+
+	type match struct {
+		value string
+		dist  int
+	}
+	var matches []match
+	for _, c := range candidates {
+		if d := levenshtein(target, strings.ToLower(c)); d <= maxDist {
+			matches = append(matches, match{c, d})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].dist != matches[j].dist {
+			return matches[i].dist < matches[j].dist
+		}
+		return matches[i].value < matches[j].value
+	})
+
+	var out []string
+	for _, m := range matches {
+		if len(out) == n {
+			break
+		}
+		out = append(out, m.value)
+	}
+	return out
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,223 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/doitintl/terraform-provider-doit/internal/provider/resource_budget"
+)
+
+func TestClosestMatches(t *testing.T) {
+	t.Parallel()
+
+	candidates := []string{"service_description", "service_id", "sku_description", "project_id", "region"}
+	tests := map[string][]string{
+		"service_descripton":  {"service_description"},
+		"Service_Description": {"service_description"},
+		"servce_id":           {"service_id"},
+		"cost_center":         nil,
+	}
+	for target, want := range tests {
+		if got := closestMatches(target, candidates, 3); !slices.Equal(got, want) {
+			t.Errorf("closestMatches(%q) = %v, want %v", target, got, want)
+		}
+	}
+
+	if got := closestMatches("aa", []string{"ab", "ba", "aab", "bb", "a"}, 2); !slices.Equal(got, []string{"a", "aab"}) {
+		t.Errorf("closestMatches did not keep the 2 nearest in order: %v", got)
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"kitten", "sitting", 3},
+		{"région", "region", 1},
+	} {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+// dimensionTestHandler serves one fixed dimension with two values and a
+// dimension list, counting requests.
+func dimensionTestHandler(calls *atomic.Int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/analytics/v1/dimension":
+			if r.URL.Query().Get("type") != "fixed" || r.URL.Query().Get("id") != "service_description" {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = io.WriteString(w, `{"title":"Dimension not found","status":404}`)
+				return
+			}
+			_, _ = io.WriteString(w, `{"id":"service_description","type":"fixed","values":[
+				{"cloud":"amazon-web-services","value":"Amazon Simple Storage Service"},
+				{"cloud":"google-cloud","value":"Compute Engine"}]}`)
+		case "/analytics/v1/dimensions":
+			_, _ = io.WriteString(w, `{"dimensions":[
+				{"id":"service_description","type":"fixed"},
+				{"id":"service_id","type":"fixed"},
+				{"id":"team","type":"label"}]}`)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+}
+
+func budgetScope(ctx context.Context, dimType, key, mode string, values ...string) attr.Value {
+	vals := make([]attr.Value, len(values))
+	for i, v := range values {
+		vals[i] = types.StringValue(v)
+	}
+	m := types.StringNull()
+	if mode != "" {
+		m = types.StringValue(mode)
+	}
+	return resource_budget.NewScopesValueMust(resource_budget.ScopesValue{}.AttributeTypes(ctx), map[string]attr.Value{
+		"case_insensitive": types.BoolNull(),
+		"id":               types.StringValue(key),
+		"include_null":     types.BoolNull(),
+		"inverse":          types.BoolNull(),
+		"mode":             m,
+		"type":             types.StringValue(dimType),
+		"values":           types.ListValueMust(types.StringType, vals),
+	})
+}
+
+func TestBudgetResource_ModifyPlan_ValidateDimensions(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	(&budgetResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	sch := schemaResp.Schema
+	nullRaw := tftypes.NewValue(sch.Type().TerraformType(ctx), nil)
+	scopesType := resource_budget.ScopesValue{}.Type(ctx)
+
+	planWith := func(t *testing.T, scopes ...attr.Value) tfsdk.Plan {
+		t.Helper()
+		plan := tfsdk.Plan{Raw: nullRaw, Schema: sch}
+		if diags := plan.SetAttribute(ctx, path.Root("scopes"), types.ListValueMust(scopesType, scopes)); diags.HasError() {
+			t.Fatalf("setting plan: %v", diags)
+		}
+		return plan
+	}
+	modifyPlan := func(t *testing.T, r *budgetResource, state tfsdk.State, plan tfsdk.Plan) diag.Diagnostics {
+		t.Helper()
+		config := tfsdk.Config{Raw: plan.Raw, Schema: sch}
+		resp := resource.ModifyPlanResponse{Plan: plan}
+		r.ModifyPlan(ctx, resource.ModifyPlanRequest{Config: config, State: state, Plan: plan}, &resp)
+		return resp.Diagnostics
+	}
+	noState := tfsdk.State{Raw: nullRaw, Schema: sch}
+
+	t.Run("unknown keys and values", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+		r := &budgetResource{client: newImportTestClient(t, dimensionTestHandler(&calls)), validateDimensions: true}
+		diags := modifyPlan(t, r, noState, planWith(t,
+			budgetScope(ctx, "fixed", "service_description", "", "Amazon Simple Storage Service", "Compute Engin", "[Service N/A]"),
+			budgetScope(ctx, "fixed", "service_descripton", ""),
+			budgetScope(ctx, "fixed", "team", ""),
+			budgetScope(ctx, "fixed", "service_description", "starts_with", "Amazon"),
+			budgetScope(ctx, "allocation_rule", "allocation_rule", "", "rule-1"),
+		))
+
+		want := []struct {
+			path   path.Path
+			detail string
+		}{
+			{
+				path.Root("scopes").AtListIndex(0).AtName("values").AtListIndex(1),
+				`"Compute Engin" is not a value of the fixed dimension "service_description". Did you mean "Compute Engine"?`,
+			},
+			{
+				path.Root("scopes").AtListIndex(1).AtName("id"),
+				`No fixed dimension with key "service_descripton" exists for this account. Did you mean "service_description"?`,
+			},
+			{
+				path.Root("scopes").AtListIndex(2).AtName("id"),
+				`The dimension "team" exists with type "label", not "fixed".`,
+			},
+		}
+		if len(diags) != len(want) {
+			t.Fatalf("got %d diagnostics, want %d: %v", len(diags), len(want), diags)
+		}
+		for i, w := range want {
+			d, ok := diags[i].(diag.DiagnosticWithPath)
+			if !ok || !d.Path().Equal(w.path) || d.Severity() != diag.SeverityError || d.Detail() != w.detail {
+				t.Errorf("diagnostic %d = %v, want an error at %s: %s", i, diags[i], w.path, w.detail)
+			}
+		}
+		// One GET per distinct dimension and a single list for suggestions.
+		if got := calls.Load(); got != 4 {
+			t.Errorf("API received %d requests, want 4", got)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+		r := &budgetResource{client: newImportTestClient(t, dimensionTestHandler(&calls))}
+		if diags := modifyPlan(t, r, noState, planWith(t, budgetScope(ctx, "fixed", "nope", ""))); diags.HasError() {
+			t.Errorf("unexpected diagnostics: %v", diags)
+		}
+		if calls.Load() != 0 {
+			t.Error("the API was called with validate_dimensions_online unset")
+		}
+	})
+
+	t.Run("unchanged scopes are not revalidated", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+		r := &budgetResource{client: newImportTestClient(t, dimensionTestHandler(&calls)), validateDimensions: true}
+		plan := planWith(t, budgetScope(ctx, "fixed", "retired_dimension", ""))
+		state := tfsdk.State{Raw: plan.Raw, Schema: sch}
+		if diags := modifyPlan(t, r, state, plan); diags.HasError() {
+			t.Errorf("unexpected diagnostics: %v", diags)
+		}
+		if calls.Load() != 0 {
+			t.Error("an unchanged scope was validated")
+		}
+	})
+
+	t.Run("API failure warns", func(t *testing.T) {
+		t.Parallel()
+
+		r := &budgetResource{
+			client: newImportTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+			}),
+			validateDimensions: true,
+		}
+		diags := modifyPlan(t, r, noState, planWith(t, budgetScope(ctx, "fixed", "service_description", "", "x")))
+		if diags.HasError() || diags.WarningsCount() != 1 || !strings.Contains(diags.Warnings()[0].Detail(), "status 403") {
+			t.Errorf("diagnostics = %v, want one warning about the failed request", diags)
+		}
+	})
+}
@@ -25,7 +25,8 @@ type dimensionsDataSource struct {
 
 type dimensionsDataSourceModel struct {
 	datasource_dimensions.DimensionsModel
-	Timeouts timeouts.Value `tfsdk:"timeouts"`
+	CustomerContext types.String   `tfsdk:"customer_context"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (d *dimensionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -35,6 +36,7 @@ func (d *dimensionsDataSource) Metadata(ctx context.Context, req datasource.Meta
 func (d *dimensionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	s := datasource_dimensions.DimensionsDataSourceSchema(ctx)
 
+	s.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx)
 
 	resp.Schema = s
@@ -71,6 +73,7 @@ func (d *dimensionsDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	// If any filter/pagination input is unknown, return unknown list
@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// explainDriftEnvVar enables drift explanations when the provider's
+// explain_drift is not set.
+const explainDriftEnvVar = "DOIT_EXPLAIN_DRIFT"
+
+// driftExplanations collects the fields of one Read whose state value differs
+// from the raw API value because the provider mapped an API normalization
+// back to the configured form: stripped "[... N/A]" sentinels, renamed alias
+// types and reformatted timestamps.
+//
+// The mapping is meant to be invisible, so when it fails to cover a case the
+// user sees a diff with no hint of where it comes from. With the provider's
+// explain_drift set, Read reports every mapping as a warning on the
+// attribute, showing both values.
+type driftExplanations struct {
+	diags diag.Diagnostics
+}
+
+type driftExplanationsKey struct{}
+
+// withDriftExplanations returns a context that collects drift explanations
+// into the returned driftExplanations.
+func withDriftExplanations(ctx context.Context) (context.Context, *driftExplanations) {
+	d := &driftExplanations{}
+	return context.WithValue(ctx, driftExplanationsKey{}, d), d
+}
+
+// diagnostics returns the collected explanations; d may be nil.
+func (d *driftExplanations) diagnostics() diag.Diagnostics {
+	if d == nil {
+		return nil
+	}
+	return d.diags
+}
+
+// explainDrift records that the API returned apiValue at p, which was mapped
+// to stateValue for reason. It does nothing unless ctx collects explanations.
+func explainDrift(ctx context.Context, p path.Path, apiValue, stateValue, reason string) {
+	d, ok := ctx.Value(driftExplanationsKey{}).(*driftExplanations)
+	if !ok {
+		return
+	}
+	d.diags.AddAttributeWarning(p,
+		"API Value Normalized",
+		fmt.Sprintf("The API returned %s, mapped to %s: %s. "+
+			"If this attribute shows a diff in plan, include this warning when reporting the issue. "+
+			"Unset explain_drift (or %s) to hide these warnings.", apiValue, stateValue, reason, explainDriftEnvVar),
+	)
+}
+
+// explainNormalizedType records an alias type or ID mapped back to the
+// configured form by normalizeDimensionsType.
+func explainNormalizedType(ctx context.Context, p path.Path, apiValue, stateValue string) {
+	if apiValue != stateValue {
+		explainDrift(ctx, p, fmt.Sprintf("%q", apiValue), fmt.Sprintf("%q", stateValue),
+			"the API renames this alias, so the configured name is kept")
+	}
+}
+
+// explainRestoredSentinels records the "[... N/A]" sentinels that
+// mergeSentinelValues restored at p, the values list of a filter, scope or
+// component, and a full-state fallback when the API returned no values.
+func explainRestoredSentinels(ctx context.Context, p path.Path, apiValues, merged []string, apiIncludeNull bool) {
+	var restored []string
+	sentinels := true
+	for _, v := range merged {
+		if !slices.Contains(apiValues, v) {
+			restored = append(restored, v)
+			sentinels = sentinels && isNAFallback(v)
+		}
+	}
+	if len(restored) == 0 {
+		return
+	}
+	reason := quoteList(restored) + " were not returned by the API, so the values are kept from state"
+	if sentinels && apiIncludeNull {
+		reason = "the API replaces " + quoteList(restored) + " with include_null = true, so they are kept from state"
+	}
+	explainDrift(ctx, p, fmt.Sprintf("values %s with include_null = %t", quoteList(apiValues), apiIncludeNull),
+		"values "+quoteList(merged), reason)
+}
+
+// explainPreservedTimestamp records a timestamp kept in its configured format
+// because the API returned the same instant formatted differently.
+func explainPreservedTimestamp(ctx context.Context, p path.Path, apiValue, stateValue string) {
+	if apiValue != stateValue {
+		explainDrift(ctx, p, fmt.Sprintf("%q", apiValue), fmt.Sprintf("%q", stateValue),
+			"both are the same instant, so the configured format is kept")
+	}
+}
+
+// quoteList formats values as an HCL list of strings.
+func quoteList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/doitintl/terraform-provider-doit/internal/provider/models"
+	"github.com/doitintl/terraform-provider-doit/internal/provider/resource_allocation"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// TestToAllocationRuleComponentsListValue_ExplainDrift verifies that mapping
+// a component the API normalized records one explanation per mapped field,
+// and none without a collecting context.
+func TestToAllocationRuleComponentsListValue_ExplainDrift(t *testing.T) {
+	ctx := context.Background()
+
+	existing := resource_allocation.NewComponentsValueMust(resource_allocation.ComponentsValue{}.AttributeTypes(ctx), map[string]attr.Value{
+		"case_insensitive":  types.BoolValue(false),
+		"include_null":      types.BoolValue(false),
+		"inverse":           types.BoolValue(false),
+		"inverse_selection": types.BoolValue(false),
+		"key":               types.StringValue("service_description"),
+		"mode":              types.StringValue("is"),
+		"type":              types.StringValue("allocation_rule"),
+		"values": types.ListValueMust(types.StringType, []attr.Value{
+			types.StringValue("Compute Engine"), types.StringValue("[Service N/A]"),
+		}),
+	})
+	components := []models.AllocationComponent{{
+		Key:         "service_description",
+		Mode:        "is",
+		Type:        "attribution",
+		IncludeNull: new(true),
+		Values:      []string{"Compute Engine"},
+	}}
+	componentsPath := path.Root("rule").AtName("components")
+
+	if _, diags := toAllocationRuleComponentsListValue(ctx, componentsPath, components, []resource_allocation.ComponentsValue{existing}); len(diags) != 0 {
+		t.Fatalf("unexpected diagnostics without explain_drift: %v", diags)
+	}
+
+	explainCtx, drift := withDriftExplanations(ctx)
+	if _, diags := toAllocationRuleComponentsListValue(explainCtx, componentsPath, components, []resource_allocation.ComponentsValue{existing}); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	want := []struct {
+		path   path.Path
+		detail string
+	}{
+		{componentsPath.AtListIndex(0).AtName("type"), `The API returned "attribution", mapped to "allocation_rule"`},
+		{componentsPath.AtListIndex(0).AtName("values"), `The API returned values ["Compute Engine"] with include_null = true, ` +
+			`mapped to values ["Compute Engine", "[Service N/A]"]: the API replaces ["[Service N/A]"] with include_null = true`},
+	}
+	got := drift.diagnostics()
+	if len(got) != len(want) || got.WarningsCount() != len(want) {
+		t.Fatalf("explanations = %v, want %d warnings", got, len(want))
+	}
+	for i, w := range want {
+		d, ok := got[i].(diag.DiagnosticWithPath)
+		if !ok || !d.Path().Equal(w.path) || !strings.HasPrefix(d.Detail(), w.detail) {
+			t.Errorf("explanation %d = %v, want at %s: %s", i, got[i], w.path, w.detail)
+		}
+	}
+}
+
+func TestExplainRestoredSentinels(t *testing.T) {
+	ctx, drift := withDriftExplanations(context.Background())
+	p := path.Root("values")
+
+	// Unchanged values and reordering are not explained.
+	explainRestoredSentinels(ctx, p, []string{"a", "b"}, []string{"b", "a"}, false)
+	if n := len(drift.diagnostics()); n != 0 {
+		t.Fatalf("got %d explanations for reordered values", n)
+	}
+
+	// The API returned nothing, so prior state was kept.
+	explainRestoredSentinels(ctx, p, nil, []string{"a"}, false)
+	got := drift.diagnostics()
+	if len(got) != 1 || !strings.Contains(got[0].Detail(), `["a"] were not returned by the API, so the values are kept from state`) {
+		t.Errorf("explanations = %v", got)
+	}
+}
+
+func TestExplainPreservedTimestamp(t *testing.T) {
+	ctx, drift := withDriftExplanations(context.Background())
+	p := path.Root("config").AtName("custom_time_range").AtName("from")
+
+	explainPreservedTimestamp(ctx, p, "2026-01-01T00:00:00Z", "2026-01-01T00:00:00Z")
+	explainPreservedTimestamp(ctx, p, "2026-01-01T00:00:00Z", "2026-01-01T01:00:00+01:00")
+	got := drift.diagnostics()
+	if len(got) != 1 || !strings.HasPrefix(got[0].Detail(), `The API returned "2026-01-01T00:00:00Z", mapped to "2026-01-01T01:00:00+01:00"`) {
+		t.Errorf("explanations = %v", got)
+	}
+}
@@ -0,0 +1,359 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/doitintl/terraform-provider-doit/internal/provider/models"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// exportTarget is one resource type written by Export.
+type exportTarget struct {
+	typeName    string
+	fileName    string
+	newResource func() resource.Resource
+	list        listObjectsFunc
+}
+
+// exportTargets lists the resource types Export walks, in output order.
+// Folders and labels come first because the other objects reference them.
+var exportTargets = []exportTarget{
+	{typeName: "doit_folder", fileName: "folders.tf", newResource: NewFolderResource, list: listFolders},
+	{typeName: "doit_label", fileName: "labels.tf", newResource: NewLabelResource, list: listLabels},
+	{typeName: "doit_allocation", fileName: "allocations.tf", newResource: NewAllocationResource, list: listAllocations},
+	{typeName: "doit_report", fileName: "reports.tf", newResource: NewReportResource, list: listReports},
+	{typeName: "doit_budget", fileName: "budgets.tf", newResource: NewBudgetResource, list: listBudgets},
+	{typeName: "doit_alert", fileName: "alerts.tf", newResource: NewAlertResource, list: listAlerts},
+}
+
+// Export writes Terraform configuration for every folder, label, allocation,
+// report, budget and alert visible to client into dir, one file per resource
+// type, each resource preceded by a matching import block.
+//
+// Each object goes through the resource's own ImportState and Read, so the
+// written attributes are exactly what `terraform import` would put in state.
+// Only configurable, non-deprecated attributes with a non-null value are
+// written, which is what lets the result plan without changes.
+//
+// Objects that fail to read are logged and skipped; the returned error joins
+// every such failure so the caller can report a partial export.
+func Export(ctx context.Context, client *models.ClientWithResponses, dir string) error {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, target := range exportTargets {
+		listCtx, cancel := context.WithTimeout(ctx, DefaultReadTimeout)
+		objects, err := target.list(listCtx, client, "")
+		cancel()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("listing %s: %w", target.typeName, err))
+			continue
+		}
+
+		file := hclwrite.NewEmptyFile()
+		used := map[string]int{}
+		written := 0
+		for _, object := range objects {
+			attrs, err := exportObject(ctx, target, client, object.ID)
+			if err != nil {
+				log.Printf("[WARN] Skipping %s %q (%s): %v", target.typeName, object.Name, object.ID, err)
+				errs = append(errs, fmt.Errorf("%s %s: %w", target.typeName, object.ID, err))
+				continue
+			}
+			if attrs == nil {
+				// Deleted between list and read.
+				continue
+			}
+			writeExportedResource(file.Body(), target.typeName, exportResourceName(object.Name, used), object.ID, attrs)
+			written++
+		}
+
+		if written == 0 {
+			continue
+		}
+		path := filepath.Join(dir, target.fileName)
+		if err := os.WriteFile(path, hclwrite.Format(file.Bytes()), 0o600); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		log.Printf("[INFO] Wrote %d %s objects to %s", written, target.typeName, path)
+	}
+
+	return errors.Join(errs...)
+}
+
+// exportedAttribute is one top-level attribute to write, in schema order.
+type exportedAttribute struct {
+	name  string
+	value cty.Value
+}
+
+// exportObject imports and reads one object, returning the attributes to
+// write, or nil if the object no longer exists.
+func exportObject(ctx context.Context, target exportTarget, client *models.ClientWithResponses, id string) ([]exportedAttribute, error) {
+	r := target.newResource()
+	if rc, ok := r.(resource.ResourceWithConfigure); ok {
+		configureResp := &resource.ConfigureResponse{}
+		rc.Configure(ctx, resource.ConfigureRequest{ProviderData: &providerData{client: client}}, configureResp)
+		if configureResp.Diagnostics.HasError() {
+			return nil, diagnosticsError(configureResp.Diagnostics)
+		}
+	}
+	importer, ok := r.(resource.ResourceWithImportState)
+	if !ok {
+		return nil, fmt.Errorf("%s does not support import", target.typeName)
+	}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		return nil, diagnosticsError(schemaResp.Diagnostics)
+	}
+	s := schemaResp.Schema
+
+	importResp := &resource.ImportStateResponse{
+		State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)},
+	}
+	importer.ImportState(ctx, resource.ImportStateRequest{ID: id}, importResp)
+	if importResp.Diagnostics.HasError() {
+		return nil, diagnosticsError(importResp.Diagnostics)
+	}
+
+	readResp := &resource.ReadResponse{State: importResp.State}
+	r.Read(ctx, resource.ReadRequest{State: importResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		return nil, diagnosticsError(readResp.Diagnostics)
+	}
+	if readResp.State.Raw.IsNull() {
+		return nil, nil
+	}
+
+	var values map[string]tftypes.Value
+	if err := readResp.State.Raw.As(&values); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(s.Attributes))
+	for name := range s.Attributes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	attrs := []exportedAttribute{}
+	for _, name := range names {
+		a := s.Attributes[name]
+		if !isExportable(a) {
+			continue
+		}
+		v, err := exportValue(values[name], nestedAttributes(a))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if v.IsNull() {
+			continue
+		}
+		attrs = append(attrs, exportedAttribute{name: name, value: v})
+	}
+	return attrs, nil
+}
+
+// isExportable reports whether an attribute belongs in generated
+// configuration: it must be settable, and not deprecated in favor of another
+// attribute that carries the same value (e.g. report config.metric).
+func isExportable(a schema.Attribute) bool {
+	return (a.IsRequired() || a.IsOptional()) && a.GetDeprecationMessage() == ""
+}
+
+// nestedAttributes returns the attributes of a nested attribute's object, or
+// nil for attributes that are not nested.
+func nestedAttributes(a schema.Attribute) map[string]schema.Attribute {
+	switch a := a.(type) {
+	case schema.SingleNestedAttribute:
+		return a.Attributes
+	case schema.ListNestedAttribute:
+		return a.NestedObject.Attributes
+	case schema.SetNestedAttribute:
+		return a.NestedObject.Attributes
+	case schema.MapNestedAttribute:
+		return a.NestedObject.Attributes
+	}
+	return nil
+}
+
+// exportValue converts a state value to its configuration form. Objects
+// described by attrs are pruned to their exportable attributes; null and
+// unknown values convert to cty.NilVal so callers can omit them.
+//
+// Collections convert to tuples and maps to objects, the shapes HCL literals
+// take in configuration; Terraform converts them back against the schema.
+func exportValue(v tftypes.Value, attrs map[string]schema.Attribute) (cty.Value, error) {
+	if v.IsNull() || !v.IsKnown() {
+		return cty.NilVal, nil
+	}
+
+	typ := v.Type()
+	switch {
+	case typ.Is(tftypes.String):
+		var s string
+		if err := v.As(&s); err != nil {
+			return cty.NilVal, err
+		}
+		return cty.StringVal(s), nil
+
+	case typ.Is(tftypes.Number):
+		f := new(big.Float)
+		if err := v.As(&f); err != nil {
+			return cty.NilVal, err
+		}
+		return cty.NumberVal(f), nil
+
+	case typ.Is(tftypes.Bool):
+		var b bool
+		if err := v.As(&b); err != nil {
+			return cty.NilVal, err
+		}
+		return cty.BoolVal(b), nil
+
+	case typ.Is(tftypes.List{}), typ.Is(tftypes.Set{}), typ.Is(tftypes.Tuple{}):
+		var elems []tftypes.Value
+		if err := v.As(&elems); err != nil {
+			return cty.NilVal, err
+		}
+		if len(elems) == 0 {
+			return cty.EmptyTupleVal, nil
+		}
+		out := make([]cty.Value, 0, len(elems))
+		for _, elem := range elems {
+			ev, err := exportValue(elem, attrs)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			if ev.IsNull() {
+				ev = cty.NullVal(cty.DynamicPseudoType)
+			}
+			out = append(out, ev)
+		}
+		return cty.TupleVal(out), nil
+
+	case typ.Is(tftypes.Map{}):
+		var elems map[string]tftypes.Value
+		if err := v.As(&elems); err != nil {
+			return cty.NilVal, err
+		}
+		out := make(map[string]cty.Value, len(elems))
+		for key, elem := range elems {
+			ev, err := exportValue(elem, attrs)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			if !ev.IsNull() {
+				out[key] = ev
+			}
+		}
+		if len(out) == 0 {
+			return cty.EmptyObjectVal, nil
+		}
+		return cty.ObjectVal(out), nil
+
+	case typ.Is(tftypes.Object{}):
+		var fields map[string]tftypes.Value
+		if err := v.As(&fields); err != nil {
+			return cty.NilVal, err
+		}
+		out := make(map[string]cty.Value, len(fields))
+		for name, field := range fields {
+			var nested map[string]schema.Attribute
+			if attrs != nil {
+				a, ok := attrs[name]
+				if !ok || !isExportable(a) {
+					continue
+				}
+				nested = nestedAttributes(a)
+			}
+			fv, err := exportValue(field, nested)
+			if err != nil {
+				return cty.NilVal, fmt.Errorf("%s: %w", name, err)
+			}
+			if !fv.IsNull() {
+				out[name] = fv
+			}
+		}
+		if len(out) == 0 {
+			return cty.EmptyObjectVal, nil
+		}
+		return cty.ObjectVal(out), nil
+	}
+
+	return cty.NilVal, fmt.Errorf("unsupported value type %s", typ)
+}
+
+// writeExportedResource appends an import block and its resource block.
+func writeExportedResource(body *hclwrite.Body, typeName, name, id string, attrs []exportedAttribute) {
+	if len(body.Blocks()) > 0 {
+		body.AppendNewline()
+	}
+
+	importBody := body.AppendNewBlock("import", nil).Body()
+	importBody.SetAttributeTraversal("to", hcl.Traversal{
+		hcl.TraverseRoot{Name: typeName},
+		hcl.TraverseAttr{Name: name},
+	})
+	importBody.SetAttributeValue("id", cty.StringVal(id))
+	body.AppendNewline()
+
+	resourceBody := body.AppendNewBlock("resource", []string{typeName, name}).Body()
+	for _, a := range attrs {
+		resourceBody.SetAttributeValue(a.name, a.value)
+	}
+}
+
+var nonIdentifierChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// exportResourceName derives a resource address name from an object's display
+// name, e.g. "Monthly Spend (EU)" becomes "monthly_spend_eu". Names are made
+// unique per resource type by suffixing repeats with _2, _3, and so on.
+func exportResourceName(displayName string, used map[string]int) string {
+	name := strings.Trim(nonIdentifierChars.ReplaceAllString(strings.ToLower(displayName), "_"), "_")
+	if name == "" {
+		name = "unnamed"
+	}
+	if name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+
+	used[name]++
+	if n := used[name]; n > 1 {
+		// The suffixed name may itself collide with a real display name.
+		return exportResourceName(name+"_"+strconv.Itoa(n), used)
+	}
+	return name
+}
+
+// diagnosticsError flattens error diagnostics into an error for callers
+// outside the framework's request/response flow.
+func diagnosticsError(diags diag.Diagnostics) error {
+	var msgs []string
+	for _, d := range diags.Errors() {
+		msgs = append(msgs, d.Summary()+": "+d.Detail())
+	}
+	return errors.New(strings.Join(msgs, "; "))
+}
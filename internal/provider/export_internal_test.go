@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/doitintl/terraform-provider-doit/internal/provider/models"
+)
+
+// TestExport_WritesImportAndResourceBlocks runs Export against a tenant with
+// two folders sharing a name and one label, and checks the generated
+// configuration: import blocks, de-duplicated addresses, and only
+// configurable attributes.
+func TestExport_WritesImportAndResourceBlocks(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/analytics/v1/folders":
+			_, _ = fmt.Fprint(w, `{"folders": [
+				{"id": "f1", "name": "Team Finance"},
+				{"id": "f2", "name": "Team Finance"}
+			]}`)
+		case "/analytics/v1/folders/f1", "/analytics/v1/folders/f2":
+			id := strings.TrimPrefix(r.URL.Path, "/analytics/v1/folders/")
+			_, _ = fmt.Fprintf(w, `{"id": %q, "name": "Team Finance", "description": "Costs", "parentFolderId": "root"}`, id)
+		case "/analytics/v1/labels":
+			_, _ = fmt.Fprint(w, `{"labels": [{"id": "l1", "name": "prod", "color": "blue"}]}`)
+		case "/analytics/v1/labels/l1":
+			_, _ = fmt.Fprint(w, `{"id": "l1", "name": "prod", "color": "blue", "type": "custom", "createTime": "2026-01-02T03:04:05Z"}`)
+		default:
+			// Every other list is empty.
+			_, _ = fmt.Fprint(w, `{}`)
+		}
+	}))
+	defer server.Close()
+
+	client, err := models.NewClientWithResponses(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	dir := t.TempDir()
+	if err := Export(context.Background(), client, dir); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	folders, err := os.ReadFile(filepath.Join(dir, "folders.tf"))
+	if err != nil {
+		t.Fatalf("reading folders.tf: %v", err)
+	}
+	for _, want := range []string{
+		"to = doit_folder.team_finance\n",
+		"to = doit_folder.team_finance_2\n",
+		`resource "doit_folder" "team_finance_2" {`,
+		`id = "f2"`,
+		`description      = "Costs"`,
+		`parent_folder_id = "root"`,
+	} {
+		if !strings.Contains(string(folders), want) {
+			t.Errorf("folders.tf does not contain %q:\n%s", want, folders)
+		}
+	}
+
+	labels, err := os.ReadFile(filepath.Join(dir, "labels.tf"))
+	if err != nil {
+		t.Fatalf("reading labels.tf: %v", err)
+	}
+	if !strings.Contains(string(labels), `color = "blue"`) {
+		t.Errorf("labels.tf does not contain color:\n%s", labels)
+	}
+	// Computed-only attributes must not be written: Terraform rejects them in
+	// configuration.
+	for _, unwanted := range []string{"create_time", "type ", "timeouts"} {
+		if strings.Contains(string(labels), unwanted) {
+			t.Errorf("labels.tf contains computed-only attribute %q:\n%s", unwanted, labels)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "reports.tf")); !os.IsNotExist(err) {
+		t.Errorf("reports.tf should not be written for an empty list, stat error = %v", err)
+	}
+}
+
+func TestExportResourceName(t *testing.T) {
+	t.Parallel()
+
+	used := map[string]int{}
+	tests := []struct {
+		displayName string
+		want        string
+	}{
+		{"Monthly Spend (EU)", "monthly_spend_eu"},
+		{"Monthly Spend (EU)", "monthly_spend_eu_2"},
+		{"monthly_spend_eu_2", "monthly_spend_eu_2_2"},
+		{"2026 Budget", "_2026_budget"},
+		{"***", "unnamed"},
+	}
+	for _, tt := range tests {
+		if got := exportResourceName(tt.displayName, used); got != tt.want {
+			t.Errorf("exportResourceName(%q) = %q, want %q", tt.displayName, got, tt.want)
+		}
+	}
+}
@@ -15,9 +15,7 @@ import (
 func (r *folderResource) populateState(ctx context.Context, state *folderResourceModel) diag.Diagnostics {
 	folderResp, err := r.client.GetFolderWithResponse(ctx, state.Id.ValueString())
 	if err != nil {
-		return diag.Diagnostics{
-			diag.NewErrorDiagnostic("Error Reading Folder", "Could not read folder ID "+state.Id.ValueString()+": "+err.Error()),
-		}
+		return apiErrorDiagnostics("Error Reading Folder", "Could not read folder ID "+state.Id.ValueString(), err)
 	}
 
 	if folderResp.StatusCode() == 404 {
@@ -24,7 +24,8 @@ type (
 	}
 	folderDataSourceModel struct {
 		datasource_folder.FolderModel
-		Timeouts timeouts.Value `tfsdk:"timeouts"`
+		CustomerContext types.String   `tfsdk:"customer_context"`
+		Timeouts        timeouts.Value `tfsdk:"timeouts"`
 	}
 )
 
@@ -38,6 +39,7 @@ func (d *folderDataSource) Schema(ctx context.Context, _ datasource.SchemaReques
 	s.MarkdownDescription = "Retrieve a Cloud Analytics folder by its ID."
 	s.Description = "Retrieve a Cloud Analytics folder by its ID."
 
+	s.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx)
 
 	resp.Schema = s
@@ -74,6 +76,7 @@ func (d *folderDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	// If ID is unknown (depends on a resource not yet created), set all computed
@@ -8,19 +8,27 @@ import (
 	"github.com/doitintl/terraform-provider-doit/internal/provider/resource_folder"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 type (
 	folderResource struct {
-		client *models.ClientWithResponses
+		client             *models.ClientWithResponses
+		readOnly           bool
+		deletionProtection bool
+		validateReferences bool
 	}
 	folderResourceModel struct {
 		resource_folder.FolderModel
-		Timeouts timeouts.Value `tfsdk:"timeouts"`
+		DeletionProtection types.Bool     `tfsdk:"deletion_protection"`
+		AdoptExisting      types.Bool     `tfsdk:"adopt_existing"`
+		CustomerContext    types.String   `tfsdk:"customer_context"`
+		Timeouts           timeouts.Value `tfsdk:"timeouts"`
 	}
 )
 
@@ -29,6 +37,7 @@ var (
 	_ resource.Resource                = (*folderResource)(nil)
 	_ resource.ResourceWithConfigure   = (*folderResource)(nil)
 	_ resource.ResourceWithImportState = (*folderResource)(nil)
+	_ resource.ResourceWithModifyPlan  = (*folderResource)(nil)
 )
 
 // NewFolderResource creates a new folder resource instance.
@@ -42,16 +51,29 @@ func (r *folderResource) Configure(_ context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*models.ClientWithResponses)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *models.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.readOnly = data.readOnly
+	r.deletionProtection = data.deletionProtection
+	r.validateReferences = data.validateReferences
+}
+
+// ModifyPlan checks that the planned parent_folder_id exists unless the
+// provider's skip_reference_validation is set.
+func (r *folderResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if !r.validateReferences {
+		return
+	}
+	resp.Diagnostics.Append(validateReferences(ctx, r.client, req.Plan, req.State,
+		referenceCheck{expr: path.MatchRoot("parent_folder_id"), kind: "folder"})...)
 }
 
 func (r *folderResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -59,7 +81,12 @@ func (r *folderResource) Metadata(_ context.Context, req resource.MetadataReques
 }
 
 func (r *folderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	ctx, id, diags := importCustomerContext(ctx, req, resp, 1)
+	resp.Diagnostics.Append(diags...)
+	req.ID = id
+
+	// Accepts either an ID or "name:<exact name>".
+	importStatePassthroughIDOrName(ctx, req, resp, "folder", nameLookup(r.client, listFolders))
 }
 
 func (r *folderResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -87,6 +114,10 @@ func (r *folderResource) Schema(ctx context.Context, _ resource.SchemaRequest, r
 		s.Attributes["description"] = attr
 	}
 
+	s.Attributes["deletion_protection"] = deletionProtectionAttribute()
+
+	s.Attributes["adopt_existing"] = adoptExistingAttribute("folder", "the same name in the same parent folder (`parent_folder_id`, or the root folder when unset)")
+	s.Attributes["customer_context"] = customerContextResourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx, timeouts.Opts{
 		Create: true,
 		Read:   true,
@@ -98,6 +129,11 @@ func (r *folderResource) Schema(ctx context.Context, _ resource.SchemaRequest, r
 }
 
 func (r *folderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_folder", "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan folderResourceModel
 
 	// Read Terraform plan data into the model
@@ -112,18 +148,36 @@ func (r *folderResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	ctx = withCustomerContext(ctx, plan.CustomerContext)
 	defer cancel()
 
+	if plan.AdoptExisting.ValueBool() {
+		existingID, adoptDiags := findAdoptable(ctx, "folder", plan.Name.ValueString(), folderLookup(r.client, plan.ParentFolderId))
+		resp.Diagnostics.Append(adoptDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if existingID != "" {
+			updated, updateDiags := r.update(ctx, existingID, &plan)
+			resp.Diagnostics.Append(updateDiags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			// Plan-first state pattern: overlay Computed-only fields from API response.
+			overlayFolderComputedFields(updated, &plan)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+			return
+		}
+	}
+
 	// Build API request
 	apiReq := plan.toCreateRequest()
 
 	// Create new folder via API
 	folderResp, err := r.client.CreateFolderWithResponse(ctx, apiReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Creating Folder",
-			"Could not create folder, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Creating Folder", "Could not create folder", err)...)
 		return
 	}
 
@@ -165,6 +219,7 @@ func (r *folderResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
 
 	diags = r.populateState(ctx, &state)
@@ -184,6 +239,11 @@ func (r *folderResource) Read(ctx context.Context, req resource.ReadRequest, res
 }
 
 func (r *folderResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_folder", "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan folderResourceModel
 
 	// Read Terraform plan data
@@ -198,6 +258,7 @@ func (r *folderResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	ctx = withCustomerContext(ctx, plan.CustomerContext)
 	defer cancel()
 
 	// Get the ID from the state
@@ -208,43 +269,60 @@ func (r *folderResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 	folderID := state.Id.ValueString()
 
+	updated, updateDiags := r.update(ctx, folderID, &plan)
+	resp.Diagnostics.Append(updateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Plan-first state pattern: overlay Computed-only fields from API response.
+	overlayFolderComputedFields(updated, &plan)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// update sends plan as an update of the folder with ID folderID and returns
+// the updated object, for Update and for Create when adopt_existing takes over
+// an existing folder.
+func (r *folderResource) update(ctx context.Context, folderID string, plan *folderResourceModel) (*models.Folder, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
 	// Build API request
 	apiReq := plan.toUpdateRequest()
 
 	// Update folder via API
 	updateResp, err := r.client.UpdateFolderWithResponse(ctx, folderID, apiReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Updating Folder",
-			"Could not update folder, unexpected error: "+err.Error(),
-		)
-		return
+		diags.Append(apiErrorDiagnostics("Error Updating Folder", "Could not update folder", err)...)
+		return nil, diags
 	}
 
 	if updateResp.StatusCode() != 200 {
-		resp.Diagnostics.AddError(
+		diags.AddError(
 			"Error Updating Folder",
 			fmt.Sprintf("Could not update folder, status: %d, body: %s", updateResp.StatusCode(), string(updateResp.Body)),
 		)
-		return
+		return nil, diags
 	}
 
 	if updateResp.JSON200 == nil {
-		resp.Diagnostics.AddError(
+		diags.AddError(
 			"Error Updating Folder",
 			"Received empty response body",
 		)
-		return
+		return nil, diags
 	}
 
-	// Plan-first state pattern: overlay Computed-only fields from API response.
-	overlayFolderComputedFields(updateResp.JSON200, &plan)
-
-	// Save updated data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	return updateResp.JSON200, diags
 }
 
 func (r *folderResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_folder", "delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var state folderResourceModel
 
 	// Read Terraform prior state data into the model
@@ -253,21 +331,24 @@ func (r *folderResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	resp.Diagnostics.Append(checkDeletionProtection(state.DeletionProtection, r.deletionProtection, "folder", state.Id.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	deleteTimeout, diags := state.Timeouts.Delete(ctx, DefaultDeleteTimeout)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
 
 	// Delete folder via API
 	deleteResp, err := r.client.DeleteFolderWithResponse(ctx, state.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Deleting Folder",
-			"Could not delete folder, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Deleting Folder", "Could not delete folder", err)...)
 		return
 	}
 
@@ -25,7 +25,8 @@ type foldersDataSource struct {
 
 type foldersDataSourceModel struct {
 	datasource_folders.FoldersModel
-	Timeouts timeouts.Value `tfsdk:"timeouts"`
+	CustomerContext types.String   `tfsdk:"customer_context"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (d *foldersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -38,6 +39,7 @@ func (d *foldersDataSource) Schema(ctx context.Context, _ datasource.SchemaReque
 	s.MarkdownDescription = "List Cloud Analytics folders."
 	s.Description = "List Cloud Analytics folders."
 
+	s.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx)
 
 	resp.Schema = s
@@ -74,6 +76,7 @@ func (d *foldersDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	// If any pagination input is unknown, return unknown for all computed attributes
@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/doitintl/terraform-provider-doit/internal/provider/models"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// importByNamePrefix marks an import ID as an object name rather than an
+// opaque API ID, e.g. `terraform import doit_report.r "name:Monthly Spend"`.
+const importByNamePrefix = "name:"
+
+// nameLookupFunc returns the IDs of every object whose name exactly matches
+// name. An empty result is not an error; the caller reports it.
+type nameLookupFunc func(ctx context.Context, name string) ([]string, error)
+
+// importStatePassthroughIDOrName imports by ID like
+// resource.ImportStatePassthroughID, but also accepts "name:<exact name>",
+// which is resolved to an ID through lookup before Read runs.
+//
+// kind is the human-readable object type used in diagnostics ("report",
+// "budget", ...).
+func importStatePassthroughIDOrName(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse, kind string, lookup nameLookupFunc) {
+	name, ok := strings.CutPrefix(req.ID, importByNamePrefix)
+	if !ok {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	if name == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Import ID %q has an empty name. Use \"%s<exact %s name>\", or import by ID.", req.ID, importByNamePrefix, kind),
+		)
+		return
+	}
+
+	// Import has no timeouts {} block of its own; bound the lookup like a Read.
+	ctx, cancel := context.WithTimeout(ctx, DefaultReadTimeout)
+	defer cancel()
+
+	ids, err := lookup(ctx, name)
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Importing by Name", fmt.Sprintf("Could not look up %s named %q", kind, name), err)...)
+		return
+	}
+
+	switch len(ids) {
+	case 0:
+		resp.Diagnostics.AddError(
+			"Import Name Not Found",
+			fmt.Sprintf("No %s is named exactly %q. Names are case-sensitive; check the name in the DoiT console, or import by ID.", kind, name),
+		)
+		return
+	case 1:
+	default:
+		resp.Diagnostics.AddError(
+			"Import Name Is Ambiguous",
+			fmt.Sprintf("%d objects of type %s are named %q (IDs: %s). Import one of them by ID instead.", len(ids), kind, name, strings.Join(ids, ", ")),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Resolved import name to ID", map[string]any{
+		"kind": kind,
+		"name": name,
+		"id":   ids[0],
+	})
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), ids[0])...)
+}
+
+// exactNameMatches returns the IDs of the objects named exactly want. The
+// list endpoints' nameContains filter is a case-insensitive substring match,
+// so every lookup narrows its results with this.
+func exactNameMatches(objects []namedObject, want string) []string {
+	var ids []string
+	for _, o := range objects {
+		if o.Name == want {
+			ids = append(ids, o.ID)
+		}
+	}
+	return ids
+}
+
+// nameLookup adapts a list function to a nameLookupFunc.
+func nameLookup(client *models.ClientWithResponses, list listObjectsFunc) nameLookupFunc {
+	return func(ctx context.Context, name string) ([]string, error) {
+		objects, err := list(ctx, client, name)
+		if err != nil {
+			return nil, err
+		}
+		return exactNameMatches(objects, name), nil
+	}
+}
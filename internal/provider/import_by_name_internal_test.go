@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/doitintl/terraform-provider-doit/internal/provider/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// runImportState calls ImportState on r with an empty state built from its
+// schema, mirroring what the framework does before the follow-up Read.
+func runImportState(t *testing.T, r resource.ResourceWithImportState, id string) *resource.ImportStateResponse {
+	t.Helper()
+	ctx := context.Background()
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("Failed to get schema: %v", schemaResp.Diagnostics)
+	}
+
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: id}, resp)
+	return resp
+}
+
+func importedID(t *testing.T, resp *resource.ImportStateResponse) string {
+	t.Helper()
+	var id types.String
+	if diags := resp.State.GetAttribute(context.Background(), path.Root("id"), &id); diags.HasError() {
+		t.Fatalf("failed to read id from state: %v", diags)
+	}
+	return id.ValueString()
+}
+
+func newImportTestClient(t *testing.T, handler http.HandlerFunc) *models.ClientWithResponses {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	client, err := models.NewClientWithResponses(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	return client
+}
+
+func TestImportStateByName_PassthroughID(t *testing.T) {
+	t.Parallel()
+
+	// No server: an ID import must not call the API.
+	r := &reportResource{}
+	resp := runImportState(t, r, "abc123")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if got := importedID(t, resp); got != "abc123" {
+		t.Errorf("id = %q, want %q", got, "abc123")
+	}
+}
+
+func TestImportStateByName_Report(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		importID      string
+		body          string
+		wantID        string
+		errorContains string
+	}{
+		{
+			name:     "exact match among substring matches",
+			importID: "name:Monthly Spend",
+			body: `{"reports": [
+				{"id": "r1", "reportName": "Monthly Spend (old)"},
+				{"id": "r2", "reportName": "Monthly Spend"},
+				{"id": "r3", "reportName": "monthly spend"}
+			]}`,
+			wantID: "r2",
+		},
+		{
+			name:          "no match",
+			importID:      "name:Monthly Spend",
+			body:          `{"reports": [{"id": "r1", "reportName": "Monthly Spend (old)"}]}`,
+			errorContains: "No report is named exactly",
+		},
+		{
+			name:     "ambiguous",
+			importID: "name:Monthly Spend",
+			body: `{"reports": [
+				{"id": "r1", "reportName": "Monthly Spend"},
+				{"id": "r2", "reportName": "Monthly Spend"}
+			]}`,
+			errorContains: "r1, r2",
+		},
+		{
+			name:          "empty name",
+			importID:      "name:",
+			errorContains: "empty name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := newImportTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if got := r.URL.Query().Get("nameContains"); got != "Monthly Spend" {
+					t.Errorf("nameContains = %q, want %q", got, "Monthly Spend")
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = fmt.Fprint(w, tt.body)
+			})
+
+			resp := runImportState(t, &reportResource{client: client}, tt.importID)
+
+			if tt.errorContains != "" {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected an error, got none")
+				}
+				if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, tt.errorContains) {
+					t.Errorf("error detail %q does not contain %q", detail, tt.errorContains)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			if got := importedID(t, resp); got != tt.wantID {
+				t.Errorf("id = %q, want %q", got, tt.wantID)
+			}
+		})
+	}
+}
+
+// TestImportStateByName_FolderPaginates covers the client-side-only lookup:
+// the folders endpoint has no name filter, so every page must be walked.
+func TestImportStateByName_FolderPaginates(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+	client := newImportTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("pageToken") == "" {
+			_, _ = fmt.Fprint(w, `{"folders": [{"id": "f1", "name": "Finance"}], "pageToken": "next"}`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"folders": [{"id": "f2", "name": "Engineering"}]}`)
+	})
+
+	resp := runImportState(t, &folderResource{client: client}, "name:Engineering")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if got := importedID(t, resp); got != "f2" {
+		t.Errorf("id = %q, want %q", got, "f2")
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 list calls, got %d", calls)
+	}
+}
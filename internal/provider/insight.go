@@ -26,9 +26,7 @@ func (r *insightResource) populateState(ctx context.Context, state *insightResou
 
 	getResp, err := r.client.GetInsightResultWithResponse(ctx, sourceID, insightKey)
 	if err != nil {
-		return diag.Diagnostics{
-			diag.NewErrorDiagnostic("Error Reading Insight", fmt.Sprintf("Could not read insight %s/%s: %s", sourceID, insightKey, err.Error())),
-		}
+		return apiErrorDiagnostics("Error Reading Insight", fmt.Sprintf("Could not read insight %s/%s", sourceID, insightKey), err)
 	}
 
 	if getResp.StatusCode() == 404 {
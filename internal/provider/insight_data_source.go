@@ -27,7 +27,8 @@ type insightDataSource struct {
 
 type insightDataSourceModel struct {
 	datasource_insight.InsightModel
-	Timeouts timeouts.Value `tfsdk:"timeouts"`
+	CustomerContext types.String   `tfsdk:"customer_context"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (ds *insightDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -51,6 +52,7 @@ func (ds *insightDataSource) Configure(_ context.Context, req datasource.Configu
 
 func (ds *insightDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	s := datasource_insight.InsightDataSourceSchema(ctx)
+	s.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx)
 	resp.Schema = s
 }
@@ -68,6 +70,7 @@ func (ds *insightDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	// If any required input is unknown (depends on a resource not yet created),
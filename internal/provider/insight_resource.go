@@ -21,11 +21,13 @@ import (
 
 type (
 	insightResource struct {
-		client *models.ClientWithResponses
+		client   *models.ClientWithResponses
+		readOnly bool
 	}
 	insightResourceModel struct {
 		resource_insight.InsightModel
-		Timeouts timeouts.Value `tfsdk:"timeouts"`
+		CustomerContext types.String   `tfsdk:"customer_context"`
+		Timeouts        timeouts.Value `tfsdk:"timeouts"`
 	}
 )
 
@@ -47,16 +49,17 @@ func (r *insightResource) Configure(_ context.Context, req resource.ConfigureReq
 		return
 	}
 
-	client, ok := req.ProviderData.(*models.ClientWithResponses)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *models.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.readOnly = data.readOnly
 }
 
 func (r *insightResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -64,12 +67,16 @@ func (r *insightResource) Metadata(_ context.Context, req resource.MetadataReque
 }
 
 func (r *insightResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, id, diags := importCustomerContext(ctx, req, resp, 2)
+	resp.Diagnostics.Append(diags...)
+	req.ID = id
+
 	// Import ID format: sourceID/insightKey
 	parts := strings.SplitN(req.ID, "/", 2)
 	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
 		resp.Diagnostics.AddError(
 			"Unexpected Import Identifier",
-			fmt.Sprintf("Expected import identifier with format: sourceID/insightKey. Got: %q", req.ID),
+			fmt.Sprintf("Expected import identifier with format: sourceID/insightKey, optionally prefixed with customerContext/. Got: %q", req.ID),
 		)
 		return
 	}
@@ -148,6 +155,7 @@ func (r *insightResource) Schema(ctx context.Context, _ resource.SchemaRequest,
 		s.Attributes["dismissal_details"] = ddAttr
 	}
 
+	s.Attributes["customer_context"] = customerContextResourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx, timeouts.Opts{
 		Create: true,
 		Read:   true,
@@ -293,6 +301,11 @@ func overlayInsightDismissalDetails(resolved, plan *resource_insight.DismissalDe
 }
 
 func (r *insightResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_insight", "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan insightResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -306,6 +319,7 @@ func (r *insightResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	ctx = withCustomerContext(ctx, plan.CustomerContext)
 	defer cancel()
 
 	apiReq, buildDiags := plan.toInsightRequest(ctx)
@@ -320,10 +334,7 @@ func (r *insightResource) Create(ctx context.Context, req resource.CreateRequest
 
 	createResp, err := r.client.PostInsightResultWithResponse(ctx, sourceID, insightKey, *apiReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Creating Insight",
-			"Could not create insight, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Creating Insight", "Could not create insight", err)...)
 		return
 	}
 
@@ -366,6 +377,7 @@ func (r *insightResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
 
 	diags := r.populateState(ctx, &state)
@@ -384,6 +396,11 @@ func (r *insightResource) Read(ctx context.Context, req resource.ReadRequest, re
 }
 
 func (r *insightResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_insight", "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan insightResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -397,6 +414,7 @@ func (r *insightResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	ctx = withCustomerContext(ctx, plan.CustomerContext)
 	defer cancel()
 
 	apiReq, buildDiags := plan.toInsightRequest(ctx)
@@ -413,10 +431,7 @@ func (r *insightResource) Update(ctx context.Context, req resource.UpdateRequest
 
 	updateResp, err := r.client.PostInsightResultWithResponse(ctx, sourceID, insightKey, *apiReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Updating Insight",
-			"Could not update insight, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Updating Insight", "Could not update insight", err)...)
 		return
 	}
 
@@ -446,6 +461,11 @@ func (r *insightResource) Update(ctx context.Context, req resource.UpdateRequest
 }
 
 func (r *insightResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_insight", "delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var state insightResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -459,6 +479,7 @@ func (r *insightResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
 
 	sourceID := models.DeleteInsightResultParamsSourceID(state.SourceId.ValueString())
@@ -469,10 +490,7 @@ func (r *insightResource) Delete(ctx context.Context, req resource.DeleteRequest
 
 	deleteResp, err := r.client.DeleteInsightResultWithResponse(ctx, sourceID, insightKey)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Deleting Insight",
-			"Could not delete insight, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Deleting Insight", "Could not delete insight", err)...)
 		return
 	}
 
@@ -27,7 +27,8 @@ type insightResourceResultsDataSource struct {
 
 type insightResourceResultsDataSourceModel struct {
 	datasource_insight_resource_results.InsightResourceResultsModel
-	Timeouts timeouts.Value `tfsdk:"timeouts"`
+	CustomerContext types.String   `tfsdk:"customer_context"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (ds *insightResourceResultsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -51,6 +52,7 @@ func (ds *insightResourceResultsDataSource) Configure(_ context.Context, req dat
 
 func (ds *insightResourceResultsDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	s := datasource_insight_resource_results.InsightResourceResultsDataSourceSchema(ctx)
+	s.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx)
 	resp.Schema = s
 }
@@ -68,6 +70,7 @@ func (ds *insightResourceResultsDataSource) Read(ctx context.Context, req dataso
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	// Guard against any unknown inputs (source_id, insight_key, max_results,
@@ -24,12 +24,14 @@ import (
 
 type (
 	insightResourceResultsResource struct {
-		client *models.ClientWithResponses
+		client   *models.ClientWithResponses
+		readOnly bool
 	}
 	insightResourceResultsModel struct {
 		InsightKey      types.String   `tfsdk:"insight_key"`
 		ResourceResults types.List     `tfsdk:"resource_results"`
 		SourceId        types.String   `tfsdk:"source_id"`
+		CustomerContext types.String   `tfsdk:"customer_context"`
 		Timeouts        timeouts.Value `tfsdk:"timeouts"`
 	}
 )
@@ -48,15 +50,16 @@ func (r *insightResourceResultsResource) Configure(_ context.Context, req resour
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*models.ClientWithResponses)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *models.ClientWithResponses, got: %T.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
 		)
 		return
 	}
-	r.client = client
+	r.client = data.client
+	r.readOnly = data.readOnly
 }
 
 func (r *insightResourceResultsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -64,11 +67,15 @@ func (r *insightResourceResultsResource) Metadata(_ context.Context, req resourc
 }
 
 func (r *insightResourceResultsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, id, diags := importCustomerContext(ctx, req, resp, 2)
+	resp.Diagnostics.Append(diags...)
+	req.ID = id
+
 	parts := strings.SplitN(req.ID, "/", 2)
 	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
 		resp.Diagnostics.AddError(
 			"Unexpected Import Identifier",
-			fmt.Sprintf("Expected import identifier with format: sourceID/insightKey. Got: %q", req.ID),
+			fmt.Sprintf("Expected import identifier with format: sourceID/insightKey, optionally prefixed with customerContext/. Got: %q", req.ID),
 		)
 		return
 	}
@@ -85,6 +92,7 @@ func (r *insightResourceResultsResource) Schema(ctx context.Context, _ resource.
 	delete(s.Attributes, "page_token")
 	delete(s.Attributes, "row_count")
 
+	s.Attributes["customer_context"] = customerContextResourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx, timeouts.Opts{
 		Create: true,
 		Read:   true,
@@ -648,10 +656,7 @@ func fetchAllRRResults(ctx context.Context, client *models.ClientWithResponses,
 
 		resp, err := client.GetInsightResourceResultsWithResponse(ctx, sourceID, insightKey, params)
 		if err != nil {
-			diags.AddError(
-				"Error Reading Insight Resource Results",
-				fmt.Sprintf("Could not read resource results for %s/%s: %s", sourceID, insightKey, err.Error()),
-			)
+			diags.Append(apiErrorDiagnostics("Error Reading Insight Resource Results", fmt.Sprintf("Could not read resource results for %s/%s", sourceID, insightKey), err)...)
 			return nil, diags
 		}
 
@@ -679,6 +684,11 @@ func fetchAllRRResults(ctx context.Context, client *models.ClientWithResponses,
 }
 
 func (r *insightResourceResultsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_insight_resource_results", "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan insightResourceResultsModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -691,6 +701,7 @@ func (r *insightResourceResultsResource) Create(ctx context.Context, req resourc
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	ctx = withCustomerContext(ctx, plan.CustomerContext)
 	defer cancel()
 
 	apiReq, buildDiags := plan.toResourceResultsRequest(ctx)
@@ -707,7 +718,7 @@ func (r *insightResourceResultsResource) Create(ctx context.Context, req resourc
 
 	createResp, err := r.client.PostInsightResourceResultsWithResponse(ctx, sourceID, insightKey, nil, *apiReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Error Creating Insight Resource Results", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Creating Insight Resource Results", "Could not create insight resource results", err)...)
 		return
 	}
 
@@ -750,6 +761,7 @@ func (r *insightResourceResultsResource) Read(ctx context.Context, req resource.
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
 
 	sourceID := state.SourceId.ValueString()
@@ -838,6 +850,11 @@ func preservePriorSeverity(ctx context.Context, priorElems []rr.ResourceResultsV
 }
 
 func (r *insightResourceResultsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_insight_resource_results", "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan insightResourceResultsModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -850,6 +867,7 @@ func (r *insightResourceResultsResource) Update(ctx context.Context, req resourc
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	ctx = withCustomerContext(ctx, plan.CustomerContext)
 	defer cancel()
 
 	apiReq, buildDiags := plan.toResourceResultsRequest(ctx)
@@ -866,7 +884,7 @@ func (r *insightResourceResultsResource) Update(ctx context.Context, req resourc
 
 	updateResp, err := r.client.PostInsightResourceResultsWithResponse(ctx, sourceID, insightKey, nil, *apiReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Error Updating Insight Resource Results", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Updating Insight Resource Results", "Could not update insight resource results", err)...)
 		return
 	}
 
@@ -887,6 +905,11 @@ func (r *insightResourceResultsResource) Update(ctx context.Context, req resourc
 }
 
 func (r *insightResourceResultsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_insight_resource_results", "delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var state insightResourceResultsModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -899,6 +922,7 @@ func (r *insightResourceResultsResource) Delete(ctx context.Context, req resourc
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
 
 	sourceID := models.PostInsightResourceResultsParamsSourceID(state.SourceId.ValueString())
@@ -915,7 +939,7 @@ func (r *insightResourceResultsResource) Delete(ctx context.Context, req resourc
 
 	delResp, err := r.client.PostInsightResourceResultsWithResponse(ctx, sourceID, insightKey, nil, emptyBody)
 	if err != nil {
-		resp.Diagnostics.AddError("Error Deleting Insight Resource Results", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Deleting Insight Resource Results", "Could not delete insight resource results", err)...)
 		return
 	}
 
@@ -27,7 +27,8 @@ type insightsDataSource struct {
 
 type insightsDataSourceModel struct {
 	datasource_insights.InsightsModel
-	Timeouts timeouts.Value `tfsdk:"timeouts"`
+	CustomerContext types.String   `tfsdk:"customer_context"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (ds *insightsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -51,6 +52,7 @@ func (ds *insightsDataSource) Configure(_ context.Context, req datasource.Config
 
 func (ds *insightsDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	s := datasource_insights.InsightsDataSourceSchema(ctx)
+	s.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx)
 	resp.Schema = s
 }
@@ -68,6 +70,7 @@ func (ds *insightsDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	// Composite inputs (lists) require IsFullyKnown to catch unknown elements.
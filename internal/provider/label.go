@@ -16,9 +16,7 @@ import (
 func (r *labelResource) populateState(ctx context.Context, state *labelResourceModel) diag.Diagnostics {
 	labelResp, err := r.client.GetLabelWithResponse(ctx, state.Id.ValueString())
 	if err != nil {
-		return diag.Diagnostics{
-			diag.NewErrorDiagnostic("Error Reading Label", "Could not read label ID "+state.Id.ValueString()+": "+err.Error()),
-		}
+		return apiErrorDiagnostics("Error Reading Label", "Could not read label ID "+state.Id.ValueString(), err)
 	}
 
 	if labelResp.StatusCode() == 404 {
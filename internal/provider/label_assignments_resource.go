@@ -20,13 +20,16 @@ import (
 
 type (
 	labelAssignmentsResource struct {
-		client *models.ClientWithResponses
+		client             *models.ClientWithResponses
+		readOnly           bool
+		validateReferences bool
 	}
 	labelAssignmentsResourceModel struct {
-		Id          types.String   `tfsdk:"id"`
-		LabelId     types.String   `tfsdk:"label_id"`
-		Assignments types.Set      `tfsdk:"assignments"`
-		Timeouts    timeouts.Value `tfsdk:"timeouts"`
+		Id              types.String   `tfsdk:"id"`
+		LabelId         types.String   `tfsdk:"label_id"`
+		Assignments     types.Set      `tfsdk:"assignments"`
+		CustomerContext types.String   `tfsdk:"customer_context"`
+		Timeouts        timeouts.Value `tfsdk:"timeouts"`
 	}
 )
 
@@ -41,6 +44,7 @@ var (
 	_ resource.Resource                = (*labelAssignmentsResource)(nil)
 	_ resource.ResourceWithConfigure   = (*labelAssignmentsResource)(nil)
 	_ resource.ResourceWithImportState = (*labelAssignmentsResource)(nil)
+	_ resource.ResourceWithModifyPlan  = (*labelAssignmentsResource)(nil)
 )
 
 // assignmentAttrTypes returns the attribute types for the assignment object.
@@ -61,16 +65,29 @@ func (r *labelAssignmentsResource) Configure(_ context.Context, req resource.Con
 		return
 	}
 
-	client, ok := req.ProviderData.(*models.ClientWithResponses)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *models.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.readOnly = data.readOnly
+	r.validateReferences = data.validateReferences
+}
+
+// ModifyPlan checks that the planned label and assigned objects exist unless
+// the provider's skip_reference_validation is set.
+func (r *labelAssignmentsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if !r.validateReferences {
+		return
+	}
+	resp.Diagnostics.Append(validateReferences(ctx, r.client, req.Plan, req.State,
+		referenceCheck{expr: path.MatchRoot("label_id"), kind: "label"},
+		referenceCheck{expr: path.MatchRoot("assignments").AtAnySetValue().AtName("object_id"), kindAttr: "object_type"})...)
 }
 
 func (r *labelAssignmentsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -127,6 +144,7 @@ func (r *labelAssignmentsResource) Schema(ctx context.Context, _ resource.Schema
 					},
 				},
 			},
+			"customer_context": customerContextResourceAttribute(),
 			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
 				Create: true,
 				Read:   true,
@@ -138,6 +156,11 @@ func (r *labelAssignmentsResource) Schema(ctx context.Context, _ resource.Schema
 }
 
 func (r *labelAssignmentsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_label_assignments", "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan labelAssignmentsResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -151,6 +174,7 @@ func (r *labelAssignmentsResource) Create(ctx context.Context, req resource.Crea
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	ctx = withCustomerContext(ctx, plan.CustomerContext)
 	defer cancel()
 
 	assignments := r.extractAssignments(plan.Assignments, &resp.Diagnostics)
@@ -165,10 +189,7 @@ func (r *labelAssignmentsResource) Create(ctx context.Context, req resource.Crea
 
 		assignResp, err := r.client.AssignObjectsToLabelWithResponse(ctx, plan.LabelId.ValueString(), apiReq)
 		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error Creating Label Assignments",
-				"Could not assign objects to label, unexpected error: "+err.Error(),
-			)
+			resp.Diagnostics.Append(apiErrorDiagnostics("Error Creating Label Assignments", "Could not assign objects to label", err)...)
 			return
 		}
 
@@ -200,14 +221,12 @@ func (r *labelAssignmentsResource) Read(ctx context.Context, req resource.ReadRe
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
 
 	assignResp, err := r.client.GetLabelAssignmentsWithResponse(ctx, state.LabelId.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading Label Assignments",
-			"Could not read label assignments: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Reading Label Assignments", "Could not read label assignments", err)...)
 		return
 	}
 
@@ -243,6 +262,11 @@ func (r *labelAssignmentsResource) Read(ctx context.Context, req resource.ReadRe
 }
 
 func (r *labelAssignmentsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_label_assignments", "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan labelAssignmentsResourceModel
 	var state labelAssignmentsResourceModel
 
@@ -258,6 +282,7 @@ func (r *labelAssignmentsResource) Update(ctx context.Context, req resource.Upda
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	ctx = withCustomerContext(ctx, plan.CustomerContext)
 	defer cancel()
 
 	oldAssignments := r.extractAssignments(state.Assignments, &resp.Diagnostics)
@@ -280,10 +305,7 @@ func (r *labelAssignmentsResource) Update(ctx context.Context, req resource.Upda
 
 		updateResp, err := r.client.AssignObjectsToLabelWithResponse(ctx, plan.LabelId.ValueString(), apiReq)
 		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error Updating Label Assignments",
-				"Could not update label assignments, unexpected error: "+err.Error(),
-			)
+			resp.Diagnostics.Append(apiErrorDiagnostics("Error Updating Label Assignments", "Could not update label assignments", err)...)
 			return
 		}
 
@@ -302,6 +324,11 @@ func (r *labelAssignmentsResource) Update(ctx context.Context, req resource.Upda
 }
 
 func (r *labelAssignmentsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_label_assignments", "delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var state labelAssignmentsResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -315,6 +342,7 @@ func (r *labelAssignmentsResource) Delete(ctx context.Context, req resource.Dele
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
 
 	assignments := r.extractAssignments(state.Assignments, &resp.Diagnostics)
@@ -329,10 +357,7 @@ func (r *labelAssignmentsResource) Delete(ctx context.Context, req resource.Dele
 
 		removeResp, err := r.client.AssignObjectsToLabelWithResponse(ctx, state.LabelId.ValueString(), apiReq)
 		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error Deleting Label Assignments",
-				"Could not remove objects from label, unexpected error: "+err.Error(),
-			)
+			resp.Diagnostics.Append(apiErrorDiagnostics("Error Deleting Label Assignments", "Could not remove objects from label", err)...)
 			return
 		}
 
@@ -348,6 +373,10 @@ func (r *labelAssignmentsResource) Delete(ctx context.Context, req resource.Dele
 }
 
 func (r *labelAssignmentsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, id, diags := importCustomerContext(ctx, req, resp, 1)
+	resp.Diagnostics.Append(diags...)
+	req.ID = id
+
 	// Import ID is the label_id
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("label_id"), req.ID)...)
@@ -7,19 +7,24 @@ import (
 	"github.com/doitintl/terraform-provider-doit/internal/provider/models"
 	"github.com/doitintl/terraform-provider-doit/internal/provider/resource_label"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 type (
 	labelResource struct {
-		client *models.ClientWithResponses
+		client   *models.ClientWithResponses
+		readOnly bool
 	}
 	labelResourceModel struct {
 		resource_label.LabelModel
-		Timeouts timeouts.Value `tfsdk:"timeouts"`
+		AdoptExisting   types.Bool     `tfsdk:"adopt_existing"`
+		CustomerContext types.String   `tfsdk:"customer_context"`
+		Timeouts        timeouts.Value `tfsdk:"timeouts"`
 	}
 )
 
@@ -41,16 +46,17 @@ func (r *labelResource) Configure(_ context.Context, req resource.ConfigureReque
 		return
 	}
 
-	client, ok := req.ProviderData.(*models.ClientWithResponses)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *models.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.readOnly = data.readOnly
 }
 
 func (r *labelResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -58,6 +64,10 @@ func (r *labelResource) Metadata(_ context.Context, req resource.MetadataRequest
 }
 
 func (r *labelResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, id, diags := importCustomerContext(ctx, req, resp, 1)
+	resp.Diagnostics.Append(diags...)
+	req.ID = id
+
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
@@ -79,6 +89,8 @@ func (r *labelResource) Schema(ctx context.Context, _ resource.SchemaRequest, re
 		s.Attributes["type"] = attr
 	}
 
+	s.Attributes["adopt_existing"] = adoptExistingAttribute("label", "the same name")
+	s.Attributes["customer_context"] = customerContextResourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx, timeouts.Opts{
 		Create: true,
 		Read:   true,
@@ -90,6 +102,11 @@ func (r *labelResource) Schema(ctx context.Context, _ resource.SchemaRequest, re
 }
 
 func (r *labelResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_label", "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan labelResourceModel
 
 	// Read Terraform plan data into the model
@@ -104,18 +121,36 @@ func (r *labelResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	ctx = withCustomerContext(ctx, plan.CustomerContext)
 	defer cancel()
 
+	if plan.AdoptExisting.ValueBool() {
+		existingID, adoptDiags := findAdoptable(ctx, "label", plan.Name.ValueString(), nameLookup(r.client, listLabels))
+		resp.Diagnostics.Append(adoptDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if existingID != "" {
+			updated, updateDiags := r.update(ctx, existingID, &plan)
+			resp.Diagnostics.Append(updateDiags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			// Plan-first state pattern: overlay Computed-only fields from API response.
+			overlayLabelComputedFields(updated, &plan)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+			return
+		}
+	}
+
 	// Convert model to API request type
 	apiReq := plan.toCreateRequest()
 
 	// Create new label via API
 	labelResp, err := r.client.CreateLabelWithResponse(ctx, apiReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Creating Label",
-			"Could not create label, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Creating Label", "Could not create label", err)...)
 		return
 	}
 
@@ -157,6 +192,7 @@ func (r *labelResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
 
 	diags = r.populateState(ctx, &state)
@@ -176,6 +212,11 @@ func (r *labelResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *labelResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_label", "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan labelResourceModel
 
 	// Read Terraform plan data
@@ -190,6 +231,7 @@ func (r *labelResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	ctx = withCustomerContext(ctx, plan.CustomerContext)
 	defer cancel()
 
 	// Get the ID from the state
@@ -200,43 +242,60 @@ func (r *labelResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 	labelID := state.Id.ValueString()
 
+	updated, updateDiags := r.update(ctx, labelID, &plan)
+	resp.Diagnostics.Append(updateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Plan-first state pattern: overlay Computed-only fields from API response.
+	overlayLabelComputedFields(updated, &plan)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// update sends plan as an update of the label with ID labelID and returns the
+// updated object, for Update and for Create when adopt_existing takes over an
+// existing label.
+func (r *labelResource) update(ctx context.Context, labelID string, plan *labelResourceModel) (*models.LabelListItem, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
 	// Convert model to API request type
 	apiReq := plan.toUpdateRequest()
 
 	// Update label via API
 	updateResp, err := r.client.UpdateLabelWithResponse(ctx, labelID, apiReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Updating Label",
-			"Could not update label, unexpected error: "+err.Error(),
-		)
-		return
+		diags.Append(apiErrorDiagnostics("Error Updating Label", "Could not update label", err)...)
+		return nil, diags
 	}
 
 	if updateResp.StatusCode() != 200 {
-		resp.Diagnostics.AddError(
+		diags.AddError(
 			"Error Updating Label",
 			fmt.Sprintf("Could not update label, status: %d, body: %s", updateResp.StatusCode(), string(updateResp.Body)),
 		)
-		return
+		return nil, diags
 	}
 
 	if updateResp.JSON200 == nil {
-		resp.Diagnostics.AddError(
+		diags.AddError(
 			"Error Updating Label",
 			"Received empty response body",
 		)
-		return
+		return nil, diags
 	}
 
-	// Plan-first state pattern: overlay Computed-only fields from API response.
-	overlayLabelComputedFields(updateResp.JSON200, &plan)
-
-	// Save updated data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	return updateResp.JSON200, diags
 }
 
 func (r *labelResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_label", "delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var state labelResourceModel
 
 	// Read Terraform prior state data into the model
@@ -251,15 +310,13 @@ func (r *labelResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
 
 	// Delete label via API
 	deleteResp, err := r.client.DeleteLabelWithResponse(ctx, state.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Deleting DoiT Label",
-			"Could not delete label, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Deleting DoiT Label", "Could not delete label", err)...)
 		return
 	}
 
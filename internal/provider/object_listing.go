@@ -0,0 +1,224 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/doitintl/terraform-provider-doit/internal/provider/models"
+)
+
+// namedObject is the ID and display name of one item from a list endpoint.
+type namedObject struct {
+	ID   string
+	Name string
+}
+
+// listObjectsFunc walks every page of a list endpoint. A non-empty
+// nameContains is passed through as the API's case-insensitive substring
+// filter where the endpoint supports one; endpoints without that filter
+// return everything and leave matching to the caller.
+type listObjectsFunc func(ctx context.Context, client *models.ClientWithResponses, nameContains string) ([]namedObject, error)
+
+// appendNamedObject appends the item when both its ID and name are set.
+func appendNamedObject(objects []namedObject, id, name *string) []namedObject {
+	if id == nil || name == nil {
+		return objects
+	}
+	return append(objects, namedObject{ID: *id, Name: *name})
+}
+
+// listStatusError formats an unexpected list response the same way the list
+// data sources do.
+func listStatusError(statusCode int, body []byte) error {
+	return fmt.Errorf("API returned status %d: %s", statusCode, string(body))
+}
+
+// hasNextPage reports whether a list response carries a page token to follow.
+func hasNextPage(pageToken *string) bool {
+	return pageToken != nil && *pageToken != ""
+}
+
+func listReports(ctx context.Context, client *models.ClientWithResponses, nameContains string) ([]namedObject, error) {
+	var objects []namedObject
+	params := &models.ListReportsParams{}
+	if nameContains != "" {
+		params.NameContains = new(nameContains)
+	}
+	for {
+		apiResp, err := client.ListReportsWithResponse(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		if apiResp.StatusCode() != 200 || apiResp.JSON200 == nil {
+			return nil, listStatusError(apiResp.StatusCode(), apiResp.Body)
+		}
+		if apiResp.JSON200.Reports != nil {
+			for _, report := range *apiResp.JSON200.Reports {
+				objects = appendNamedObject(objects, report.Id, report.ReportName)
+			}
+		}
+		if !hasNextPage(apiResp.JSON200.PageToken) {
+			return objects, nil
+		}
+		params.PageToken = apiResp.JSON200.PageToken
+	}
+}
+
+func listBudgets(ctx context.Context, client *models.ClientWithResponses, nameContains string) ([]namedObject, error) {
+	var objects []namedObject
+	params := &models.ListBudgetsParams{}
+	if nameContains != "" {
+		params.NameContains = new(nameContains)
+	}
+	for {
+		apiResp, err := client.ListBudgetsWithResponse(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		if apiResp.StatusCode() != 200 || apiResp.JSON200 == nil {
+			return nil, listStatusError(apiResp.StatusCode(), apiResp.Body)
+		}
+		if apiResp.JSON200.Budgets != nil {
+			for _, budget := range *apiResp.JSON200.Budgets {
+				objects = appendNamedObject(objects, budget.Id, budget.BudgetName)
+			}
+		}
+		if !hasNextPage(apiResp.JSON200.PageToken) {
+			return objects, nil
+		}
+		params.PageToken = apiResp.JSON200.PageToken
+	}
+}
+
+func listAlerts(ctx context.Context, client *models.ClientWithResponses, nameContains string) ([]namedObject, error) {
+	var objects []namedObject
+	params := &models.ListAlertsParams{}
+	if nameContains != "" {
+		params.NameContains = new(nameContains)
+	}
+	for {
+		apiResp, err := client.ListAlertsWithResponse(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		if apiResp.StatusCode() != 200 || apiResp.JSON200 == nil {
+			return nil, listStatusError(apiResp.StatusCode(), apiResp.Body)
+		}
+		if apiResp.JSON200.Alerts != nil {
+			for _, alert := range *apiResp.JSON200.Alerts {
+				objects = appendNamedObject(objects, alert.Id, &alert.Name)
+			}
+		}
+		if !hasNextPage(apiResp.JSON200.PageToken) {
+			return objects, nil
+		}
+		params.PageToken = apiResp.JSON200.PageToken
+	}
+}
+
+func listAllocations(ctx context.Context, client *models.ClientWithResponses, nameContains string) ([]namedObject, error) {
+	var objects []namedObject
+	params := &models.ListAllocationsParams{}
+	if nameContains != "" {
+		params.NameContains = new(nameContains)
+	}
+	for {
+		apiResp, err := client.ListAllocationsWithResponse(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		if apiResp.StatusCode() != 200 || apiResp.JSON200 == nil {
+			return nil, listStatusError(apiResp.StatusCode(), apiResp.Body)
+		}
+		if apiResp.JSON200.Allocations != nil {
+			for _, allocation := range *apiResp.JSON200.Allocations {
+				objects = appendNamedObject(objects, allocation.Id, allocation.Name)
+			}
+		}
+		if !hasNextPage(apiResp.JSON200.PageToken) {
+			return objects, nil
+		}
+		params.PageToken = apiResp.JSON200.PageToken
+	}
+}
+
+func listLabels(ctx context.Context, client *models.ClientWithResponses, nameContains string) ([]namedObject, error) {
+	var objects []namedObject
+	params := &models.ListLabelsParams{}
+	if nameContains != "" {
+		params.NameContains = new(nameContains)
+	}
+	for {
+		apiResp, err := client.ListLabelsWithResponse(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		if apiResp.StatusCode() != 200 || apiResp.JSON200 == nil {
+			return nil, listStatusError(apiResp.StatusCode(), apiResp.Body)
+		}
+		if apiResp.JSON200.Labels != nil {
+			for _, label := range *apiResp.JSON200.Labels {
+				objects = appendNamedObject(objects, &label.Id, &label.Name)
+			}
+		}
+		if !hasNextPage(apiResp.JSON200.PageToken) {
+			return objects, nil
+		}
+		params.PageToken = apiResp.JSON200.PageToken
+	}
+}
+
+// listFolders ignores nameContains: the folders endpoint has no name filter.
+func listFolders(ctx context.Context, client *models.ClientWithResponses, _ string) ([]namedObject, error) {
+	folders, err := listFolderModels(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	var objects []namedObject
+	for _, folder := range folders {
+		objects = appendNamedObject(objects, folder.Id, folder.Name)
+	}
+	return objects, nil
+}
+
+// listFolderModels walks every page of the folders endpoint, keeping the
+// fields listFolders drops, such as the parent folder.
+func listFolderModels(ctx context.Context, client *models.ClientWithResponses) ([]models.Folder, error) {
+	var folders []models.Folder
+	params := &models.ListFoldersParams{}
+	for {
+		apiResp, err := client.ListFoldersWithResponse(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		if apiResp.StatusCode() != 200 || apiResp.JSON200 == nil {
+			return nil, listStatusError(apiResp.StatusCode(), apiResp.Body)
+		}
+		if apiResp.JSON200.Folders != nil {
+			folders = append(folders, *apiResp.JSON200.Folders...)
+		}
+		if !hasNextPage(apiResp.JSON200.PageToken) {
+			return folders, nil
+		}
+		params.PageToken = apiResp.JSON200.PageToken
+	}
+}
+
+// listCustomThemes ignores nameContains: the themes endpoint has no name
+// filter, and returns every theme in one page.
+func listCustomThemes(ctx context.Context, client *models.ClientWithResponses, _ string) ([]namedObject, error) {
+	apiResp, err := client.ListCustomThemesWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if apiResp.StatusCode() != 200 || apiResp.JSON200 == nil {
+		return nil, listStatusError(apiResp.StatusCode(), apiResp.Body)
+	}
+	var objects []namedObject
+	if apiResp.JSON200.Themes != nil {
+		for _, theme := range *apiResp.JSON200.Themes {
+			objects = appendNamedObject(objects, &theme.Id, &theme.Name)
+		}
+	}
+	return objects, nil
+}
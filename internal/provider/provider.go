@@ -4,15 +4,24 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
 	"time"
 
+	"github.com/doitintl/terraform-provider-doit/internal/provider/models"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
@@ -28,8 +37,90 @@ const HostURL string = "https://api.doit.com"
 type doitProviderModel struct {
 	Host            types.String `tfsdk:"host"`
 	DoiTAPITOken    types.String `tfsdk:"api_token"`
+	APITokenFile    types.String `tfsdk:"api_token_file"`
+	APITokenCommand types.List   `tfsdk:"api_token_command"`
 	CustomerContext types.String `tfsdk:"customer_context"`
 	RequestTimeout  types.String `tfsdk:"request_timeout"`
+	DefaultLabels   types.Set    `tfsdk:"default_labels"`
+
+	DefaultNotificationRecipients types.List `tfsdk:"default_notification_recipients"`
+	DefaultSlackChannels          types.List `tfsdk:"default_slack_channels"`
+	DefaultCollaborators          types.List `tfsdk:"default_collaborators"`
+
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"`
+	ReadOnly           types.Bool `tfsdk:"read_only"`
+
+	ValidateDimensionsOnline types.Bool   `tfsdk:"validate_dimensions_online"`
+	ValidateRecipients       types.String `tfsdk:"validate_recipients"`
+	SkipReferenceValidation  types.Bool   `tfsdk:"skip_reference_validation"`
+	ExplainDrift             types.Bool   `tfsdk:"explain_drift"`
+
+	DetectConcurrentModification types.Bool `tfsdk:"detect_concurrent_modification"`
+
+	MaxRequestsPerSecond types.Float64 `tfsdk:"max_requests_per_second"`
+	Burst                types.Int64   `tfsdk:"burst"`
+
+	CacheReferenceData types.Bool `tfsdk:"cache_reference_data"`
+
+	ProxyURL           types.String `tfsdk:"proxy_url"`
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	ClientCert         types.String `tfsdk:"client_cert"`
+	ClientKey          types.String `tfsdk:"client_key"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+
+	CircuitBreaker types.Object `tfsdk:"circuit_breaker"`
+	Retry          types.Object `tfsdk:"retry"`
+	Tracing        types.Object `tfsdk:"tracing"`
+}
+
+// providerData is handed to every resource through ResourceData. Data sources
+// only need the API client and receive it directly.
+type providerData struct {
+	client *models.ClientWithResponses
+
+	// defaultLabels are label IDs assigned to every report, budget, alert,
+	// allocation and annotation the provider creates. See default_labels.go.
+	defaultLabels []string
+
+	// notificationDefaults fill budget and alert recipients, Slack channels
+	// and collaborators left out of configuration. See default_notifications.go.
+	notificationDefaults notificationDefaults
+
+	// deletionProtection applies to resources with a deletion_protection
+	// attribute that is not set. See deletion_protection.go.
+	deletionProtection bool
+
+	// validateDimensions enables the plan-time checks of doit_budget,
+	// doit_alert, doit_allocation and doit_report dimension filters. See
+	// dimension_validation.go.
+	validateDimensions bool
+
+	// recipientValidation is the validate_recipients mode of the plan-time
+	// checks of doit_budget, doit_alert and doit_sharing email addresses,
+	// which look up the customer of customerContext, the provider's
+	// customer_context. See recipient_validation.go.
+	recipientValidation string
+	customerContext     string
+
+	// validateReferences enables the plan-time existence checks of folder,
+	// report, label and other object IDs referenced by resources, unless
+	// skip_reference_validation is set. See reference_validation.go.
+	validateReferences bool
+
+	// explainDrift makes Read of doit_report, doit_budget, doit_alert and
+	// doit_allocation warn about every API normalization it maps back to the
+	// configured form. See drift_explanation.go.
+	explainDrift bool
+
+	// readOnly makes Create, Update and Delete of every resource fail before
+	// any API request. See read_only.go.
+	readOnly bool
+
+	// detectConcurrentModification makes Update of doit_budget, doit_alert,
+	// doit_allocation and doit_report fail when the object changed since the
+	// last refresh. See concurrent_modification.go.
+	detectConcurrentModification bool
 }
 
 // New is a helper function to simplify provider server and testing implementation.
@@ -41,12 +132,31 @@ func New(version string) func() provider.Provider {
 	}
 }
 
+// NewServer returns the provider's protocol server factory for
+// tf6server.Serve, with a span started for every resource and data source
+// operation when tracing is enabled. The returned shutdown function exports
+// buffered spans and must be called after the server stops.
+func NewServer(version string) (func() tfprotov6.ProviderServer, func(context.Context) error) {
+	p := &doitProvider{
+		version:   version,
+		telemetry: &telemetry{},
+	}
+	server := providerserver.NewProtocol6(p)()
+	return func() tfprotov6.ProviderServer {
+		return &tracingServer{ProviderServer: server, telemetry: p.telemetry}
+	}, p.telemetry.shutdown
+}
+
 // doitProvider is the provider implementation.
 type doitProvider struct {
 	// version is set to the provider version on release, "dev" when the
 	// provider is built and ran locally, and "test" when running acceptance
 	// testing.
 	version string
+
+	// telemetry holds the tracer provider once tracing is configured. See
+	// tracing.go.
+	telemetry *telemetry
 }
 
 // Metadata returns the provider type name.
@@ -73,6 +183,30 @@ func (p *doitProvider) Schema(ctx context.Context, _ provider.SchemaRequest, res
 				Optional:  true,
 				Sensitive: true,
 			},
+			"api_token_file": schema.StringAttribute{
+				Description: "Path to a file containing the API token, for example one kept up to date by a Vault agent. " +
+					"The file is read again whenever it changes, so a rotated token is used without restarting Terraform. " +
+					"May also be provided by DOIT_API_TOKEN_FILE environment variable. Conflicts with `api_token` and `api_token_command`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ConflictsWith(path.MatchRoot("api_token"), path.MatchRoot("api_token_command")),
+				},
+			},
+			"api_token_command": schema.ListAttribute{
+				Description: "Command that prints the API token, as a program followed by its arguments, e.g. " +
+					"`[\"vault\", \"kv\", \"get\", \"-field=token\", \"secret/doit\"]`. The output is either the bare token, " +
+					"reused for 15 minutes, or a JSON object `{\"token\": \"...\", \"expires_at\": \"<RFC 3339 time>\"}`, " +
+					"reused until shortly before it expires. The command runs again when the token is due, so tokens " +
+					"rotated during a run are picked up. Conflicts with `api_token` and `api_token_file`.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+					listvalidator.ConflictsWith(path.MatchRoot("api_token"), path.MatchRoot("api_token_file")),
+				},
+			},
 			"customer_context": schema.StringAttribute{
 				Description: "Customer context. May also be provided by DOIT_CUSTOMER_CONTEXT " +
 					"environment variable. This field is required for DoiT employees only.",
@@ -93,6 +227,297 @@ func (p *doitProvider) Schema(ctx context.Context, _ provider.SchemaRequest, res
 					requestTimeoutValidator{},
 				},
 			},
+			"default_labels": schema.SetAttribute{
+				Description: "IDs of labels to assign to every report, budget, alert, allocation and annotation " +
+					"this provider creates, similar to default tags in other providers. The labels are assigned " +
+					"after the object is created and unassigned before it is destroyed; objects that already exist " +
+					"are not relabeled when this list changes. On reports and annotations, default labels are " +
+					"kept out of the `labels` attribute so they cause no diff. A doit_label_assignments resource " +
+					"managing the same label takes precedence: it removes any default assignment it does not declare.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"default_notification_recipients": schema.ListAttribute{
+				Description: "Email addresses used as `recipients` on every doit_budget and doit_alert " +
+					"that does not set `recipients` itself.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"default_slack_channels": schema.ListNestedAttribute{
+				Description: "Slack channels used as `recipients_slack_channels` on every doit_budget " +
+					"that does not set `recipients_slack_channels` itself. Fields other than `id` may be " +
+					"omitted and are then taken from the API.",
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Slack channel ID.",
+							Required:    true,
+						},
+						"customer_id": schema.StringAttribute{
+							Description: "Slack customer ID.",
+							Optional:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Slack channel name.",
+							Optional:    true,
+						},
+						"shared": schema.BoolAttribute{
+							Description: "Whether the channel is shared.",
+							Optional:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "Slack channel type.",
+							Optional:    true,
+						},
+						"workspace": schema.StringAttribute{
+							Description: "Slack workspace ID.",
+							Optional:    true,
+						},
+					},
+				},
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"default_collaborators": schema.ListNestedAttribute{
+				Description: "Collaborators used as `collaborators` on every doit_budget that does not " +
+					"set `collaborators` itself. Must contain exactly one collaborator with role `owner`.",
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"email": schema.StringAttribute{
+							Description: "Collaborator email address.",
+							Required:    true,
+						},
+						"role": schema.StringAttribute{
+							Description: "Possible values: `owner`, `editor`, `viewer`.",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf("owner", "editor", "viewer"),
+							},
+						},
+					},
+				},
+				Validators: []validator.List{
+					defaultCollaboratorsOwnerValidator{},
+				},
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Description: "Default for the `deletion_protection` attribute of doit_report, doit_allocation, " +
+					"doit_budget, doit_folder and doit_datahub_dataset resources that do not set it. " +
+					"Defaults to false.",
+				Optional: true,
+			},
+			"validate_dimensions_online": schema.BoolAttribute{
+				Description: "Whether dimension keys and values in the scopes of doit_budget and doit_alert, the " +
+					"components of doit_allocation and the filters of doit_report are checked against the DoiT API " +
+					"during plan. Unknown keys and values are reported as errors with suggestions of similar ones. " +
+					"Adds API requests to every plan that changes them. Defaults to false.",
+				Optional: true,
+			},
+			"validate_recipients": schema.StringAttribute{
+				Description: "Whether email addresses in the recipients and collaborators of doit_budget, the recipients " +
+					"of doit_alert and the permissions of doit_sharing are checked against the DoiT API during plan. " +
+					"An address passes when it belongs to a user of the account or to one of the customer's domains " +
+					"or allowed invite domains. With \"warn\", other addresses produce warnings; with \"strict\", " +
+					"errors. Addresses unchanged since the last apply are not checked again. Defaults to \"off\".",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(recipientValidationOff, recipientValidationWarn, recipientValidationStrict),
+				},
+			},
+			"skip_reference_validation": schema.BoolAttribute{
+				Description: "Whether to skip the plan-time check that folders, reports, labels and other objects " +
+					"referenced by ID exist: the folder_id of doit_allocation and doit_report, the parent_folder_id " +
+					"of doit_folder, the labels of doit_report and doit_annotation, the reports of doit_annotation " +
+					"and the label and assigned objects of doit_label_assignments. Missing objects are reported as " +
+					"errors on the referencing attribute. IDs unchanged since the last apply are not checked again. " +
+					"Defaults to false.",
+				Optional: true,
+			},
+			"read_only": schema.BoolAttribute{
+				Description: "Whether the provider refuses to change anything: creating, updating or deleting any " +
+					"resource fails before an API request is made, and the API client rejects every request that " +
+					"could change data. Plans, refreshes, imports and data sources keep working. Meant for pipelines " +
+					"that only run terraform plan. Can also be set with the DOIT_READ_ONLY environment variable. " +
+					"Defaults to false.",
+				Optional: true,
+			},
+			"explain_drift": schema.BoolAttribute{
+				Description: "Whether refreshing doit_report, doit_budget, doit_alert and doit_allocation reports a " +
+					"warning for every value the API returned in a normalized form that the provider mapped back to " +
+					"the configured one, such as a stripped \"[Service N/A]\" value, a renamed alias type or a " +
+					"reformatted timestamp. Meant for tracking down unexpected diffs. Can also be set with the " +
+					"DOIT_EXPLAIN_DRIFT environment variable. Defaults to false.",
+				Optional: true,
+			},
+			"detect_concurrent_modification": schema.BoolAttribute{
+				Description: "Whether updating doit_budget, doit_alert, doit_allocation or doit_report first reads " +
+					"the object again and fails when its update time differs from the last refresh, meaning it " +
+					"was changed outside Terraform, for example in the DoiT console. The error lists the " +
+					"attributes that changed, instead of the update silently overwriting them. Costs one extra " +
+					"API request per update. doit_report reads its update time from the reports list, as the " +
+					"report config has none, which costs one list request, filtered by the report's name, per " +
+					"refresh, create and update of each report; the report's query is not run. Defaults to false.",
+				Optional: true,
+			},
+			"max_requests_per_second": schema.Float64Attribute{
+				Description: "Maximum number of API requests per second, shared by all concurrent operations " +
+					"and including retries. When the API responds with 429, the rate is lowered and then " +
+					"gradually raised back to this value. Unlimited when unset.",
+				Optional: true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0.1),
+				},
+			},
+			"burst": schema.Int64Attribute{
+				Description: "Number of requests that may be sent at once before `max_requests_per_second` applies. " +
+					"Requires `max_requests_per_second`. Defaults to one second's worth of requests.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+					int64validator.AlsoRequires(path.MatchRoot("max_requests_per_second")),
+				},
+			},
+			"cache_reference_data": schema.BoolAttribute{
+				Description: "Whether responses for reference data — dimensions, roles, support platforms and products, " +
+					"and the current user — are cached in memory for the duration of a plan or apply, with concurrent " +
+					"identical requests sent only once. Writes through this provider invalidate the affected entries. " +
+					"Objects Terraform manages are never cached. Defaults to false.",
+				Optional: true,
+			},
+			"proxy_url": schema.StringAttribute{
+				Description: "URL of the proxy to reach the DoiT API through, e.g. \"http://proxy.example.com:3128\". " +
+					"Supports the http, https and socks5 schemes. When unset, the HTTPS_PROXY and NO_PROXY " +
+					"environment variables apply.",
+				Optional: true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Description: "PEM-encoded CA certificates to trust in addition to the system roots, for example " +
+					"the CA of a TLS-inspecting proxy. Conflicts with `ca_cert_file`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("ca_cert_file")),
+				},
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Description: "Path to a file of PEM-encoded CA certificates to trust in addition to the system roots. " +
+					"Conflicts with `ca_cert_pem`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"client_cert": schema.StringAttribute{
+				Description: "PEM-encoded client certificate presented for mutual TLS, e.g. `file(\"client.crt\")`. " +
+					"Requires `client_key`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_key")),
+				},
+			},
+			"client_key": schema.StringAttribute{
+				Description: "PEM-encoded private key of `client_cert`. Requires `client_cert`.",
+				Optional:    true,
+				Sensitive:   true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_cert")),
+				},
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Description: "Whether to skip verification of the API's TLS certificate. Exposes the API token to " +
+					"anyone able to intercept the connection; prefer `ca_cert_pem` or `ca_cert_file`. Defaults to false.",
+				Optional: true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"circuit_breaker": schema.SingleNestedBlock{
+				Description: "Fails API requests fast during a sustained DoiT API outage. After `failure_threshold` " +
+					"consecutive outage responses (502, 503, 504 or 524) across all operations, remaining requests fail " +
+					"immediately instead of each retrying until its operation timeout. After `cool_down`, a single " +
+					"probe request is sent; if it succeeds, requests flow again. Other server errors, such as a 500 for " +
+					"one invalid request, do not count. Disabled unless the block is set; `circuit_breaker {}` enables " +
+					"it with the default settings.",
+				Attributes: map[string]schema.Attribute{
+					"enabled": schema.BoolAttribute{
+						Description: "Whether the circuit breaker is active. Defaults to `true` when the block is set.",
+						Optional:    true,
+					},
+					"failure_threshold": schema.Int64Attribute{
+						Description: "Consecutive outage responses (502, 503, 504 or 524), across all operations, that open " +
+							"the breaker. Defaults to `10`.",
+						Optional: true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+					"cool_down": schema.StringAttribute{
+						Description: "How long requests fail fast before a probe is sent, as a duration string " +
+							"(e.g. \"30s\"). Defaults to \"30s\".",
+						Optional: true,
+					},
+				},
+			},
+			"retry": schema.SingleNestedBlock{
+				Description: "Tunes how API requests are retried. By default, 429, 502, 503 and 504 responses " +
+					"are retried with exponential backoff until the operation timeout expires.",
+				Attributes: map[string]schema.Attribute{
+					"initial_interval": schema.StringAttribute{
+						Description: "First backoff delay, as a duration string (e.g. \"2s\"). Also the minimum " +
+							"wait when the API sends Retry-After. Defaults to \"2s\".",
+						Optional: true,
+					},
+					"max_interval": schema.StringAttribute{
+						Description: "Maximum backoff delay between attempts, as a duration string (e.g. \"1m\"). " +
+							"Also caps an honored Retry-After. Defaults to \"1m\".",
+						Optional: true,
+					},
+					"max_attempts": schema.Int64Attribute{
+						Description: "Maximum number of attempts per request, the first one included. " +
+							"When unset, retries continue until the operation timeout expires.",
+						Optional: true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+					"extra_retryable_statuses": schema.MapAttribute{
+						Description: "Additional HTTP status codes to retry, keyed by upper-case HTTP method, " +
+							"e.g. `{ GET = [500] }`. Retrying a 5xx on POST or PATCH can apply the request twice " +
+							"and produces a warning.",
+						ElementType: types.ListType{ElemType: types.Int64Type},
+						Optional:    true,
+					},
+				},
+			},
+			"tracing": schema.SingleNestedBlock{
+				Description: "Exports OpenTelemetry traces of provider operations and API requests over OTLP/HTTP. " +
+					"Each resource and data source operation is a span, with a child span per API request attempt " +
+					"recording the method, route, status, retry count and backoff wait. Tracing is also enabled, " +
+					"without this block, when OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT is set; " +
+					"the other OTEL_EXPORTER_OTLP_* variables are honored either way.",
+				Attributes: map[string]schema.Attribute{
+					"endpoint": schema.StringAttribute{
+						Description: "Base URL of the OTLP/HTTP collector, e.g. \"http://localhost:4318\". Spans are sent " +
+							"to its /v1/traces path. Defaults to the OTEL_EXPORTER_OTLP_* environment variables, " +
+							"or \"https://localhost:4318\".",
+						Optional: true,
+					},
+					"headers": schema.MapAttribute{
+						Description: "Headers sent with every export request, e.g. for collector authentication.",
+						ElementType: types.StringType,
+						Optional:    true,
+						Sensitive:   true,
+					},
+				},
+			},
 		},
 	}
 }
@@ -143,6 +568,121 @@ func (p *doitProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		)
 	}
 
+	if config.APITokenFile.IsUnknown() || config.APITokenCommand.IsUnknown() {
+		resp.Diagnostics.AddError(
+			"Unknown DoiT API Token Source",
+			"The provider cannot create the DoiT API client as api_token_file or api_token_command is not known until apply. "+
+				"Set the value statically in the configuration, or use the DOIT_API_TOKEN_FILE environment variable.",
+		)
+	}
+
+	if config.DefaultLabels.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_labels"),
+			"Unknown Default Labels",
+			"The provider cannot be configured because default_labels is not known until apply. "+
+				"Reference labels that already exist, or target apply the doit_label resources first.",
+		)
+	}
+
+	for _, d := range []struct {
+		name  string
+		value types.List
+	}{
+		{"default_notification_recipients", config.DefaultNotificationRecipients},
+		{"default_slack_channels", config.DefaultSlackChannels},
+		{"default_collaborators", config.DefaultCollaborators},
+	} {
+		if d.value.IsUnknown() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(d.name),
+				"Unknown Provider Default",
+				fmt.Sprintf("The provider cannot be configured because %s is not known until apply. "+
+					"Set the value statically in the configuration.", d.name),
+			)
+		}
+	}
+
+	if config.MaxRequestsPerSecond.IsUnknown() || config.Burst.IsUnknown() {
+		resp.Diagnostics.AddError(
+			"Unknown Rate Limit",
+			"The provider cannot be configured because max_requests_per_second or burst is not known until apply. "+
+				"Set the values statically in the configuration.",
+		)
+	}
+
+	if config.CacheReferenceData.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("cache_reference_data"),
+			"Unknown Provider Setting",
+			"The provider cannot be configured because cache_reference_data is not known until apply. "+
+				"Set the value statically in the configuration.",
+		)
+	}
+
+	if config.DeletionProtection.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("deletion_protection"),
+			"Unknown Provider Default",
+			"The provider cannot be configured because deletion_protection is not known until apply. "+
+				"Set the value statically in the configuration.",
+		)
+	}
+
+	if config.ValidateDimensionsOnline.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("validate_dimensions_online"),
+			"Unknown Provider Setting",
+			"The provider cannot be configured because validate_dimensions_online is not known until apply. "+
+				"Set the value statically in the configuration.",
+		)
+	}
+
+	if config.ValidateRecipients.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("validate_recipients"),
+			"Unknown Provider Setting",
+			"The provider cannot be configured because validate_recipients is not known until apply. "+
+				"Set the value statically in the configuration.",
+		)
+	}
+
+	if config.SkipReferenceValidation.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("skip_reference_validation"),
+			"Unknown Provider Setting",
+			"The provider cannot be configured because skip_reference_validation is not known until apply. "+
+				"Set the value statically in the configuration.",
+		)
+	}
+
+	if config.DetectConcurrentModification.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("detect_concurrent_modification"),
+			"Unknown Provider Setting",
+			"The provider cannot be configured because detect_concurrent_modification is not known until apply. "+
+				"Set the value statically in the configuration.",
+		)
+	}
+
+	if config.ReadOnly.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("read_only"),
+			"Unknown Provider Setting",
+			"The provider cannot be configured because read_only is not known until apply. "+
+				"Set the value statically in the configuration.",
+		)
+	}
+
+	if config.ExplainDrift.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("explain_drift"),
+			"Unknown Provider Setting",
+			"The provider cannot be configured because explain_drift is not known until apply. "+
+				"Set the value statically in the configuration.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -179,12 +719,18 @@ func (p *doitProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		host = HostURL
 	}
 
-	if doiTAPIToken == "" {
+	// A token source configured in HCL takes precedence over DOIT_API_TOKEN;
+	// DOIT_API_TOKEN_FILE applies only when no token is set at all.
+	tokenSource, tokenDiags := apiTokenSource(ctx, config, doiTAPIToken)
+	resp.Diagnostics.Append(tokenDiags...)
+
+	if doiTAPIToken == "" && tokenSource == nil && !tokenDiags.HasError() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("doiTAPIToken"),
 			"Missing DoiT API Token",
 			"The provider cannot create the DoiT API client as there is a missing or empty value for the DoiT API token. "+
-				"Set the doiTAPIToken value in the configuration or use the DOIT_API_TOKEN environment variable. "+
+				"Set the doiTAPIToken value in the configuration or use the DOIT_API_TOKEN environment variable, "+
+				"or configure api_token_file or api_token_command. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
@@ -193,6 +739,32 @@ func (p *doitProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		return
 	}
 
+	explainDrift := config.ExplainDrift.ValueBool()
+	if v := os.Getenv(explainDriftEnvVar); v != "" && config.ExplainDrift.IsNull() {
+		if parsed, err := strconv.ParseBool(v); err == nil {
+			explainDrift = parsed
+		} else {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("explain_drift"),
+				"Invalid "+explainDriftEnvVar,
+				fmt.Sprintf("Could not parse %s environment variable %q as a boolean: %s", explainDriftEnvVar, v, err),
+			)
+		}
+	}
+
+	readOnly := config.ReadOnly.ValueBool()
+	if v := os.Getenv(readOnlyEnvVar); v != "" && config.ReadOnly.IsNull() {
+		if parsed, err := strconv.ParseBool(v); err == nil {
+			readOnly = parsed
+		} else {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("read_only"),
+				"Invalid "+readOnlyEnvVar,
+				fmt.Sprintf("Could not parse %s environment variable %q as a boolean: %s", readOnlyEnvVar, v, err),
+			)
+		}
+	}
+
 	// Parse request timeout
 	requestTimeout := DefaultRequestTimeout
 	if v := os.Getenv("DOIT_REQUEST_TIMEOUT"); v != "" {
@@ -231,14 +803,84 @@ func (p *doitProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		resp.Diagnostics.Append(validateRequestTimeout(requestTimeout)...)
 	}
 
+	retryPolicy, retryDiags := retryPolicyFromConfig(ctx, config.Retry)
+	resp.Diagnostics.Append(retryDiags...)
+
+	transport, transportDiags := transportSettingsFromConfig(config)
+	resp.Diagnostics.Append(transportDiags...)
+
+	tracing, tracingDiags := tracingSettingsFromConfig(ctx, config.Tracing)
+	resp.Diagnostics.Append(tracingDiags...)
+
+	breaker, breakerDiags := circuitBreakerFromConfig(ctx, config.CircuitBreaker)
+	resp.Diagnostics.Append(breakerDiags...)
+
+	wireLog, err := wireLoggerFromEnv()
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid HTTP Debug Configuration", err.Error())
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	tflog.Debug(ctx, "Request timeout configured", map[string]any{"timeout": requestTimeout.String()})
 
+	clientOpts := []ClientOption{WithRetryPolicy(retryPolicy)}
+	if tokenSource != nil {
+		clientOpts = append(clientOpts, WithTokenSource(tokenSource))
+	}
+	if !config.MaxRequestsPerSecond.IsNull() {
+		limit := config.MaxRequestsPerSecond.ValueFloat64()
+		burst := defaultBurst(limit)
+		if !config.Burst.IsNull() {
+			burst = int(config.Burst.ValueInt64())
+		}
+		tflog.Debug(ctx, "Client rate limit configured", map[string]any{
+			"requests_per_second": limit,
+			"burst":               burst,
+		})
+		clientOpts = append(clientOpts, WithRateLimit(limit, burst))
+	}
+	if config.CacheReferenceData.ValueBool() {
+		clientOpts = append(clientOpts, WithResponseCache())
+	}
+	if !transport.isZero() {
+		t, err := newTransport(transport)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid TLS Configuration", err.Error())
+			return
+		}
+		clientOpts = append(clientOpts, WithTransport(t))
+	}
+	if tracing.enabled {
+		tp, err := newTracerProvider(ctx, tracing, p.version)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("tracing"), "Unable to Configure Tracing", err.Error())
+			return
+		}
+		// A provider built by New rather than NewServer has no telemetry
+		// holder, and API attempts are then traced without operation spans.
+		if p.telemetry != nil {
+			p.telemetry.setTracerProvider(tp)
+		}
+		clientOpts = append(clientOpts, WithTracer(tp.Tracer(tracerName)))
+	}
+	if breaker != nil {
+		clientOpts = append(clientOpts, WithCircuitBreaker(breaker.threshold, breaker.coolDown))
+	}
+	if readOnly {
+		tflog.Info(ctx, "Read-only mode enabled")
+		clientOpts = append(clientOpts, WithReadOnly())
+	}
+	if wireLog != nil {
+		tflog.Info(ctx, "HTTP wire logging enabled", map[string]any{"max_body_bytes": wireLog.maxBody})
+		clientOpts = append(clientOpts, WithWireLogging(wireLog.maxBody))
+	}
+
 	// Create a new DoiT client using the configuration values
-	client, err := NewClient(ctx, host, doiTAPIToken, customerContext, req.TerraformVersion, p.version, requestTimeout)
+	client, err := NewClient(ctx, host, doiTAPIToken, customerContext, req.TerraformVersion, p.version, requestTimeout,
+		clientOpts...)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create DoiT API Client",
@@ -249,10 +891,44 @@ func (p *doitProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		return
 	}
 
+	var defaultLabels []string
+	if !config.DefaultLabels.IsNull() {
+		resp.Diagnostics.Append(config.DefaultLabels.ElementsAs(ctx, &defaultLabels, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	var defaults notificationDefaults
+	if !config.DefaultNotificationRecipients.IsNull() {
+		resp.Diagnostics.Append(config.DefaultNotificationRecipients.ElementsAs(ctx, &defaults.recipients, false)...)
+	}
+	if !config.DefaultSlackChannels.IsNull() {
+		resp.Diagnostics.Append(config.DefaultSlackChannels.ElementsAs(ctx, &defaults.slackChannels, false)...)
+	}
+	if !config.DefaultCollaborators.IsNull() {
+		resp.Diagnostics.Append(config.DefaultCollaborators.ElementsAs(ctx, &defaults.collaborators, false)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Make the DoiT client available during DataSource and Resource
 	// type Configure methods.
 	resp.DataSourceData = client
-	resp.ResourceData = client
+	resp.ResourceData = &providerData{
+		client:                       client,
+		defaultLabels:                defaultLabels,
+		notificationDefaults:         defaults,
+		deletionProtection:           config.DeletionProtection.ValueBool(),
+		validateDimensions:           config.ValidateDimensionsOnline.ValueBool(),
+		recipientValidation:          config.ValidateRecipients.ValueString(),
+		customerContext:              customerContext,
+		validateReferences:           !config.SkipReferenceValidation.ValueBool(),
+		explainDrift:                 explainDrift,
+		readOnly:                     readOnly,
+		detectConcurrentModification: config.DetectConcurrentModification.ValueBool(),
+	}
 
 	tflog.Info(ctx, "Configured DoiT client", map[string]any{"success": true})
 }
@@ -261,6 +937,7 @@ func (p *doitProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 func (p *doitProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewAllocationDataSource,
+		NewAllocationAnalysisDataSource,
 		NewBudgetDataSource,
 		NewReportDataSource,
 		NewAnnotationDataSource,
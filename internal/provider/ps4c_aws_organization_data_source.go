@@ -24,7 +24,8 @@ type ps4cAwsOrganizationDataSource struct {
 
 type ps4cAwsOrganizationDataSourceModel struct {
 	datasource_ps4c_aws_organization.Ps4cAwsOrganizationModel
-	Timeouts timeouts.Value `tfsdk:"timeouts"`
+	CustomerContext types.String   `tfsdk:"customer_context"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (d *ps4cAwsOrganizationDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -52,6 +53,7 @@ func (d *ps4cAwsOrganizationDataSource) Schema(ctx context.Context, _ datasource
 	s.MarkdownDescription = "Retrieve a single AWS Organization tracked by PerfectScale for Commitments (PS4C), by its management (payer) account ID."
 	s.Description = "Retrieve a single AWS Organization tracked by PerfectScale for Commitments (PS4C), by its management (payer) account ID."
 
+	s.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx)
 
 	resp.Schema = s
@@ -70,6 +72,7 @@ func (d *ps4cAwsOrganizationDataSource) Read(ctx context.Context, req datasource
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	// If the account ID is unknown (depends on a resource not yet created), set
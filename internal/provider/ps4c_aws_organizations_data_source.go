@@ -24,7 +24,8 @@ type ps4cAwsOrganizationsDataSource struct {
 
 type ps4cAwsOrganizationsDataSourceModel struct {
 	datasource_ps4c_aws_organizations.Ps4cAwsOrganizationsModel
-	Timeouts timeouts.Value `tfsdk:"timeouts"`
+	CustomerContext types.String   `tfsdk:"customer_context"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (d *ps4cAwsOrganizationsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -52,6 +53,7 @@ func (d *ps4cAwsOrganizationsDataSource) Schema(ctx context.Context, _ datasourc
 	s.MarkdownDescription = "List AWS Organizations tracked by PerfectScale for Commitments (PS4C)."
 	s.Description = "List AWS Organizations tracked by PerfectScale for Commitments (PS4C)."
 
+	s.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx)
 
 	resp.Schema = s
@@ -71,6 +73,7 @@ func (d *ps4cAwsOrganizationsDataSource) Read(ctx context.Context, req datasourc
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	// If any pagination input is unknown, return unknown for all computed attributes.
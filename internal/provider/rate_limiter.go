@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Adaptive rate limiting tuning. A 429 halves the current rate (multiplicative
+// decrease); every successful response then adds back a fixed share of the
+// configured rate (additive increase), so a single throttling episode is
+// recovered from after rateRecoverySuccesses successes.
+const (
+	// rateDecreaseFactor is applied to the current rate on a 429.
+	rateDecreaseFactor = 0.5
+
+	// rateFloorFraction bounds how far repeated 429s can push the rate down,
+	// as a fraction of the configured rate. Below this, the retry backoff is
+	// what keeps the client from hammering the API.
+	rateFloorFraction = 0.1
+
+	// rateRecoverySuccesses is how many successful responses it takes to climb
+	// from the floor back to the configured rate.
+	rateRecoverySuccesses = 20
+
+	// rateDecreaseCooldown collapses the burst of 429s that concurrent
+	// operations receive for the same throttling episode into one decrease.
+	// Without it, ten in-flight requests would cut the rate by 2^10.
+	rateDecreaseCooldown = time.Second
+)
+
+// rateLimiter is a token bucket shared by every request of one DCIRetryClient,
+// and therefore by every concurrent Terraform operation of the provider.
+//
+// Tokens accrue at rate per second up to burst. A request that finds no token
+// reserves one anyway, driving the balance negative, and sleeps until its
+// reservation matures; this keeps waiters in FIFO order without a queue.
+type rateLimiter struct {
+	mu sync.Mutex
+
+	// limit is the configured max_requests_per_second; rate never exceeds it.
+	limit float64
+	// rate is the current, adaptively lowered, requests per second.
+	rate  float64
+	burst float64
+
+	tokens       float64
+	last         time.Time
+	lastDecrease time.Time
+
+	// now is time.Now outside tests.
+	now func() time.Time
+}
+
+// newRateLimiter returns a limiter allowing limit requests per second with
+// bursts of up to burst requests. The bucket starts full.
+func newRateLimiter(limit float64, burst int) *rateLimiter {
+	return &rateLimiter{
+		limit:  limit,
+		rate:   limit,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		now:    time.Now,
+	}
+}
+
+// defaultBurst is the burst used when max_requests_per_second is set without
+// burst: one second's worth of requests, and at least one.
+func defaultBurst(limit float64) int {
+	return max(1, int(math.Ceil(limit)))
+}
+
+// advance credits the tokens earned since the last call. Callers hold mu.
+func (l *rateLimiter) advance(now time.Time) {
+	if !l.last.IsZero() {
+		l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+}
+
+// reserve takes a token and returns how long the caller must wait before
+// using it.
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.advance(l.now())
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// cancel returns a token whose reservation was abandoned.
+func (l *rateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.advance(l.now())
+	l.tokens = min(l.burst, l.tokens+1)
+}
+
+// Wait blocks until a request may be sent, or the context is done.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	wait := l.reserve()
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.cancel()
+		return ctx.Err()
+	}
+}
+
+// throttled lowers the rate after a 429. It returns the new rate and whether
+// it changed; a 429 within rateDecreaseCooldown of the previous decrease is
+// treated as part of the same episode.
+func (l *rateLimiter) throttled() (float64, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if !l.lastDecrease.IsZero() && now.Sub(l.lastDecrease) < rateDecreaseCooldown {
+		return l.rate, false
+	}
+	floor := l.limit * rateFloorFraction
+	if l.rate <= floor {
+		return l.rate, false
+	}
+
+	// Credit tokens at the old rate before switching, and drop any burst
+	// headroom: the API just said it is already saturated.
+	l.advance(now)
+	l.tokens = min(l.tokens, 0)
+	l.rate = max(floor, l.rate*rateDecreaseFactor)
+	l.lastDecrease = now
+	return l.rate, true
+}
+
+// succeeded raises a lowered rate back toward the configured limit.
+func (l *rateLimiter) succeeded() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.rate >= l.limit {
+		return
+	}
+	l.advance(l.now())
+	step := l.limit * (1 - rateFloorFraction) / rateRecoverySuccesses
+	l.rate += step
+	// Snap to the limit rather than stopping a rounding error short of it.
+	if l.rate > l.limit-step/2 {
+		l.rate = l.limit
+	}
+}
@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced time source for rateLimiter.now.
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func newTestRateLimiter(limit float64, burst int) (*rateLimiter, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	l := newRateLimiter(limit, burst)
+	l.now = clock.now
+	return l, clock
+}
+
+func TestRateLimiter_Reserve(t *testing.T) {
+	t.Parallel()
+	l, clock := newTestRateLimiter(2, 2)
+
+	// The bucket starts full: a burst goes out immediately.
+	for i := range 2 {
+		if wait := l.reserve(); wait != 0 {
+			t.Fatalf("reserve #%d wait = %s, want 0", i+1, wait)
+		}
+	}
+	// Then requests are spaced at 1/rate, each queued behind the previous one.
+	if wait := l.reserve(); wait != 500*time.Millisecond {
+		t.Errorf("third reserve wait = %s, want 500ms", wait)
+	}
+	if wait := l.reserve(); wait != time.Second {
+		t.Errorf("fourth reserve wait = %s, want 1s", wait)
+	}
+
+	// Tokens accrue with time but never beyond burst.
+	clock.t = clock.t.Add(time.Hour)
+	for i := range 2 {
+		if wait := l.reserve(); wait != 0 {
+			t.Fatalf("reserve #%d after refill wait = %s, want 0", i+1, wait)
+		}
+	}
+	if wait := l.reserve(); wait == 0 {
+		t.Error("expected a wait once the refilled burst is spent")
+	}
+}
+
+func TestRateLimiter_Throttled(t *testing.T) {
+	t.Parallel()
+	l, clock := newTestRateLimiter(10, 10)
+
+	if rate, lowered := l.throttled(); !lowered || rate != 5 {
+		t.Fatalf("throttled() = %v, %v, want 5, true", rate, lowered)
+	}
+	// Concurrent 429s from the same episode count once.
+	if rate, lowered := l.throttled(); lowered || rate != 5 {
+		t.Errorf("throttled() within cooldown = %v, %v, want 5, false", rate, lowered)
+	}
+	// A 429 drops the burst headroom.
+	if wait := l.reserve(); wait == 0 {
+		t.Error("expected a wait right after a 429")
+	}
+
+	// Repeated episodes stop at the floor.
+	for range 10 {
+		clock.t = clock.t.Add(rateDecreaseCooldown)
+		l.throttled()
+	}
+	if want := 10 * rateFloorFraction; l.rate != want {
+		t.Errorf("rate after repeated 429s = %v, want floor %v", l.rate, want)
+	}
+
+	// Successes climb back to, and not past, the configured rate.
+	for range rateRecoverySuccesses {
+		l.succeeded()
+	}
+	if l.rate != l.limit {
+		t.Errorf("rate after %d successes = %v, want %v", rateRecoverySuccesses, l.rate, l.limit)
+	}
+	l.succeeded()
+	if l.rate != l.limit {
+		t.Errorf("rate exceeded limit: %v", l.rate)
+	}
+}
+
+func TestRateLimiter_WaitCanceled(t *testing.T) {
+	t.Parallel()
+	l := newRateLimiter(0.1, 1)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("first Wait: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Wait() error = %v, want deadline exceeded", err)
+	}
+	// The abandoned reservation is returned, so the next caller is not queued
+	// behind a request that was never sent.
+	if l.tokens < -1e-3 {
+		t.Errorf("tokens = %v after cancel, want the reservation refunded", l.tokens)
+	}
+}
+
+// TestDCIRetryClient_RateLimitAdapts confirms a 429 lowers the shared rate and
+// the retried request's success starts raising it again.
+func TestDCIRetryClient_RateLimitAdapts(t *testing.T) {
+	t.Parallel()
+
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := newTestRetryClient(5*time.Second, constantBackOff(time.Millisecond))
+	client.limiter = newRateLimiter(100, 100)
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	want := 100*rateDecreaseFactor + 100*(1-rateFloorFraction)/rateRecoverySuccesses
+	if got := client.limiter.rate; got != want {
+		t.Errorf("rate = %v, want %v", got, want)
+	}
+}
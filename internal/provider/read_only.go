@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// readOnlyEnvVar enables read-only mode when the provider's read_only is not
+// set, so a CI pipeline can enforce it without changing the configuration.
+const readOnlyEnvVar = "DOIT_READ_ONLY"
+
+// errReadOnly is returned by DCIRetryClient for a request that could change
+// data while the provider is read-only.
+var errReadOnly = errors.New("the provider is read-only")
+
+// readOnlyPOSTRoutes are the routes, as returned by urlTemplate, that use
+// POST only to carry a query. They read data, so data sources using them keep
+// working in read-only mode.
+var readOnlyPOSTRoutes = []string{
+	"/analytics/v1/reports/query",
+	"/ava/v1/askSync",
+	"/clouddiagrams/v1/scheme/find",
+	"/clouddiagrams/v1/scheme/get",
+	"/clouddiagrams/v1/scheme/search",
+	"/clouddiagrams/v1/statussheet/{id}/get",
+}
+
+// allowedWhenReadOnly reports whether req cannot change data: a GET or HEAD,
+// or a POST to one of readOnlyPOSTRoutes.
+func allowedWhenReadOnly(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		return true
+	case http.MethodPost:
+		return slices.Contains(readOnlyPOSTRoutes, urlTemplate(req.URL.Path))
+	default:
+		return false
+	}
+}
+
+// checkReadOnly returns an error diagnostic when the provider's read_only is
+// set. Call at the start of Create, Update and Delete, before any API
+// request; DCIRetryClient rejects writes as well, as a second line of
+// defense. typeName is the resource type, e.g. "doit_folder", and operation
+// "create", "update" or "delete".
+func checkReadOnly(readOnly bool, typeName, operation string) diag.Diagnostics {
+	if !readOnly {
+		return nil
+	}
+
+	var diags diag.Diagnostics
+	diags.AddError(
+		"Provider Is Read-Only",
+		fmt.Sprintf("Cannot %s %s: the provider's read_only is true (set in the provider configuration or with %s), "+
+			"so no DoiT object can be created, updated or deleted. Plans still work; apply with a provider "+
+			"configuration that is allowed to make changes.", operation, typeName, readOnlyEnvVar),
+	)
+	return diags
+}
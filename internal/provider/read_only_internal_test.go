@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestAllowedWhenReadOnly(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		method, path string
+		want         bool
+	}{
+		{http.MethodGet, "/analytics/v1/reports/abc", true},
+		{http.MethodHead, "/analytics/v1/reports/abc", true},
+		{http.MethodPost, "/analytics/v1/reports/query", true},
+		{http.MethodPost, "/clouddiagrams/v1/statussheet/s1/get", true},
+		{http.MethodPost, "/analytics/v1/reports", false},
+		{http.MethodPatch, "/analytics/v1/reports/abc", false},
+		{http.MethodPut, "/analytics/v1/folders/abc", false},
+		{http.MethodDelete, "/analytics/v1/reports/abc", false},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, "https://api.doit.com"+tt.path, nil)
+		if got := allowedWhenReadOnly(req); got != tt.want {
+			t.Errorf("allowedWhenReadOnly(%s %s) = %v, want %v", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestDCIRetryClient_ReadOnlyRejectsWrites(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	c := newTestRetryClient(5*time.Second, constantBackOff(time.Millisecond))
+	c.readOnly = true
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodDelete, server.URL+"/analytics/v1/reports/abc", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Do(req); !errors.Is(err, errReadOnly) {
+		t.Errorf("DELETE error = %v, want errReadOnly", err)
+	}
+	if calls.Load() != 0 {
+		t.Fatal("a write reached the server in read-only mode")
+	}
+
+	resp, err := doGet(context.Background(), t, c, server.URL+"/analytics/v1/reports/abc")
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Errorf("GET = %v, %v; want 200", resp, err)
+	}
+}
+
+// TestReadOnly_ResourceOperationsFail verifies that Create, Update and Delete
+// fail before touching the API, naming the resource type.
+func TestReadOnly_ResourceOperationsFail(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	r := &folderResource{readOnly: true}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	sch := schemaResp.Schema
+	nullRaw := tftypes.NewValue(sch.Type().TerraformType(ctx), nil)
+	plan := tfsdk.Plan{Raw: nullRaw, Schema: sch}
+	state := tfsdk.State{Raw: nullRaw, Schema: sch}
+
+	// r.client is nil: any API call would panic.
+	createResp := resource.CreateResponse{State: state}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+	updateResp := resource.UpdateResponse{State: state}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: state}, &updateResp)
+	deleteResp := resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, &deleteResp)
+
+	for op, diags := range map[string]diag.Diagnostics{
+		"create": createResp.Diagnostics,
+		"update": updateResp.Diagnostics,
+		"delete": deleteResp.Diagnostics,
+	} {
+		if len(diags) != 1 || diags[0].Summary() != "Provider Is Read-Only" {
+			t.Fatalf("%s diagnostics = %v, want one read-only error", op, diags)
+		}
+	}
+	if got := createResp.Diagnostics[0].Detail(); !strings.HasPrefix(got, "Cannot create doit_folder:") {
+		t.Errorf("create detail = %q, want it to name doit_folder", got)
+	}
+}
@@ -0,0 +1,263 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/doitintl/terraform-provider-doit/internal/provider/models"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Recipient validation modes of the provider's validate_recipients.
+const (
+	recipientValidationOff    = "off"
+	recipientValidationWarn   = "warn"
+	recipientValidationStrict = "strict"
+)
+
+// recipientValidator checks planned notification recipients, collaborators
+// and sharing users when the provider's validate_recipients is set. The API
+// accepts any email address, so a misspelled or external recipient silently
+// never receives a notification.
+//
+// An address passes when it belongs to a user of the tenant
+// (/iam/v1/users) or its domain is one of the customer's domains or allowed
+// invite domains. The customer is the object's or the provider's
+// customer_context; without one, the API token's own domain stands in for
+// the customer's domains, as the token does not reveal its customer ID.
+type recipientValidator struct {
+	client *models.ClientWithResponses
+	// providerCustomerContext is the provider's customer_context.
+	providerCustomerContext string
+
+	users   map[string]bool
+	domains []string
+}
+
+// validateRecipients validates the email addresses planned at the string
+// paths matched by each expression, e.g. path.MatchRoot("recipients").
+// AtAnyListIndex(). Mismatches are warnings, or errors when mode is strict.
+//
+// Addresses already in state are not checked again, so a user who leaves the
+// tenant does not block unrelated changes. Values that are not email
+// addresses are left to the resources' own validators. When the API cannot
+// be reached, validation is skipped with a warning.
+func validateRecipients(ctx context.Context, client *models.ClientWithResponses, mode, providerCustomerContext string, plan tfsdk.Plan, state tfsdk.State, exprs ...path.Expression) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if mode == "" || mode == recipientValidationOff || plan.Raw.IsNull() {
+		return diags
+	}
+
+	var customerContext types.String
+	diags.Append(plan.GetAttribute(ctx, path.Root("customer_context"), &customerContext)...)
+	if diags.HasError() {
+		return diags
+	}
+	ctx = withCustomerContext(ctx, customerContext)
+
+	applied := map[string]bool{}
+	if !state.Raw.IsNull() {
+		for _, expr := range exprs {
+			emails, _, d := plannedStrings(ctx, state, expr)
+			if d.HasError() {
+				continue
+			}
+			for _, email := range emails {
+				applied[strings.ToLower(email)] = true
+			}
+		}
+	}
+
+	v := &recipientValidator{client: client, providerCustomerContext: providerCustomerContext}
+	for _, expr := range exprs {
+		emails, paths, d := plannedStrings(ctx, plan, expr)
+		diags.Append(d...)
+		if d.HasError() {
+			return diags
+		}
+		for i, email := range emails {
+			if applied[strings.ToLower(email)] || !strings.Contains(email, "@") {
+				continue
+			}
+			known, err := v.known(ctx, email)
+			if err != nil {
+				diags.AddWarning(
+					"Recipient Validation Skipped",
+					"validate_recipients is set, but users and domains could not be read from the DoiT API, "+
+						"so email addresses were not checked: "+err.Error(),
+				)
+				return diags
+			}
+			if known {
+				continue
+			}
+			summary := "Unknown Recipient"
+			detail := unknownRecipientDetail(email, v.domains)
+			if mode == recipientValidationStrict {
+				diags.AddAttributeError(paths[i], summary, detail)
+			} else {
+				diags.AddAttributeWarning(paths[i], summary, detail)
+			}
+		}
+	}
+	return diags
+}
+
+// pathMatcher is implemented by tfsdk.Plan and tfsdk.State.
+type pathMatcher interface {
+	attributeGetter
+	PathMatches(ctx context.Context, expr path.Expression) (path.Paths, diag.Diagnostics)
+}
+
+// plannedStrings returns the known, non-empty string values at the paths
+// matched by expr, with their paths.
+func plannedStrings(ctx context.Context, data pathMatcher, expr path.Expression) ([]string, []path.Path, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	paths, d := data.PathMatches(ctx, expr)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, nil, diags
+	}
+
+	var emails []string
+	var emailPaths []path.Path
+	for _, p := range paths {
+		// PathMatches also returns null or unknown parents of the expression.
+		if !expr.Matches(p) {
+			continue
+		}
+		var v types.String
+		diags.Append(data.GetAttribute(ctx, p, &v)...)
+		if diags.HasError() {
+			return nil, nil, diags
+		}
+		if v.IsNull() || v.IsUnknown() || v.ValueString() == "" {
+			continue
+		}
+		emails = append(emails, v.ValueString())
+		emailPaths = append(emailPaths, p)
+	}
+	return emails, emailPaths, diags
+}
+
+// unknownRecipientDetail explains why email failed validation.
+func unknownRecipientDetail(email string, domains []string) string {
+	detail := fmt.Sprintf("%q is not a user of this DoiT account", email)
+	if len(domains) == 0 {
+		return detail + ", and no allowed domains are known. Check the address for typos, or invite the user first."
+	}
+	return fmt.Sprintf("%s, and its domain %q is not one of the allowed domains (%s). "+
+		"Check the address for typos, or invite the user first.", detail, emailDomain(email), strings.Join(domains, ", "))
+}
+
+// known reports whether email belongs to a user of the tenant or to one of
+// the customer's allowed domains. Users and domains are read once per plan.
+func (v *recipientValidator) known(ctx context.Context, email string) (bool, error) {
+	if v.users == nil {
+		users, err := v.listUsers(ctx)
+		if err != nil {
+			return false, err
+		}
+		v.users = users
+	}
+	if v.users[strings.ToLower(email)] {
+		return true, nil
+	}
+
+	if v.domains == nil {
+		domains, err := v.allowedDomains(ctx)
+		if err != nil {
+			return false, err
+		}
+		v.domains = domains
+	}
+	return emailDomainAllowed(email, v.domains), nil
+}
+
+// listUsers returns the lower-cased email addresses of the tenant's users,
+// including invited ones.
+func (v *recipientValidator) listUsers(ctx context.Context) (map[string]bool, error) {
+	resp, err := v.client.ListUsersWithResponse(ctx, &models.ListUsersParams{})
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusOK || resp.JSON200 == nil {
+		return nil, fmt.Errorf("unexpected status %d listing users", resp.StatusCode())
+	}
+	users := map[string]bool{}
+	if resp.JSON200.Users != nil {
+		for _, u := range *resp.JSON200.Users {
+			if u.Email != nil {
+				users[strings.ToLower(*u.Email)] = true
+			}
+		}
+	}
+	return users, nil
+}
+
+// allowedDomains returns the customer's domains and allowed invite domains,
+// or the API token's domain when the customer is not known.
+func (v *recipientValidator) allowedDomains(ctx context.Context) ([]string, error) {
+	customerID, ok := customerContextFromContext(ctx)
+	if !ok {
+		customerID = v.providerCustomerContext
+	}
+
+	var domains []string
+	if customerID == "" {
+		resp, err := v.client.ValidateWithResponse(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode() != http.StatusOK || resp.JSON200 == nil {
+			return nil, fmt.Errorf("unexpected status %d validating the API token", resp.StatusCode())
+		}
+		if resp.JSON200.Domain != nil {
+			domains = append(domains, *resp.JSON200.Domain)
+		}
+	} else {
+		resp, err := v.client.GetCustomerWithResponse(ctx, customerID)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode() != http.StatusOK || resp.JSON200 == nil {
+			return nil, fmt.Errorf("unexpected status %d reading customer %s", resp.StatusCode(), customerID)
+		}
+		c := resp.JSON200
+		if c.PrimaryDomain != nil {
+			domains = append(domains, *c.PrimaryDomain)
+		}
+		if c.Domains != nil {
+			domains = append(domains, *c.Domains...)
+		}
+		if c.Settings != nil && c.Settings.AllowedInviteDomains != nil {
+			domains = append(domains, *c.Settings.AllowedInviteDomains...)
+		}
+	}
+
+	allowed := []string{}
+	for _, d := range domains {
+		d = strings.ToLower(strings.TrimSpace(d))
+		if d != "" && !slices.Contains(allowed, d) {
+			allowed = append(allowed, d)
+		}
+	}
+	return allowed, nil
+}
+
+// emailDomain returns the lower-cased domain of email.
+func emailDomain(email string) string {
+	return strings.ToLower(email[strings.LastIndex(email, "@")+1:])
+}
+
+// emailDomainAllowed reports whether the domain of email is one of domains,
+// which are lower case.
+func emailDomainAllowed(email string, domains []string) bool {
+	return slices.Contains(domains, emailDomain(email))
+}
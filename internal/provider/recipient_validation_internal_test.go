@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// recipientTestHandler serves two users, a token of domain example.com and
+// customer cust-1, which allows partner.io, counting requests.
+func recipientTestHandler(calls *atomic.Int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/iam/v1/users":
+			_, _ = io.WriteString(w, `{"rowCount":2,"users":[
+				{"email":"Alice@corp.com","status":"active"},
+				{"email":"bob@contractor.net","status":"invited"}]}`)
+		case "/auth/v1/validate":
+			_, _ = io.WriteString(w, `{"email":"ci@example.com","domain":"example.com"}`)
+		case "/customers/v1/customers/cust-1":
+			_, _ = io.WriteString(w, `{"id":"cust-1","primaryDomain":"corp.com","domains":["corp.com","corp.de"],
+				"settings":{"allowedInviteDomains":["Partner.io"]}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func TestBudgetResource_ModifyPlan_ValidateRecipients(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	(&budgetResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	sch := schemaResp.Schema
+	nullRaw := tftypes.NewValue(sch.Type().TerraformType(ctx), nil)
+
+	planWith := func(t *testing.T, customerContext string, recipients ...string) tfsdk.Plan {
+		t.Helper()
+		plan := tfsdk.Plan{Raw: nullRaw, Schema: sch}
+		vals := make([]attr.Value, len(recipients))
+		for i, r := range recipients {
+			vals[i] = types.StringValue(r)
+		}
+		diags := plan.SetAttribute(ctx, path.Root("recipients"), types.ListValueMust(types.StringType, vals))
+		if customerContext != "" {
+			diags.Append(plan.SetAttribute(ctx, path.Root("customer_context"), types.StringValue(customerContext))...)
+		}
+		if diags.HasError() {
+			t.Fatalf("setting plan: %v", diags)
+		}
+		return plan
+	}
+	modifyPlan := func(t *testing.T, r *budgetResource, state tfsdk.State, plan tfsdk.Plan) diag.Diagnostics {
+		t.Helper()
+		config := tfsdk.Config{Raw: plan.Raw, Schema: sch}
+		resp := resource.ModifyPlanResponse{Plan: plan}
+		r.ModifyPlan(ctx, resource.ModifyPlanRequest{Config: config, State: state, Plan: plan}, &resp)
+		return resp.Diagnostics
+	}
+	noState := tfsdk.State{Raw: nullRaw, Schema: sch}
+	recipientPath := func(i int) path.Path { return path.Root("recipients").AtListIndex(i) }
+
+	t.Run("warn", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+		r := &budgetResource{client: newImportTestClient(t, recipientTestHandler(&calls)), recipientValidation: recipientValidationWarn}
+		diags := modifyPlan(t, r, noState, planWith(t, "",
+			"alice@corp.com", "BOB@contractor.net", "ops@example.com", "alcie@corp.com", "#not-an-email"))
+
+		if diags.HasError() || len(diags) != 1 {
+			t.Fatalf("diagnostics = %v, want a single warning", diags)
+		}
+		d, ok := diags[0].(diag.DiagnosticWithPath)
+		want := `"alcie@corp.com" is not a user of this DoiT account, and its domain "corp.com" is not one of the allowed domains (example.com).`
+		if !ok || !d.Path().Equal(recipientPath(3)) || !strings.HasPrefix(d.Detail(), want) {
+			t.Errorf("diagnostic = %v, want a warning at %s: %s", diags[0], recipientPath(3), want)
+		}
+		// The users are listed once, and the token's domain read once.
+		if got := calls.Load(); got != 2 {
+			t.Errorf("API received %d requests, want 2", got)
+		}
+	})
+
+	t.Run("strict with customer domains", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+		r := &budgetResource{client: newImportTestClient(t, recipientTestHandler(&calls)), recipientValidation: recipientValidationStrict}
+		diags := modifyPlan(t, r, noState, planWith(t, "cust-1",
+			"new@corp.de", "someone@partner.io", "ops@example.com"))
+
+		if diags.ErrorsCount() != 1 || len(diags) != 1 {
+			t.Fatalf("diagnostics = %v, want a single error", diags)
+		}
+		d, ok := diags[0].(diag.DiagnosticWithPath)
+		if !ok || !d.Path().Equal(recipientPath(2)) || !strings.Contains(d.Detail(), "(corp.com, corp.de, partner.io)") {
+			t.Errorf("diagnostic = %v, want an error at %s listing the customer's domains", diags[0], recipientPath(2))
+		}
+	})
+
+	t.Run("provider customer context", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+		r := &budgetResource{
+			client:              newImportTestClient(t, recipientTestHandler(&calls)),
+			recipientValidation: recipientValidationStrict,
+			customerContext:     "cust-1",
+		}
+		if diags := modifyPlan(t, r, noState, planWith(t, "", "someone@partner.io")); diags.HasError() {
+			t.Errorf("unexpected diagnostics: %v", diags)
+		}
+	})
+
+	t.Run("off", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+		r := &budgetResource{client: newImportTestClient(t, recipientTestHandler(&calls)), recipientValidation: recipientValidationOff}
+		if diags := modifyPlan(t, r, noState, planWith(t, "", "nobody@nowhere.org")); len(diags) != 0 {
+			t.Errorf("unexpected diagnostics: %v", diags)
+		}
+		if calls.Load() != 0 {
+			t.Error("the API was called with validate_recipients off")
+		}
+	})
+
+	t.Run("applied recipients are not revalidated", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+		r := &budgetResource{client: newImportTestClient(t, recipientTestHandler(&calls)), recipientValidation: recipientValidationStrict}
+		state := tfsdk.State{Raw: planWith(t, "", "former@corp.com").Raw, Schema: sch}
+		if diags := modifyPlan(t, r, state, planWith(t, "", "alice@corp.com", "former@corp.com")); diags.HasError() {
+			t.Errorf("unexpected diagnostics: %v", diags)
+		}
+		if got := calls.Load(); got != 1 {
+			t.Errorf("API received %d requests, want only the user list", got)
+		}
+	})
+
+	t.Run("API failure warns", func(t *testing.T) {
+		t.Parallel()
+
+		r := &budgetResource{
+			client: newImportTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+			}),
+			recipientValidation: recipientValidationStrict,
+		}
+		diags := modifyPlan(t, r, noState, planWith(t, "", "alice@corp.com"))
+		if diags.HasError() || diags.WarningsCount() != 1 || !strings.Contains(diags.Warnings()[0].Detail(), "status 403") {
+			t.Errorf("diagnostics = %v, want one warning about the failed request", diags)
+		}
+	})
+}
@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/doitintl/terraform-provider-doit/internal/provider/models"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// rootFolderID is the folder_id and parent_folder_id of top-level objects.
+const rootFolderID = "root"
+
+// referenceGetters fetch one object of each kind that can be referenced by
+// ID, returning the response status. They use the cheapest GET of the kind:
+// reports are read through their config, as GET /reports/{id} runs the query.
+var referenceGetters = map[string]func(ctx context.Context, client *models.ClientWithResponses, id string) (int, error){
+	"alert": func(ctx context.Context, client *models.ClientWithResponses, id string) (int, error) {
+		resp, err := client.GetAlertWithResponse(ctx, id)
+		if err != nil {
+			return 0, err
+		}
+		return resp.StatusCode(), nil
+	},
+	"allocation": func(ctx context.Context, client *models.ClientWithResponses, id string) (int, error) {
+		resp, err := client.GetAllocationWithResponse(ctx, id)
+		if err != nil {
+			return 0, err
+		}
+		return resp.StatusCode(), nil
+	},
+	"annotation": func(ctx context.Context, client *models.ClientWithResponses, id string) (int, error) {
+		resp, err := client.GetAnnotationWithResponse(ctx, id)
+		if err != nil {
+			return 0, err
+		}
+		return resp.StatusCode(), nil
+	},
+	"budget": func(ctx context.Context, client *models.ClientWithResponses, id string) (int, error) {
+		resp, err := client.GetBudgetWithResponse(ctx, id)
+		if err != nil {
+			return 0, err
+		}
+		return resp.StatusCode(), nil
+	},
+	"folder": func(ctx context.Context, client *models.ClientWithResponses, id string) (int, error) {
+		resp, err := client.GetFolderWithResponse(ctx, id)
+		if err != nil {
+			return 0, err
+		}
+		return resp.StatusCode(), nil
+	},
+	"label": func(ctx context.Context, client *models.ClientWithResponses, id string) (int, error) {
+		resp, err := client.GetLabelWithResponse(ctx, id)
+		if err != nil {
+			return 0, err
+		}
+		return resp.StatusCode(), nil
+	},
+	"report": func(ctx context.Context, client *models.ClientWithResponses, id string) (int, error) {
+		resp, err := client.GetReportConfigWithResponse(ctx, id)
+		if err != nil {
+			return 0, err
+		}
+		return resp.StatusCode(), nil
+	},
+}
+
+// referenceCheck names the string attributes holding IDs of one kind of
+// object, e.g. {expr: path.MatchRoot("folder_id"), kind: "folder"}.
+type referenceCheck struct {
+	expr path.Expression
+	kind string
+	// kindAttr, when set instead of kind, names a sibling attribute holding
+	// the kind of each reference, as in doit_label_assignments assignments.
+	kindAttr string
+}
+
+// validateReferences checks that the objects referenced by the planned IDs
+// exist, unless the provider's skip_reference_validation is set. A deleted
+// or mistyped folder, report or label ID is otherwise reported by the API as
+// a 400 during apply, with no hint of which attribute holds it.
+//
+// Missing objects are reported as errors against the attribute holding the
+// ID. IDs already in state are not checked again, so an object deleted
+// outside Terraform does not block unrelated changes, and each ID is
+// fetched once per plan. Kinds without a cheap GET, such as metrics, are
+// left to the API. When the API cannot be reached, or answers with anything
+// but 200 or 404, validation is skipped with a warning.
+func validateReferences(ctx context.Context, client *models.ClientWithResponses, plan tfsdk.Plan, state tfsdk.State, checks ...referenceCheck) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if plan.Raw.IsNull() {
+		return diags
+	}
+
+	var customerContext types.String
+	diags.Append(plan.GetAttribute(ctx, path.Root("customer_context"), &customerContext)...)
+	if diags.HasError() {
+		return diags
+	}
+	ctx = withCustomerContext(ctx, customerContext)
+
+	applied := map[string]bool{}
+	if !state.Raw.IsNull() {
+		for _, c := range checks {
+			ids, _, d := plannedStrings(ctx, state, c.expr)
+			if d.HasError() {
+				continue
+			}
+			for _, id := range ids {
+				applied[id] = true
+			}
+		}
+	}
+
+	checked := map[string]bool{}
+	for _, c := range checks {
+		ids, paths, d := plannedStrings(ctx, plan, c.expr)
+		diags.Append(d...)
+		if d.HasError() {
+			return diags
+		}
+		for i, id := range ids {
+			kind := c.kind
+			if c.kindAttr != "" {
+				var v types.String
+				d := plan.GetAttribute(ctx, paths[i].ParentPath().AtName(c.kindAttr), &v)
+				diags.Append(d...)
+				if d.HasError() {
+					return diags
+				}
+				kind = v.ValueString()
+			}
+			get, ok := referenceGetters[kind]
+			if !ok || applied[id] || (kind == "folder" && id == rootFolderID) {
+				continue
+			}
+			key := kind + "/" + id
+			if checked[key] {
+				continue
+			}
+			checked[key] = true
+
+			status, err := get(ctx, client, id)
+			if err == nil && status != http.StatusOK && status != http.StatusNotFound {
+				err = fmt.Errorf("unexpected status %d reading %s %s", status, kind, id)
+			}
+			if err != nil {
+				diags.AddWarning(
+					"Reference Validation Skipped",
+					"Referenced objects could not be read from the DoiT API, so their IDs were not checked: "+err.Error()+
+						"\n\nSet skip_reference_validation in the provider configuration to turn the check off.",
+				)
+				return diags
+			}
+			if status == http.StatusNotFound {
+				diags.AddAttributeError(paths[i],
+					"Referenced "+strings.ToUpper(kind[:1])+kind[1:]+" Not Found",
+					fmt.Sprintf("No %s with ID %q exists, or it is not visible to this API key. "+
+						"Check the ID, or whether the %s was deleted outside Terraform.", kind, id, kind),
+				)
+			}
+		}
+	}
+	return diags
+}
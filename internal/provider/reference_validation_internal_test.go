@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// referenceTestHandler serves label lbl-1, report rep-1 and budget bud-1,
+// counting requests. Any other object is not found.
+func referenceTestHandler(calls *atomic.Int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/analytics/v1/labels/lbl-1":
+			_, _ = io.WriteString(w, `{"id":"lbl-1","name":"team"}`)
+		case "/analytics/v1/reports/rep-1/config":
+			_, _ = io.WriteString(w, `{"id":"rep-1"}`)
+		case "/analytics/v1/budgets/bud-1":
+			_, _ = io.WriteString(w, `{"id":"bud-1"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"error":"not found"}`)
+		}
+	}
+}
+
+func TestLabelAssignmentsResource_ModifyPlan_ValidateReferences(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	(&labelAssignmentsResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	sch := schemaResp.Schema
+	nullRaw := tftypes.NewValue(sch.Type().TerraformType(ctx), nil)
+
+	assignment := func(objectType, objectID string) types.Object {
+		return types.ObjectValueMust(assignmentAttrTypes(), map[string]attr.Value{
+			"object_id":   types.StringValue(objectID),
+			"object_type": types.StringValue(objectType),
+		})
+	}
+	planWith := func(t *testing.T, labelID string, assignments ...types.Object) tfsdk.Plan {
+		t.Helper()
+		plan := tfsdk.Plan{Raw: nullRaw, Schema: sch}
+		vals := make([]attr.Value, len(assignments))
+		for i, a := range assignments {
+			vals[i] = a
+		}
+		diags := plan.SetAttribute(ctx, path.Root("label_id"), types.StringValue(labelID))
+		diags.Append(plan.SetAttribute(ctx, path.Root("assignments"),
+			types.SetValueMust(types.ObjectType{AttrTypes: assignmentAttrTypes()}, vals))...)
+		if diags.HasError() {
+			t.Fatalf("setting plan: %v", diags)
+		}
+		return plan
+	}
+	modifyPlan := func(t *testing.T, r *labelAssignmentsResource, state tfsdk.State, plan tfsdk.Plan) diag.Diagnostics {
+		t.Helper()
+		resp := resource.ModifyPlanResponse{Plan: plan}
+		r.ModifyPlan(ctx, resource.ModifyPlanRequest{Config: tfsdk.Config{Raw: plan.Raw, Schema: sch}, State: state, Plan: plan}, &resp)
+		return resp.Diagnostics
+	}
+	noState := tfsdk.State{Raw: nullRaw, Schema: sch}
+
+	t.Run("missing objects", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+		r := &labelAssignmentsResource{client: newImportTestClient(t, referenceTestHandler(&calls)), validateReferences: true}
+		gone := assignment("report", "rep-gone")
+		diags := modifyPlan(t, r, noState, planWith(t, "lbl-gone",
+			assignment("report", "rep-1"), gone, assignment("budget", "bud-1"), assignment("metric", "met-1")))
+
+		if diags.ErrorsCount() != 2 || len(diags) != 2 {
+			t.Fatalf("diagnostics = %v, want two errors", diags)
+		}
+		want := []struct {
+			path    path.Path
+			summary string
+		}{
+			{path.Root("label_id"), "Referenced Label Not Found"},
+			{path.Root("assignments").AtSetValue(gone).AtName("object_id"), "Referenced Report Not Found"},
+		}
+		for i, w := range want {
+			d, ok := diags[i].(diag.DiagnosticWithPath)
+			if !ok || !d.Path().Equal(w.path) || d.Summary() != w.summary {
+				t.Errorf("diagnostic %d = %v, want %q at %s", i, diags[i], w.summary, w.path)
+			}
+		}
+		// Metrics have no cheap GET and are left to the API.
+		if got := calls.Load(); got != 4 {
+			t.Errorf("API received %d requests, want 4", got)
+		}
+	})
+
+	t.Run("applied references are not checked again", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+		r := &labelAssignmentsResource{client: newImportTestClient(t, referenceTestHandler(&calls)), validateReferences: true}
+		state := tfsdk.State{Raw: planWith(t, "lbl-gone", assignment("report", "rep-gone")).Raw, Schema: sch}
+		diags := modifyPlan(t, r, state, planWith(t, "lbl-gone", assignment("report", "rep-gone"), assignment("report", "rep-1")))
+		if len(diags) != 0 {
+			t.Errorf("unexpected diagnostics: %v", diags)
+		}
+		if got := calls.Load(); got != 1 {
+			t.Errorf("API received %d requests, want only rep-1", got)
+		}
+	})
+
+	t.Run("skipped", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+		r := &labelAssignmentsResource{client: newImportTestClient(t, referenceTestHandler(&calls))}
+		if diags := modifyPlan(t, r, noState, planWith(t, "lbl-gone")); len(diags) != 0 {
+			t.Errorf("unexpected diagnostics: %v", diags)
+		}
+		if calls.Load() != 0 {
+			t.Error("the API was called with skip_reference_validation set")
+		}
+	})
+
+	t.Run("API failure warns", func(t *testing.T) {
+		t.Parallel()
+
+		r := &labelAssignmentsResource{
+			client: newImportTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			}),
+			validateReferences: true,
+		}
+		diags := modifyPlan(t, r, noState, planWith(t, "lbl-1"))
+		if diags.HasError() || diags.WarningsCount() != 1 || !strings.Contains(diags.Warnings()[0].Detail(), "status 500") {
+			t.Errorf("diagnostics = %v, want one warning about the failed request", diags)
+		}
+	})
+}
+
+func TestAllocationResource_ModifyPlan_ValidateFolder(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	(&allocationResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	sch := schemaResp.Schema
+	nullRaw := tftypes.NewValue(sch.Type().TerraformType(ctx), nil)
+
+	for folderID, wantErr := range map[string]bool{"root": false, "fld-gone": true} {
+		var calls atomic.Int32
+		r := &allocationResource{client: newImportTestClient(t, referenceTestHandler(&calls)), validateReferences: true}
+		plan := tfsdk.Plan{Raw: nullRaw, Schema: sch}
+		if diags := plan.SetAttribute(ctx, path.Root("folder_id"), types.StringValue(folderID)); diags.HasError() {
+			t.Fatalf("setting plan: %v", diags)
+		}
+		resp := resource.ModifyPlanResponse{Plan: plan}
+		r.ModifyPlan(ctx, resource.ModifyPlanRequest{
+			Config: tfsdk.Config{Raw: plan.Raw, Schema: sch},
+			State:  tfsdk.State{Raw: nullRaw, Schema: sch},
+			Plan:   plan,
+		}, &resp)
+
+		if got := resp.Diagnostics.HasError(); got != wantErr {
+			t.Errorf("folder_id %q: diagnostics = %v, want error %v", folderID, resp.Diagnostics, wantErr)
+		}
+		// The root folder is not an object and is never looked up.
+		if folderID == "root" && calls.Load() != 0 {
+			t.Errorf("folder_id %q: the API was called", folderID)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"strings"
@@ -11,9 +12,25 @@ import (
 	"github.com/doitintl/terraform-provider-doit/internal/provider/resource_report"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+// reportUpdateTimeKey is the private state key holding the report's update
+// time, in milliseconds since the epoch, as of the last refresh. Reports have
+// no update_time attribute: the config endpoint Read uses does not return it,
+// so it is read from the reports list, and only when
+// detect_concurrent_modification is set.
+const reportUpdateTimeKey = "update_time"
+
+// privateState is the framework's private resource state, as found in the
+// Private field of Read, Create and Update requests and responses.
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
 // overlayReportComputedFields preserves all user-configured values from the Terraform
 // plan and selectively resolves only Unknown (Optional+Computed) fields from the API response.
 //
@@ -581,9 +598,7 @@ func mergeSentinelValues(apiValues []string, stateVals []string, apiIncludeNull
 func (r *reportResource) populateState(ctx context.Context, state *reportResourceModel) diag.Diagnostics {
 	reportResp, err := r.client.GetReportConfigWithResponse(ctx, state.Id.ValueString())
 	if err != nil {
-		return diag.Diagnostics{
-			diag.NewErrorDiagnostic("Error reading report", "Could not read report config, unexpected error: "+err.Error()),
-		}
+		return apiErrorDiagnostics("Error reading report", "Could not read report config", err)
 	}
 
 	// Handle externally deleted resource
@@ -607,6 +622,130 @@ func (r *reportResource) populateState(ctx context.Context, state *reportResourc
 	return mapReportToModel(ctx, reportResp.JSON200, state)
 }
 
+// getUpdateTime fetches the update time of the report id, named name, from
+// the reports list. GET /reports/{id} also returns it, but runs the report's
+// query. The list is filtered by name, and walked unfiltered when the report
+// is not found under it, as it may have been renamed outside Terraform. The
+// update time is null when the report no longer exists.
+func (r *reportResource) getUpdateTime(ctx context.Context, id, name string) (types.Int64, diag.Diagnostics) {
+	updateTime, found, err := r.findUpdateTime(ctx, id, name)
+	if err == nil && !found && name != "" {
+		updateTime, _, err = r.findUpdateTime(ctx, id, "")
+	}
+	if err != nil {
+		return types.Int64Null(), apiErrorDiagnostics("Error reading report", "Could not read report update time", err)
+	}
+	return updateTime, nil
+}
+
+// findUpdateTime walks the reports list, filtered by nameContains when it is
+// not empty, for the update time of the report id.
+func (r *reportResource) findUpdateTime(ctx context.Context, id, nameContains string) (types.Int64, bool, error) {
+	params := &models.ListReportsParams{}
+	if nameContains != "" {
+		params.NameContains = new(nameContains)
+	}
+	for {
+		apiResp, err := r.client.ListReportsWithResponse(ctx, params)
+		if err != nil {
+			return types.Int64Null(), false, err
+		}
+		if apiResp.StatusCode() != 200 || apiResp.JSON200 == nil {
+			return types.Int64Null(), false, listStatusError(apiResp.StatusCode(), apiResp.Body)
+		}
+		if apiResp.JSON200.Reports != nil {
+			for _, report := range *apiResp.JSON200.Reports {
+				if report.Id != nil && *report.Id == id {
+					return types.Int64PointerValue(report.UpdateTime), true, nil
+				}
+			}
+		}
+		if !hasNextPage(apiResp.JSON200.PageToken) {
+			return types.Int64Null(), false, nil
+		}
+		params.PageToken = apiResp.JSON200.PageToken
+	}
+}
+
+// saveUpdateTime fetches the report's update time and stores it in private
+// for the next Update's concurrent modification check. Failing to fetch it
+// is only a warning, as the report itself was read or written: the saved
+// time is removed, and the next Update skips the check.
+func (r *reportResource) saveUpdateTime(ctx context.Context, id, name string, private privateState) diag.Diagnostics {
+	var diags diag.Diagnostics
+	updateTime, timeDiags := r.getUpdateTime(ctx, id, name)
+	if timeDiags.HasError() {
+		for _, d := range timeDiags.Errors() {
+			diags.AddWarning(d.Summary(), d.Detail()+"\n\nThe next update of this report is not checked for changes made outside Terraform.")
+		}
+		updateTime = types.Int64Null()
+	}
+	// A nil value removes the key.
+	var value []byte
+	if !updateTime.IsNull() {
+		value = []byte(fmt.Sprint(updateTime.ValueInt64()))
+	}
+	diags.Append(private.SetKey(ctx, reportUpdateTimeKey, value)...)
+	return diags
+}
+
+// savedUpdateTime returns the update time saveUpdateTime stored in private,
+// or null when there is none, e.g. because the report was last refreshed
+// without detect_concurrent_modification.
+func savedUpdateTime(ctx context.Context, private privateState) (types.Int64, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, reportUpdateTimeKey)
+	if diags.HasError() || len(value) == 0 {
+		return types.Int64Null(), diags
+	}
+	var updateTime int64
+	if err := json.Unmarshal(value, &updateTime); err != nil {
+		diags.AddError("Error reading report", fmt.Sprintf("Could not decode the saved report update time %q: %s", value, err))
+		return types.Int64Null(), diags
+	}
+	return types.Int64Value(updateTime), diags
+}
+
+// checkConcurrentModification fails when the report in prior was updated
+// after priorTime, its update time at the last refresh, listing the
+// attributes that changed. See diffConcurrentModification.
+func (r *reportResource) checkConcurrentModification(ctx context.Context, prior tfsdk.State, priorTime types.Int64) diag.Diagnostics {
+	if priorTime.IsNull() {
+		return nil
+	}
+	var remote reportResourceModel
+	diags := prior.Get(ctx, &remote)
+	if diags.HasError() {
+		return diags
+	}
+	id := remote.Id.ValueString()
+
+	remoteTime, timeDiags := r.getUpdateTime(ctx, id, remote.Name.ValueString())
+	diags.Append(timeDiags...)
+	// A deleted report is left to the update request to report.
+	if diags.HasError() || remoteTime.IsNull() || remoteTime.Equal(priorTime) {
+		return diags
+	}
+
+	// Refresh the way Read does, so that default labels and values mapped
+	// back to their configured form do not show up as changes.
+	configuredLabels := remote.Labels
+	diags.Append(r.populateState(ctx, &remote)...)
+	if diags.HasError() || remote.Id.IsNull() {
+		return diags
+	}
+	var labelDiags diag.Diagnostics
+	remote.Labels, labelDiags = withoutDefaultLabels(ctx, remote.Labels, configuredLabels, r.defaultLabels)
+	diags.Append(labelDiags...)
+
+	refreshed := tfsdk.State{Schema: prior.Schema, Raw: prior.Raw.Copy()}
+	diags.Append(refreshed.Set(ctx, &remote)...)
+	if diags.HasError() {
+		return diags
+	}
+	diags.Append(diffModifiedObject(ctx, "report", id, prior, refreshed, priorTime, remoteTime)...)
+	return diags
+}
+
 func (plan *reportResourceModel) toCreateRequest(ctx context.Context) (req models.CreateReportJSONRequestBody, diags diag.Diagnostics) {
 	if !plan.Name.IsNull() && !plan.Name.IsUnknown() {
 		req.Name = plan.Name.ValueStringPointer()
@@ -1236,6 +1375,8 @@ func mapReportToModel(ctx context.Context, resp *models.ExternalReport, state *r
 			existingTime, err := time.Parse(time.RFC3339, existingFrom)
 			if err == nil && existingTime.Equal(*config.CustomTimeRange.From) {
 				ctrMap["from"] = types.StringValue(existingFrom)
+				explainPreservedTimestamp(ctx, path.Root("config").AtName("custom_time_range").AtName("from"),
+					config.CustomTimeRange.From.Format(time.RFC3339), existingFrom)
 			} else {
 				ctrMap["from"] = types.StringValue(config.CustomTimeRange.From.Format(time.RFC3339))
 			}
@@ -1245,6 +1386,8 @@ func mapReportToModel(ctx context.Context, resp *models.ExternalReport, state *r
 			existingTime, err := time.Parse(time.RFC3339, existingTo)
 			if err == nil && existingTime.Equal(*config.CustomTimeRange.To) {
 				ctrMap["to"] = types.StringValue(existingTo)
+				explainPreservedTimestamp(ctx, path.Root("config").AtName("custom_time_range").AtName("to"),
+					config.CustomTimeRange.To.Format(time.RFC3339), existingTo)
 			} else {
 				ctrMap["to"] = types.StringValue(config.CustomTimeRange.To.Format(time.RFC3339))
 			}
@@ -1275,6 +1418,7 @@ func mapReportToModel(ctx context.Context, resp *models.ExternalReport, state *r
 			// Normalize alias types to preserve user's configured value
 			if i < len(existingDimTypes) {
 				dType = normalizeDimensionsType(dType, existingDimTypes[i])
+				explainNormalizedType(ctx, path.Root("config").AtName("dimensions").AtListIndex(i).AtName("type"), string(*d.Type), dType)
 			}
 			m := map[string]attr.Value{
 				"id":   types.StringPointerValue(d.Id),
@@ -1328,11 +1472,14 @@ func mapReportToModel(ctx context.Context, resp *models.ExternalReport, state *r
 			// Normalize alias types and IDs to preserve user's configured value.
 			// The filter ID can also be an alias (e.g. "allocation_rule" vs "attribution")
 			// when it references a dimension type directly.
+			filterPath := path.Root("config").AtName("filters").AtListIndex(i)
 			if i < len(existingFilterTypes) {
 				fType = normalizeDimensionsType(fType, existingFilterTypes[i])
+				explainNormalizedType(ctx, filterPath.AtName("type"), string(f.Type), fType)
 			}
 			if i < len(existingFilterIDs) {
 				fID = normalizeDimensionsType(fID, existingFilterIDs[i])
+				explainNormalizedType(ctx, filterPath.AtName("id"), f.Id, fID)
 			}
 			// Prefer the plan/state value for includeNull when available, falling back to the
 			// API response. The API does echo includeNull correctly (e.g. it returns true when
@@ -1402,6 +1549,7 @@ func mapReportToModel(ctx context.Context, resp *models.ExternalReport, state *r
 				var stateVals []string
 				if d := existingFilterValues[i].ElementsAs(ctx, &stateVals, false); !d.HasError() {
 					mergedValues = mergeSentinelValues(apiValues, stateVals, apiIncludeNull)
+					explainRestoredSentinels(ctx, filterPath.AtName("values"), apiValues, mergedValues, apiIncludeNull)
 				}
 			}
 			if len(mergedValues) > 0 {
@@ -1452,9 +1600,11 @@ func mapReportToModel(ctx context.Context, resp *models.ExternalReport, state *r
 			// Normalize alias types and IDs to preserve user's configured value
 			if i < len(existingGroupTypes) {
 				groupType = normalizeDimensionsType(groupType, existingGroupTypes[i])
+				explainNormalizedType(ctx, path.Root("config").AtName("group").AtListIndex(i).AtName("type"), string(*g.Type), groupType)
 			}
 			if groupID != nil && i < len(existingGroupIDs) {
 				groupID = new(normalizeDimensionsType(*groupID, existingGroupIDs[i]))
+				explainNormalizedType(ctx, path.Root("config").AtName("group").AtListIndex(i).AtName("id"), *g.Id, *groupID)
 			}
 			m := map[string]attr.Value{
 				"id":   types.StringPointerValue(groupID),
@@ -1735,6 +1885,8 @@ func mapReportToModel(ctx context.Context, resp *models.ExternalReport, state *r
 				existingTime, err := time.Parse(time.RFC3339, existingFrom)
 				if err == nil && existingTime.Equal(*config.SecondaryTimeRange.CustomTimeRange.From) {
 					ctrMap["from"] = types.StringValue(existingFrom)
+					explainPreservedTimestamp(ctx, path.Root("config").AtName("secondary_time_range").AtName("custom_time_range").AtName("from"),
+						config.SecondaryTimeRange.CustomTimeRange.From.Format(time.RFC3339), existingFrom)
 				} else {
 					ctrMap["from"] = types.StringValue(config.SecondaryTimeRange.CustomTimeRange.From.Format(time.RFC3339))
 				}
@@ -1743,6 +1895,8 @@ func mapReportToModel(ctx context.Context, resp *models.ExternalReport, state *r
 				existingTime, err := time.Parse(time.RFC3339, existingTo)
 				if err == nil && existingTime.Equal(*config.SecondaryTimeRange.CustomTimeRange.To) {
 					ctrMap["to"] = types.StringValue(existingTo)
+					explainPreservedTimestamp(ctx, path.Root("config").AtName("secondary_time_range").AtName("custom_time_range").AtName("to"),
+						config.SecondaryTimeRange.CustomTimeRange.To.Format(time.RFC3339), existingTo)
 				} else {
 					ctrMap["to"] = types.StringValue(config.SecondaryTimeRange.CustomTimeRange.To.Format(time.RFC3339))
 				}
@@ -0,0 +1,341 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/doitintl/terraform-provider-doit/internal/provider/resource_report"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+// The DoiT API sits behind an edge proxy that answers requests still running
+// after 120 seconds with a 524, and a query that hits it fails only after the
+// full wait (see the request_timeout docs). Query time grows with the number
+// of result rows the engine has to build: time buckets times the distinct
+// values of every group-by dimension. The estimator below approximates that
+// row count from the config alone, so an oversized query can be flagged
+// while validating instead of after two minutes.
+
+// reportQueryCostWarnRows is the estimated row count above which a query is
+// likely to exceed the edge timeout.
+const reportQueryCostWarnRows = 1_000_000
+
+// reportDimensionCardinality is the typical number of distinct values of
+// fixed dimensions in a billing account, used when the group has no limit
+// and is not narrowed by an "is" filter. Unlisted fixed dimensions and
+// label-like dimensions count as defaultReportDimensionCardinality.
+var reportDimensionCardinality = map[string]float64{
+	"cloud_provider":      4,
+	"billing_account_id":  20,
+	"cost_type":           10,
+	"credit":              20,
+	"country":             60,
+	"region":              40,
+	"zone":                120,
+	"usage_unit":          50,
+	"service_description": 100,
+	"service_id":          100,
+	"project_id":          500,
+	"project_name":        500,
+	"operation":           2_000,
+	"sku_description":     10_000,
+	"sku_id":              10_000,
+	"resource_id":         200_000,
+	"resource_global_id":  200_000,
+}
+
+const defaultReportDimensionCardinality = 100
+
+// reportIntervalDays is the length of each time_interval, and of each
+// time_range unit, in days.
+var reportIntervalDays = map[string]float64{
+	"hour":      1.0 / 24,
+	"day":       1,
+	"dayCumSum": 1,
+	"week":      7,
+	"isoweek":   7,
+	"month":     365.25 / 12,
+	"quarter":   365.25 / 4,
+	"year":      365.25,
+}
+
+// reportQueryShape is the part of a report config that drives query cost.
+type reportQueryShape struct {
+	spanDays     float64
+	timeInterval string
+	groups       []reportGroupShape
+	splits       int
+	forecast     bool
+	secondary    bool
+}
+
+// reportGroupShape is one group-by dimension. limit is its top-N limit, or 0;
+// filterValues is the number of values an "is" filter on the same dimension
+// restricts it to, or 0.
+type reportGroupShape struct {
+	dimType      string
+	id           string
+	limit        int64
+	filterValues int
+}
+
+// reportQueryCost is the estimate for a query: the number of result rows,
+// the factors it is the product of, and ways to reduce them.
+type reportQueryCost struct {
+	rows        float64
+	factors     []string
+	suggestions []string
+}
+
+// estimateReportQueryCost estimates the rows a query with shape s builds.
+func estimateReportQueryCost(s reportQueryShape) reportQueryCost {
+	var c reportQueryCost
+
+	buckets := 1.0
+	if s.timeInterval == "week_day" {
+		buckets = math.Min(7, math.Ceil(s.spanDays))
+	} else if days, ok := reportIntervalDays[s.timeInterval]; ok {
+		buckets = math.Max(1, math.Ceil(s.spanDays/days))
+	}
+	c.rows = buckets
+	c.factors = append(c.factors, fmt.Sprintf("%s %s periods", formatCount(buckets), s.timeInterval))
+	if buckets > 400 {
+		c.suggestions = append(c.suggestions, fmt.Sprintf(
+			"use a coarser time_interval than %q, or a shorter time_range (%s days)", s.timeInterval, formatCount(s.spanDays)))
+	}
+
+	type groupCost struct {
+		id          string
+		cardinality float64
+	}
+	var groups []groupCost
+	for _, g := range s.groups {
+		// Datetime groups split the same time buckets again.
+		if g.dimType == "datetime" {
+			continue
+		}
+		card, ok := reportDimensionCardinality[g.id]
+		if g.dimType != "fixed" || !ok {
+			card = defaultReportDimensionCardinality
+		}
+		if g.filterValues > 0 {
+			card = math.Min(card, float64(g.filterValues))
+		}
+		if g.limit > 0 {
+			card = math.Min(card, float64(g.limit))
+		}
+		c.rows *= card
+		c.factors = append(c.factors, fmt.Sprintf("~%s values of %s", formatCount(card), g.id))
+		groups = append(groups, groupCost{g.id, card})
+	}
+	sort.SliceStable(groups, func(i, j int) bool { return groups[i].cardinality > groups[j].cardinality })
+	for _, g := range groups {
+		if g.cardinality >= 1_000 {
+			c.suggestions = append(c.suggestions, fmt.Sprintf(
+				"set a limit on the %s group, or filter it to the values you need", g.id))
+		}
+	}
+
+	if s.splits > 0 {
+		c.rows *= float64(1 + s.splits)
+		c.factors = append(c.factors, fmt.Sprintf("%d splits", s.splits))
+	}
+	if s.forecast {
+		c.rows *= 2
+		c.factors = append(c.factors, "a forecast")
+		c.suggestions = append(c.suggestions, "disable the forecast")
+	}
+	if s.secondary {
+		c.rows *= 2
+		c.factors = append(c.factors, "a secondary time range")
+		c.suggestions = append(c.suggestions, "remove secondary_time_range")
+	}
+	return c
+}
+
+// formatCount formats a rounded count with thousands separators.
+func formatCount(v float64) string {
+	s := fmt.Sprintf("%.0f", math.Round(v))
+	var b strings.Builder
+	for i, r := range s {
+		if i > 0 && (len(s)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// reportTimeRangeDays returns the number of days a report time range covers
+// at now, and false when it cannot be told from the config.
+func reportTimeRangeDays(tr resource_report.TimeRangeValue, custom resource_report.CustomTimeRangeValue, now time.Time) (float64, bool) {
+	if tr.IsNull() || tr.IsUnknown() || tr.Mode.IsNull() || tr.Mode.IsUnknown() {
+		return 0, false
+	}
+
+	if tr.Mode.ValueString() == "custom" {
+		if custom.IsNull() || custom.IsUnknown() || custom.From.IsUnknown() || custom.To.IsUnknown() {
+			return 0, false
+		}
+		from, to := now, now
+		if !custom.From.IsNull() {
+			t, err := time.Parse(time.RFC3339, custom.From.ValueString())
+			if err != nil {
+				return 0, false
+			}
+			from = t
+		}
+		if !custom.To.IsNull() {
+			t, err := time.Parse(time.RFC3339, custom.To.ValueString())
+			if err != nil {
+				return 0, false
+			}
+			to = t
+		}
+		return math.Max(0, to.Sub(from).Hours()/24), true
+	}
+
+	if tr.Unit.IsNull() || tr.Unit.IsUnknown() || tr.Amount.IsUnknown() {
+		return 0, false
+	}
+	unitDays, ok := reportIntervalDays[tr.Unit.ValueString()]
+	if !ok {
+		return 0, false
+	}
+	amount := int64(1)
+	if tr.Mode.ValueString() == "last" && !tr.Amount.IsNull() {
+		amount = tr.Amount.ValueInt64()
+		if !tr.IncludeCurrent.IsNull() && !tr.IncludeCurrent.IsUnknown() && tr.IncludeCurrent.ValueBool() {
+			amount++
+		}
+	}
+	return float64(amount) * unitDays, true
+}
+
+// reportQueryShapeFromConfig builds the shape of a report config, and false
+// when the time range or groups are not known yet.
+func reportQueryShapeFromConfig(ctx context.Context, cfg resource_report.ConfigValue, now time.Time) (reportQueryShape, bool) {
+	var s reportQueryShape
+	if cfg.IsNull() || cfg.IsUnknown() || cfg.TimeInterval.IsNull() || cfg.TimeInterval.IsUnknown() ||
+		cfg.Group.IsUnknown() || cfg.Filters.IsUnknown() {
+		return s, false
+	}
+	span, ok := reportTimeRangeDays(cfg.TimeRange, cfg.CustomTimeRange, now)
+	if !ok {
+		return s, false
+	}
+	s.spanDays = span
+	s.timeInterval = cfg.TimeInterval.ValueString()
+
+	// An "is" filter bounds the values of the dimension it filters.
+	filterValues := map[[2]string]int{}
+	var filters []resource_report.FiltersValue
+	if diags := cfg.Filters.ElementsAs(ctx, &filters, false); diags.HasError() {
+		return s, false
+	}
+	for _, f := range filters {
+		if f.Inverse.ValueBool() || (!f.Mode.IsNull() && f.Mode.ValueString() != "is") ||
+			f.Values.IsNull() || f.Values.IsUnknown() {
+			continue
+		}
+		filterValues[[2]string{f.FiltersType.ValueString(), f.Id.ValueString()}] = len(f.Values.Elements())
+	}
+
+	var groups []resource_report.GroupValue
+	if diags := cfg.Group.ElementsAs(ctx, &groups, false); diags.HasError() {
+		return s, false
+	}
+	for _, g := range groups {
+		if g.Id.IsUnknown() || g.GroupType.IsUnknown() {
+			return s, false
+		}
+		gs := reportGroupShape{dimType: g.GroupType.ValueString(), id: g.Id.ValueString()}
+		gs.filterValues = filterValues[[2]string{gs.dimType, gs.id}]
+		if !g.Limit.IsNull() && !g.Limit.IsUnknown() && !g.Limit.Value.IsNull() && !g.Limit.Value.IsUnknown() {
+			gs.limit = g.Limit.Value.ValueInt64()
+		}
+		s.groups = append(s.groups, gs)
+	}
+
+	if !cfg.Splits.IsNull() && !cfg.Splits.IsUnknown() {
+		s.splits = len(cfg.Splits.Elements())
+	}
+	s.forecast = !cfg.ForecastSettings.IsNull() && !cfg.ForecastSettings.IsUnknown() ||
+		!cfg.AdvancedAnalysis.IsNull() && !cfg.AdvancedAnalysis.IsUnknown() && cfg.AdvancedAnalysis.Forecast.ValueBool()
+	s.secondary = !cfg.SecondaryTimeRange.IsNull() && !cfg.SecondaryTimeRange.IsUnknown()
+	return s, true
+}
+
+// validateReportQueryCost is the shared body used by both the report resource
+// and the report_query data source. It warns when the configured query is
+// estimated to run past the API's edge timeout.
+func validateReportQueryCost(ctx context.Context, config tfsdk.Config, diags *diag.Diagnostics) {
+	var cfg resource_report.ConfigValue
+	d := config.GetAttribute(ctx, path.Root("config"), &cfg)
+	diags.Append(d...)
+	if d.HasError() {
+		return
+	}
+
+	shape, ok := reportQueryShapeFromConfig(ctx, cfg, time.Now())
+	if !ok {
+		return
+	}
+	cost := estimateReportQueryCost(shape)
+	if cost.rows <= reportQueryCostWarnRows {
+		return
+	}
+
+	detail := fmt.Sprintf("This query is estimated to build about %s rows (%s). "+
+		"Queries of this size often run longer than the 120 seconds the DoiT API allows, "+
+		"and then fail with a 524 error after the full wait.", formatCount(cost.rows), strings.Join(cost.factors, " × "))
+	if len(cost.suggestions) > 0 {
+		detail += " To narrow it, " + strings.Join(cost.suggestions, "; ") + "."
+	}
+	diags.AddAttributeWarning(path.Root("config"), "Report Query May Time Out", detail)
+}
+
+// reportQueryCostValidator warns when a report's query is estimated to exceed
+// the API's edge timeout. The report resource runs no query itself, but
+// doit_report_result and the console run the report's config.
+type reportQueryCostValidator struct{}
+
+var _ resource.ConfigValidator = reportQueryCostValidator{}
+
+func (v reportQueryCostValidator) Description(_ context.Context) string {
+	return "Warns when the report's query is likely to exceed the API's 120-second timeout"
+}
+
+func (v reportQueryCostValidator) MarkdownDescription(_ context.Context) string {
+	return "Warns when the report's query is likely to exceed the API's 120-second timeout"
+}
+
+func (v reportQueryCostValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	validateReportQueryCost(ctx, req.Config, &resp.Diagnostics)
+}
+
+// reportQueryCostDataSourceValidator is the data-source counterpart of
+// reportQueryCostValidator for report_query.
+type reportQueryCostDataSourceValidator struct{}
+
+var _ datasource.ConfigValidator = reportQueryCostDataSourceValidator{}
+
+func (v reportQueryCostDataSourceValidator) Description(_ context.Context) string {
+	return "Warns when the query is likely to exceed the API's 120-second timeout"
+}
+
+func (v reportQueryCostDataSourceValidator) MarkdownDescription(_ context.Context) string {
+	return "Warns when the query is likely to exceed the API's 120-second timeout"
+}
+
+func (v reportQueryCostDataSourceValidator) ValidateDataSource(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	validateReportQueryCost(ctx, req.Config, &resp.Diagnostics)
+}
@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/doitintl/terraform-provider-doit/internal/provider/resource_report"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestEstimateReportQueryCost(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		shape reportQueryShape
+		rows  float64
+		warns bool
+	}{
+		{
+			name:  "monthly by service",
+			shape: reportQueryShape{spanDays: 365.25, timeInterval: "month", groups: []reportGroupShape{{dimType: "fixed", id: "service_description"}}},
+			rows:  1_200,
+		},
+		{
+			name: "daily by sku and project",
+			shape: reportQueryShape{spanDays: 90, timeInterval: "day", groups: []reportGroupShape{
+				{dimType: "fixed", id: "sku_description"}, {dimType: "fixed", id: "project_id"},
+			}},
+			rows:  450_000_000,
+			warns: true,
+		},
+		{
+			name: "limited and filtered groups",
+			shape: reportQueryShape{spanDays: 90, timeInterval: "day", groups: []reportGroupShape{
+				{dimType: "fixed", id: "sku_description", limit: 10}, {dimType: "fixed", id: "project_id", filterValues: 2},
+			}},
+			rows: 1_800,
+		},
+		{
+			name:  "datetime groups and labels",
+			shape: reportQueryShape{spanDays: 7, timeInterval: "week_day", groups: []reportGroupShape{{dimType: "datetime", id: "year"}, {dimType: "label", id: "team"}}},
+			rows:  700,
+		},
+		{
+			name:  "hourly resources with forecast and secondary range",
+			shape: reportQueryShape{spanDays: 2, timeInterval: "hour", groups: []reportGroupShape{{dimType: "fixed", id: "resource_id"}}, forecast: true, secondary: true},
+			rows:  48 * 200_000 * 4,
+			warns: true,
+		},
+	}
+	for _, tt := range tests {
+		got := estimateReportQueryCost(tt.shape)
+		if math.Abs(got.rows-tt.rows) > 0.5 {
+			t.Errorf("%s: rows = %v, want %v", tt.name, got.rows, tt.rows)
+		}
+		if warns := got.rows > reportQueryCostWarnRows; warns != tt.warns {
+			t.Errorf("%s: warns = %v, want %v", tt.name, warns, tt.warns)
+		}
+	}
+
+	got := estimateReportQueryCost(reportQueryShape{spanDays: 730, timeInterval: "day", groups: []reportGroupShape{
+		{dimType: "fixed", id: "project_id"}, {dimType: "fixed", id: "resource_id"},
+	}})
+	want := []string{
+		`use a coarser time_interval than "day", or a shorter time_range (730 days)`,
+		"set a limit on the resource_id group, or filter it to the values you need",
+	}
+	if strings.Join(got.suggestions, "\n") != strings.Join(want, "\n") {
+		t.Errorf("suggestions = %q, want %q", got.suggestions, want)
+	}
+}
+
+func TestReportTimeRangeDays(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Date(2026, 6, 15, 12, 0, 0, 0, time.UTC)
+	timeRange := func(mode string, unit types.String, amount int64, includeCurrent bool) resource_report.TimeRangeValue {
+		return resource_report.NewTimeRangeValueMust(resource_report.TimeRangeValue{}.AttributeTypes(ctx), map[string]attr.Value{
+			"mode":            types.StringValue(mode),
+			"unit":            unit,
+			"amount":          types.Int64Value(amount),
+			"include_current": types.BoolValue(includeCurrent),
+		})
+	}
+	custom := resource_report.NewCustomTimeRangeValueMust(resource_report.CustomTimeRangeValue{}.AttributeTypes(ctx), map[string]attr.Value{
+		"from": types.StringValue("2026-01-01T00:00:00Z"),
+		"to":   types.StringNull(),
+	})
+	noCustom := resource_report.NewCustomTimeRangeValueNull()
+
+	tests := []struct {
+		name   string
+		tr     resource_report.TimeRangeValue
+		custom resource_report.CustomTimeRangeValue
+		days   float64
+		ok     bool
+	}{
+		{"last 30 days", timeRange("last", types.StringValue("day"), 30, false), noCustom, 30, true},
+		{"last 2 weeks and current", timeRange("last", types.StringValue("week"), 2, true), noCustom, 21, true},
+		{"current quarter", timeRange("current", types.StringValue("quarter"), 0, false), noCustom, 365.25 / 4, true},
+		{"custom until now", timeRange("custom", types.StringNull(), 0, false), custom, 165.5, true},
+		{"unset", resource_report.NewTimeRangeValueNull(), noCustom, 0, false},
+	}
+	for _, tt := range tests {
+		days, ok := reportTimeRangeDays(tt.tr, tt.custom, now)
+		if ok != tt.ok || math.Abs(days-tt.days) > 1e-9 {
+			t.Errorf("%s: reportTimeRangeDays() = %v, %v; want %v, %v", tt.name, days, ok, tt.days, tt.ok)
+		}
+	}
+}
+
+func TestFormatCount(t *testing.T) {
+	t.Parallel()
+
+	for v, want := range map[float64]string{0: "0", 999.6: "1,000", 1234567: "1,234,567", 100000: "100,000"} {
+		if got := formatCount(v); got != want {
+			t.Errorf("formatCount(%v) = %q, want %q", v, got, want)
+		}
+	}
+}
+
+func TestReportQueryCostValidator(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	(&reportResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	sch := schemaResp.Schema
+
+	validate := func(t *testing.T, groupID string) diag.Diagnostics {
+		t.Helper()
+		state := tfsdk.State{Raw: tftypes.NewValue(sch.Type().TerraformType(ctx), nil), Schema: sch}
+		group := resource_report.NewGroupValueMust(resource_report.GroupValue{}.AttributeTypes(ctx), map[string]attr.Value{
+			"id":    types.StringValue(groupID),
+			"type":  types.StringValue("fixed"),
+			"limit": resource_report.NewLimitValueNull(),
+		})
+		diags := state.SetAttribute(ctx, path.Root("config").AtName("time_interval"), types.StringValue("day"))
+		diags.Append(state.SetAttribute(ctx, path.Root("config").AtName("time_range").AtName("mode"), types.StringValue("last"))...)
+		diags.Append(state.SetAttribute(ctx, path.Root("config").AtName("time_range").AtName("unit"), types.StringValue("month"))...)
+		diags.Append(state.SetAttribute(ctx, path.Root("config").AtName("time_range").AtName("amount"), types.Int64Value(6))...)
+		diags.Append(state.SetAttribute(ctx, path.Root("config").AtName("group"),
+			types.ListValueMust(resource_report.GroupValue{}.Type(ctx), []attr.Value{group}))...)
+		if diags.HasError() {
+			t.Fatalf("building config: %v", diags)
+		}
+
+		var resp resource.ValidateConfigResponse
+		reportQueryCostValidator{}.ValidateResource(ctx, resource.ValidateConfigRequest{
+			Config: tfsdk.Config{Raw: state.Raw, Schema: sch},
+		}, &resp)
+		return resp.Diagnostics
+	}
+
+	if diags := validate(t, "service_description"); len(diags) != 0 {
+		t.Errorf("unexpected diagnostics for a small query: %v", diags)
+	}
+
+	diags := validate(t, "resource_id")
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Fatalf("diagnostics = %v, want one warning", diags)
+	}
+	if detail := diags.Warnings()[0].Detail(); !strings.Contains(detail, "183 day periods × ~200,000 values of resource_id") {
+		t.Errorf("warning detail = %q", detail)
+	}
+}
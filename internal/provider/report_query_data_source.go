@@ -47,10 +47,11 @@ type reportQueryDataSourceModel struct {
 	Config resource_report.ConfigValue `tfsdk:"config"`
 
 	// Outputs
-	ResultJSON types.String   `tfsdk:"result_json"`
-	CacheHit   types.Bool     `tfsdk:"cache_hit"`
-	RowCount   types.Int64    `tfsdk:"row_count"`
-	Timeouts   timeouts.Value `tfsdk:"timeouts"`
+	ResultJSON      types.String   `tfsdk:"result_json"`
+	CacheHit        types.Bool     `tfsdk:"cache_hit"`
+	RowCount        types.Int64    `tfsdk:"row_count"`
+	CustomerContext types.String   `tfsdk:"customer_context"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (d *reportQueryDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -139,7 +140,8 @@ func (d *reportQueryDataSource) Schema(ctx context.Context, _ datasource.SchemaR
 				MarkdownDescription: "The number of data rows in the result.",
 				Computed:            true,
 			},
-			"timeouts": timeouts.Attributes(ctx),
+			"customer_context": customerContextDataSourceAttribute(),
+			"timeouts":         timeouts.Attributes(ctx),
 		},
 	}
 }
@@ -149,6 +151,8 @@ func (d *reportQueryDataSource) ConfigValidators(_ context.Context) []datasource
 		// Same empty-range / RFC3339 checks as the resource; the query config
 		// reuses the report resource's config types.
 		reportTimestampDataSourceValidator{},
+		// Warn when the query is likely to run past the API's edge timeout.
+		reportQueryCostDataSourceValidator{},
 	}
 }
 
@@ -193,6 +197,7 @@ func (d *reportQueryDataSource) Read(ctx context.Context, req datasource.ReadReq
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	// If the config contains any unknown values (e.g., during a plan where
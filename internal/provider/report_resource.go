@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var (
@@ -28,11 +29,20 @@ func NewReportResource() resource.Resource {
 
 type (
 	reportResource struct {
-		client *models.ClientWithResponses
+		client                       *models.ClientWithResponses
+		readOnly                     bool
+		detectConcurrentModification bool
+		defaultLabels                []string
+		deletionProtection           bool
+		validateDimensions           bool
+		explainDrift                 bool
+		validateReferences           bool
 	}
 	reportResourceModel struct {
 		resource_report.ReportModel
-		Timeouts timeouts.Value `tfsdk:"timeouts"`
+		DeletionProtection types.Bool     `tfsdk:"deletion_protection"`
+		CustomerContext    types.String   `tfsdk:"customer_context"`
+		Timeouts           timeouts.Value `tfsdk:"timeouts"`
 	}
 )
 
@@ -213,6 +223,9 @@ func (r *reportResource) Schema(ctx context.Context, _ resource.SchemaRequest, r
 		"config.time_range",                             // silently preserved on removal
 	)
 
+	s.Attributes["deletion_protection"] = deletionProtectionAttribute()
+
+	s.Attributes["customer_context"] = customerContextResourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx, timeouts.Opts{
 		Create: true,
 		Read:   true,
@@ -227,16 +240,23 @@ func (r *reportResource) Configure(_ context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*models.ClientWithResponses)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *models.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.readOnly = data.readOnly
+	r.detectConcurrentModification = data.detectConcurrentModification
+	r.deletionProtection = data.deletionProtection
+	r.defaultLabels = data.defaultLabels
+	r.validateDimensions = data.validateDimensions
+	r.explainDrift = data.explainDrift
+	r.validateReferences = data.validateReferences
 }
 
 func (r *reportResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
@@ -253,6 +273,8 @@ func (r *reportResource) ConfigValidators(_ context.Context) []resource.ConfigVa
 		reportCustomTimeRangeUnitValidator{},
 		// Warn when legacy [... N/A] NullFallback sentinels are used in filter values.
 		reportFilterNAValidator{},
+		// Warn when the query is likely to run past the API's edge timeout.
+		reportQueryCostValidator{},
 	}
 }
 
@@ -277,9 +299,28 @@ func (r *reportResource) ConfigValidators(_ context.Context) []resource.ConfigVa
 // ranges) are intentionally NOT listed: removing them is idempotent (the prior
 // value sticks with no drift), matching the default Category B behavior for
 // unclearable leaves, so forcing a replace would be gratuitously destructive.
+//
+// With validate_dimensions_online it also checks config.filters against the
+// API, on create as well as update (see validateDimensionFilters), and unless
+// skip_reference_validation is set, that folder_id and labels exist (see
+// validateReferences).
 func (r *reportResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
-	// Skip on create (no prior state) and destroy (no plan).
-	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+	if req.Plan.Raw.IsNull() {
+		return // destroy
+	}
+
+	if r.validateDimensions {
+		resp.Diagnostics.Append(validateDimensionFilters(ctx, r.client, req, "id",
+			path.MatchRoot("config").AtName("filters").AtAnyListIndex())...)
+	}
+	if r.validateReferences {
+		resp.Diagnostics.Append(validateReferences(ctx, r.client, req.Plan, req.State,
+			referenceCheck{expr: path.MatchRoot("folder_id"), kind: "folder"},
+			referenceCheck{expr: path.MatchRoot("labels").AtAnyListIndex(), kind: "label"})...)
+	}
+
+	// Skip the rest on create (no prior state).
+	if req.State.Raw.IsNull() {
 		return
 	}
 
@@ -290,10 +331,20 @@ func (r *reportResource) ModifyPlan(ctx context.Context, req resource.ModifyPlan
 }
 
 func (r *reportResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	ctx, id, diags := importCustomerContext(ctx, req, resp, 1)
+	resp.Diagnostics.Append(diags...)
+	req.ID = id
+
+	// Accepts either an ID or "name:<exact name>".
+	importStatePassthroughIDOrName(ctx, req, resp, "report", nameLookup(r.client, listReports))
 }
 
 func (r *reportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_report", "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan reportResourceModel
 
 	diags := req.Plan.Get(ctx, &plan)
@@ -308,6 +359,7 @@ func (r *reportResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	ctx = withCustomerContext(ctx, plan.CustomerContext)
 	defer cancel()
 
 	reportReq, diags := plan.toCreateRequest(ctx)
@@ -318,10 +370,7 @@ func (r *reportResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	reportResp, err := r.client.CreateReportWithResponse(ctx, reportReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating report",
-			"Could not create report, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error creating report", "Could not create report", err)...)
 		return
 	}
 
@@ -359,7 +408,13 @@ func (r *reportResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	resp.Diagnostics.Append(assignDefaultLabels(ctx, r.client, r.defaultLabels, models.LabelAssignmentObjectObjectTypeReport, *reportResp.JSON201.Id)...)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+
+	if r.detectConcurrentModification {
+		resp.Diagnostics.Append(r.saveUpdateTime(ctx, *reportResp.JSON201.Id, plan.Name.ValueString(), resp.Private)...)
+	}
 }
 
 func (r *reportResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -376,8 +431,14 @@ func (r *reportResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
+	var drift *driftExplanations
+	if r.explainDrift {
+		ctx, drift = withDriftExplanations(ctx)
+	}
 
+	configuredLabels := state.Labels
 	diags = r.populateState(ctx, &state)
 	if diags.HasError() {
 		resp.Diagnostics.Append(diags...)
@@ -390,10 +451,27 @@ func (r *reportResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
+	state.Labels, diags = withoutDefaultLabels(ctx, state.Labels, configuredLabels, r.defaultLabels)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(drift.diagnostics()...)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+
+	if r.detectConcurrentModification {
+		resp.Diagnostics.Append(r.saveUpdateTime(ctx, state.Id.ValueString(), state.Name.ValueString(), resp.Private)...)
+	}
 }
 
 func (r *reportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_report", "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan reportResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -407,13 +485,34 @@ func (r *reportResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	ctx = withCustomerContext(ctx, plan.CustomerContext)
 	defer cancel()
 
 	var state reportResourceModel
 	diags = req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 
-	reportReq, diags := plan.toUpdateRequest(ctx)
+	if r.detectConcurrentModification {
+		priorTime, timeDiags := savedUpdateTime(ctx, req.Private)
+		resp.Diagnostics.Append(timeDiags...)
+		resp.Diagnostics.Append(r.checkConcurrentModification(ctx, req.State, priorTime)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Send the default labels the report still has along with the configured
+	// ones; otherwise the PATCH would unassign them. The plan itself keeps
+	// only the configured labels.
+	configuredLabels := plan.Labels
+	reqPlan := plan
+	reqPlan.Labels, diags = withDefaultLabels(ctx, plan.Labels, r.defaultLabels, reportLabels(r.client, state.Id.ValueString()))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reportReq, diags := reqPlan.toUpdateRequest(ctx)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -421,10 +520,7 @@ func (r *reportResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 	reportResp, err := r.client.UpdateReportWithResponse(ctx, state.Id.ValueString(), reportReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error updating report",
-			"Could not update report, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error updating report", "Could not update report", err)...)
 		return
 	}
 
@@ -462,10 +558,27 @@ func (r *reportResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	plan.Labels, diags = withoutDefaultLabels(ctx, plan.Labels, configuredLabels, r.defaultLabels)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+
+	// Record this update's own time, so that the next update does not take
+	// it for a change made outside Terraform.
+	if r.detectConcurrentModification {
+		resp.Diagnostics.Append(r.saveUpdateTime(ctx, state.Id.ValueString(), plan.Name.ValueString(), resp.Private)...)
+	}
 }
 
 func (r *reportResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_report", "delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var state reportResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -473,20 +586,25 @@ func (r *reportResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	resp.Diagnostics.Append(checkDeletionProtection(state.DeletionProtection, r.deletionProtection, "report", state.Id.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	deleteTimeout, diags := state.Timeouts.Delete(ctx, DefaultDeleteTimeout)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
 
+	resp.Diagnostics.Append(unassignDefaultLabels(ctx, r.client, r.defaultLabels, models.LabelAssignmentObjectObjectTypeReport, state.Id.ValueString())...)
+
 	deleteResp, err := r.client.DeleteReportWithResponse(ctx, state.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error deleting report",
-			"Could not delete report, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error deleting report", "Could not delete report", err)...)
 		return
 	}
 
@@ -50,11 +50,12 @@ type reportResultDataSourceModel struct {
 	EndDate   types.String `tfsdk:"end_date"`
 
 	// Outputs
-	ResultJSON types.String   `tfsdk:"result_json"`
-	ReportName types.String   `tfsdk:"report_name"`
-	CacheHit   types.Bool     `tfsdk:"cache_hit"`
-	RowCount   types.Int64    `tfsdk:"row_count"`
-	Timeouts   timeouts.Value `tfsdk:"timeouts"`
+	ResultJSON      types.String   `tfsdk:"result_json"`
+	ReportName      types.String   `tfsdk:"report_name"`
+	CacheHit        types.Bool     `tfsdk:"cache_hit"`
+	RowCount        types.Int64    `tfsdk:"row_count"`
+	CustomerContext types.String   `tfsdk:"customer_context"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (d *reportResultDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -161,7 +162,8 @@ func (d *reportResultDataSource) Schema(ctx context.Context, _ datasource.Schema
 				MarkdownDescription: "The number of data rows in the result.",
 				Computed:            true,
 			},
-			"timeouts": timeouts.Attributes(ctx),
+			"customer_context": customerContextDataSourceAttribute(),
+			"timeouts":         timeouts.Attributes(ctx),
 		},
 	}
 }
@@ -198,6 +200,7 @@ func (d *reportResultDataSource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	// If ID or any query parameter is unknown, return early
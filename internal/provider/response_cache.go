@@ -0,0 +1,232 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/sync/singleflight"
+)
+
+// cacheableEndpoint is a reference-data GET endpoint whose responses may be
+// reused for the rest of the provider's lifetime, which is a single plan or
+// apply. invalidatedBy is the path prefix of the API whose writes can change
+// what the endpoint returns.
+type cacheableEndpoint struct {
+	path          string
+	invalidatedBy string
+}
+
+// cacheableEndpoints lists the only responses the cache stores. They back
+// doit_dimensions, doit_dimension, doit_roles, doit_platforms, doit_products
+// and doit_current_user, which large configurations read many times with
+// identical arguments. Endpoints for objects Terraform manages are
+// deliberately absent: serving a stale report or budget would hide drift.
+//
+// Dimensions include labels and allocations, so any analytics write
+// invalidates them; current_user is served by /auth/v1/validate.
+var cacheableEndpoints = []cacheableEndpoint{
+	{path: "/analytics/v1/dimension", invalidatedBy: "/analytics/v1/"},
+	{path: "/analytics/v1/dimensions", invalidatedBy: "/analytics/v1/"},
+	{path: "/auth/v1/validate", invalidatedBy: "/iam/v1/"},
+	{path: "/iam/v1/roles", invalidatedBy: "/iam/v1/"},
+	{path: "/support/v1/metadata/platforms", invalidatedBy: "/support/v1/"},
+	{path: "/support/v1/metadata/products", invalidatedBy: "/support/v1/"},
+}
+
+// cachedResponse is a fully read response that can be handed to any number
+// of callers.
+type cachedResponse struct {
+	status int
+	header http.Header
+	body   []byte
+
+	// invalidatedBy is copied from the endpoint the response came from.
+	invalidatedBy string
+}
+
+// toResponse returns a fresh *http.Response for req; each caller gets its own
+// body reader.
+func (r *cachedResponse) toResponse(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.status, http.StatusText(r.status)),
+		StatusCode:    r.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(r.body)),
+		ContentLength: int64(len(r.body)),
+		Request:       req,
+	}
+}
+
+// responseCache holds reference-data responses for one provider instance and
+// coalesces concurrent identical requests into one.
+//
+// Only 200 responses are stored. Coalesced callers share whatever the single
+// request returned, errors included, but a failure is never cached.
+type responseCache struct {
+	mu      sync.Mutex
+	entries map[string]*cachedResponse
+
+	// generation counts invalidations per invalidatedBy prefix. A fill that
+	// started before an invalidation is not stored, so a GET racing a write
+	// cannot put pre-write data back.
+	generation map[string]uint64
+
+	group singleflight.Group
+
+	// flights holds the context of each shared request in progress, keyed
+	// like entries.
+	flights map[string]*flight
+}
+
+// flight is the context a shared request runs under. It is detached from
+// the context of the caller that started the request, so that caller giving
+// up does not fail the others, and canceled once every caller waiting for
+// the request has given up, so an abandoned request does not keep retrying.
+type flight struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	waiters int
+}
+
+func newResponseCache() *responseCache {
+	return &responseCache{
+		entries:    map[string]*cachedResponse{},
+		generation: map[string]uint64{},
+		flights:    map[string]*flight{},
+	}
+}
+
+// join registers the caller of req as waiting for the shared request for
+// key, and returns the context that request runs under.
+func (c *responseCache) join(req *http.Request, key string) context.Context {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	f, ok := c.flights[key]
+	if !ok {
+		ctx, cancel := context.WithCancel(context.WithoutCancel(req.Context()))
+		f = &flight{ctx: ctx, cancel: cancel}
+		c.flights[key] = f
+	}
+	f.waiters++
+	return f.ctx
+}
+
+// leave unregisters a caller joined for key, canceling the shared request
+// once no caller is left.
+func (c *responseCache) leave(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	f := c.flights[key]
+	f.waiters--
+	if f.waiters == 0 {
+		f.cancel()
+		delete(c.flights, key)
+	}
+}
+
+// endpointFor returns the cacheable endpoint req targets, if any.
+func endpointFor(req *http.Request) (cacheableEndpoint, bool) {
+	if req.Method != http.MethodGet {
+		return cacheableEndpoint{}, false
+	}
+	for _, e := range cacheableEndpoints {
+		if strings.HasSuffix(req.URL.Path, e.path) {
+			return e, true
+		}
+	}
+	return cacheableEndpoint{}, false
+}
+
+// cacheKey identifies a response. The tenant header is part of it because
+// DoiT employees can scope individual requests to different customers.
+func cacheKey(req *http.Request) string {
+	return req.URL.String() + "\x00" + req.Header.Get("X-Tenant-Id")
+}
+
+// Do serves req from the cache when possible, and otherwise sends it through
+// next. Writes are sent through next and then invalidate the endpoints they
+// can affect.
+func (c *responseCache) Do(req *http.Request, next func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	endpoint, ok := endpointFor(req)
+	if !ok {
+		if req.Method != http.MethodGet && req.Method != http.MethodHead {
+			defer c.invalidate(req.Context(), req.URL.Path)
+		}
+		return next(req)
+	}
+
+	key := cacheKey(req)
+	c.mu.Lock()
+	cached, hit := c.entries[key]
+	gen := c.generation[endpoint.invalidatedBy]
+	c.mu.Unlock()
+	if hit {
+		tflog.Debug(req.Context(), "Response cache hit", map[string]any{"url": req.URL.String()})
+		return cached.toResponse(req), nil
+	}
+
+	flightCtx := c.join(req, key)
+	defer c.leave(key)
+	ch := c.group.DoChan(key, func() (any, error) {
+		resp, err := next(req.WithContext(flightCtx))
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		r := &cachedResponse{status: resp.StatusCode, header: resp.Header, body: body, invalidatedBy: endpoint.invalidatedBy}
+		if resp.StatusCode == http.StatusOK {
+			c.mu.Lock()
+			if c.generation[endpoint.invalidatedBy] == gen {
+				c.entries[key] = r
+			}
+			c.mu.Unlock()
+		}
+		return r, nil
+	})
+
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		if res.Shared {
+			tflog.Debug(req.Context(), "Response cache coalesced identical request", map[string]any{"url": req.URL.String()})
+		}
+		return res.Val.(*cachedResponse).toResponse(req), nil
+	case <-req.Context().Done():
+		// The shared request runs under its own context, so each caller gives
+		// up on its own without failing the others.
+		return nil, req.Context().Err()
+	}
+}
+
+// invalidate drops every entry a write to path can have made stale.
+func (c *responseCache) invalidate(ctx context.Context, path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, e := range cacheableEndpoints {
+		if strings.Contains(path, e.invalidatedBy) {
+			c.generation[e.invalidatedBy]++
+		}
+	}
+	for key, r := range c.entries {
+		if strings.Contains(path, r.invalidatedBy) {
+			delete(c.entries, key)
+			tflog.Debug(ctx, "Response cache entry invalidated", map[string]any{"write_path": path})
+		}
+	}
+}
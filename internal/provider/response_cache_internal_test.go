@@ -0,0 +1,224 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// cacheTestServer counts requests per path and answers every GET with the
+// path itself, or with 404 for paths ending in "/missing".
+func cacheTestServer(t *testing.T, release <-chan struct{}) (*httptest.Server, *sync.Map) {
+	t.Helper()
+	var counts sync.Map
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n, _ := counts.LoadOrStore(r.Method+" "+r.URL.Path, new(atomic.Int32))
+		n.(*atomic.Int32).Add(1)
+		if release != nil {
+			<-release
+		}
+		if r.URL.Query().Get("id") == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = io.WriteString(w, r.URL.Path)
+	}))
+	t.Cleanup(server.Close)
+	return server, &counts
+}
+
+func requestCount(counts *sync.Map, key string) int32 {
+	n, ok := counts.Load(key)
+	if !ok {
+		return 0
+	}
+	return n.(*atomic.Int32).Load()
+}
+
+func cachedClient() *DCIRetryClient {
+	c := newTestRetryClient(5*time.Second, constantBackOff(time.Millisecond))
+	c.cache = newResponseCache()
+	return c
+}
+
+func doRequest(t *testing.T, c *DCIRetryClient, method, url, tenant string) (int, string) {
+	t.Helper()
+	req, err := http.NewRequestWithContext(context.Background(), method, url, nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	if tenant != "" {
+		req.Header.Set("X-Tenant-Id", tenant)
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, url, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("reading body: %v", err)
+	}
+	return resp.StatusCode, string(body)
+}
+
+func TestResponseCache_Hit(t *testing.T) {
+	t.Parallel()
+	server, counts := cacheTestServer(t, nil)
+	c := cachedClient()
+
+	for range 3 {
+		if status, body := doRequest(t, c, http.MethodGet, server.URL+"/analytics/v1/dimensions", ""); status != http.StatusOK || body != "/analytics/v1/dimensions" {
+			t.Fatalf("got %d %q", status, body)
+		}
+	}
+	if n := requestCount(counts, "GET /analytics/v1/dimensions"); n != 1 {
+		t.Errorf("dimensions requests = %d, want 1", n)
+	}
+
+	// A different tenant is a different response.
+	doRequest(t, c, http.MethodGet, server.URL+"/analytics/v1/dimensions", "customer-2")
+	if n := requestCount(counts, "GET /analytics/v1/dimensions"); n != 2 {
+		t.Errorf("dimensions requests after tenant switch = %d, want 2", n)
+	}
+}
+
+func TestResponseCache_NotCached(t *testing.T) {
+	t.Parallel()
+	server, counts := cacheTestServer(t, nil)
+	c := cachedClient()
+
+	// Managed objects are never cached.
+	doRequest(t, c, http.MethodGet, server.URL+"/analytics/v1/reports/r1", "")
+	doRequest(t, c, http.MethodGet, server.URL+"/analytics/v1/reports/r1", "")
+	if n := requestCount(counts, "GET /analytics/v1/reports/r1"); n != 2 {
+		t.Errorf("report requests = %d, want 2", n)
+	}
+
+	// Nor are failures on cacheable endpoints.
+	for range 2 {
+		if status, _ := doRequest(t, c, http.MethodGet, server.URL+"/analytics/v1/dimension?id=missing", ""); status != http.StatusNotFound {
+			t.Fatalf("status = %d, want 404", status)
+		}
+	}
+	if n := requestCount(counts, "GET /analytics/v1/dimension"); n != 2 {
+		t.Errorf("missing dimension requests = %d, want 2", n)
+	}
+}
+
+func TestResponseCache_WriteInvalidates(t *testing.T) {
+	t.Parallel()
+	server, counts := cacheTestServer(t, nil)
+	c := cachedClient()
+
+	doRequest(t, c, http.MethodGet, server.URL+"/iam/v1/roles", "")
+	doRequest(t, c, http.MethodGet, server.URL+"/support/v1/metadata/platforms", "")
+
+	doRequest(t, c, http.MethodPost, server.URL+"/iam/v1/users", "")
+
+	doRequest(t, c, http.MethodGet, server.URL+"/iam/v1/roles", "")
+	doRequest(t, c, http.MethodGet, server.URL+"/support/v1/metadata/platforms", "")
+
+	if n := requestCount(counts, "GET /iam/v1/roles"); n != 2 {
+		t.Errorf("roles requests = %d, want 2 after an IAM write", n)
+	}
+	if n := requestCount(counts, "GET /support/v1/metadata/platforms"); n != 1 {
+		t.Errorf("platforms requests = %d, want 1: an IAM write must not invalidate support metadata", n)
+	}
+}
+
+func TestResponseCache_Coalesces(t *testing.T) {
+	t.Parallel()
+	release := make(chan struct{})
+	server, counts := cacheTestServer(t, release)
+	c := cachedClient()
+
+	const callers = 10
+	var wg sync.WaitGroup
+	for range callers {
+		wg.Go(func() {
+			doRequest(t, c, http.MethodGet, server.URL+"/support/v1/metadata/products", "")
+		})
+	}
+
+	// Let the single in-flight request finish once it has arrived.
+	deadline := time.Now().Add(5 * time.Second)
+	for requestCount(counts, "GET /support/v1/metadata/products") == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	if n := requestCount(counts, "GET /support/v1/metadata/products"); n != 1 {
+		t.Errorf("products requests = %d, want 1 for %d concurrent callers", n, callers)
+	}
+}
+
+// TestResponseCache_LeaderCancelDoesNotFailFollowers verifies that the caller
+// whose request is shared can give up without failing the callers that
+// joined it.
+func TestResponseCache_LeaderCancelDoesNotFailFollowers(t *testing.T) {
+	t.Parallel()
+	release := make(chan struct{})
+	server, counts := cacheTestServer(t, release)
+	c := cachedClient()
+	url := server.URL + "/support/v1/metadata/products"
+
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		req, _ := http.NewRequestWithContext(leaderCtx, http.MethodGet, url, nil)
+		resp, err := c.Do(req)
+		if err == nil {
+			resp.Body.Close()
+		}
+		leaderErr <- err
+	}()
+	deadline := time.Now().Add(5 * time.Second)
+	for requestCount(counts, "GET /support/v1/metadata/products") == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	var (
+		follower sync.WaitGroup
+		status   int
+		body     string
+	)
+	follower.Go(func() {
+		status, body = doRequest(t, c, http.MethodGet, url, "")
+	})
+	waiters := func() int {
+		c.cache.mu.Lock()
+		defer c.cache.mu.Unlock()
+		if f, ok := c.cache.flights[cacheKey(httptest.NewRequest(http.MethodGet, url, nil))]; ok {
+			return f.waiters
+		}
+		return 0
+	}
+	for waiters() < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	cancelLeader()
+	if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("leader error = %v, want context.Canceled", err)
+	}
+	close(release)
+	follower.Wait()
+
+	if status != http.StatusOK || body != "/support/v1/metadata/products" {
+		t.Errorf("follower got %d %q, want the shared response", status, body)
+	}
+	if n := requestCount(counts, "GET /support/v1/metadata/products"); n != 1 {
+		t.Errorf("products requests = %d, want 1", n)
+	}
+	if n := len(c.cache.flights); n != 0 {
+		t.Errorf("%d flights left after every caller returned", n)
+	}
+}
@@ -0,0 +1,170 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/cenkalti/backoff/v5"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// RetryPolicy tunes how DCIRetryClient retries transient failures. It is set
+// from the provider's retry {} block; DefaultRetryPolicy holds the values used
+// when the block is absent.
+//
+// Overrides are checked by validateRetryPolicy (timeouts.go) against the same
+// invariants the compile-time assertions enforce for the defaults.
+type RetryPolicy struct {
+	// InitialInterval is the first backoff delay, and the floor for an
+	// honored Retry-After.
+	InitialInterval time.Duration
+
+	// MaxInterval caps each backoff delay. It is also the Retry-After cap
+	// (see maxRetryAfter), so no single wait ever exceeds it.
+	MaxInterval time.Duration
+
+	// MaxAttempts bounds the number of attempts per request, the first one
+	// included. Zero means no limit: the operation timeout alone bounds the
+	// retry loop.
+	MaxAttempts uint
+
+	// ExtraRetryableStatuses lists status codes to retry in addition to
+	// 429/502/503/504, keyed by upper-case HTTP method. This is how a user
+	// opts idempotent GETs into retrying 500, for example.
+	ExtraRetryableStatuses map[string][]int
+}
+
+// DefaultRetryPolicy returns the policy DCIRetryClient uses unless the
+// provider configuration overrides it.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		InitialInterval: retryInitialInterval,
+		MaxInterval:     retryMaxInterval,
+	}
+}
+
+// newBackOff builds a fresh exponential policy for one Do call.
+func (p RetryPolicy) newBackOff() *backoff.ExponentialBackOff {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = p.InitialInterval
+	b.Multiplier = retryMultiplier
+	b.MaxInterval = p.MaxInterval
+	return b
+}
+
+// retryOptions returns the backoff.Retry options that depend on the policy.
+func (p RetryPolicy) retryOptions() []backoff.RetryOption {
+	if p.MaxAttempts == 0 {
+		return nil
+	}
+	return []backoff.RetryOption{backoff.WithMaxTries(p.MaxAttempts)}
+}
+
+// isExtraRetryable reports whether the user asked for status to be retried
+// for requests with the given method.
+func (p RetryPolicy) isExtraRetryable(method string, status int) bool {
+	return slices.Contains(p.ExtraRetryableStatuses[method], status)
+}
+
+// retryPolicyMethods are the HTTP methods accepted as keys of
+// extra_retryable_statuses.
+var retryPolicyMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+}
+
+// idempotentMethod reports whether repeating a request with this method is
+// safe. Retrying a POST or PATCH that failed with a 5xx can apply it twice,
+// since the server may have acted before failing.
+func idempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryModel maps the provider's retry {} block.
+type retryModel struct {
+	InitialInterval        types.String `tfsdk:"initial_interval"`
+	MaxInterval            types.String `tfsdk:"max_interval"`
+	MaxAttempts            types.Int64  `tfsdk:"max_attempts"`
+	ExtraRetryableStatuses types.Map    `tfsdk:"extra_retryable_statuses"`
+}
+
+// retryPolicyFromConfig resolves the provider's retry {} block into a
+// RetryPolicy, starting from DefaultRetryPolicy for anything left unset, and
+// validates the result. A null block yields the defaults unchanged.
+func retryPolicyFromConfig(ctx context.Context, block types.Object) (RetryPolicy, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	policy := DefaultRetryPolicy()
+	if block.IsNull() {
+		return policy, diags
+	}
+
+	var m retryModel
+	diags.Append(block.As(ctx, &m, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true})...)
+	if diags.HasError() {
+		return policy, diags
+	}
+	if block.IsUnknown() || m.InitialInterval.IsUnknown() || m.MaxInterval.IsUnknown() ||
+		m.MaxAttempts.IsUnknown() || m.ExtraRetryableStatuses.IsUnknown() {
+		diags.AddAttributeError(
+			retryPath,
+			"Unknown Retry Configuration",
+			"The provider cannot be configured because part of the retry block is not known until apply. "+
+				"Set the values statically in the configuration.",
+		)
+		return policy, diags
+	}
+
+	parseInterval := func(name string, v types.String, dst *time.Duration) {
+		if v.IsNull() {
+			return
+		}
+		d, err := time.ParseDuration(v.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				retryPath.AtName(name),
+				"Invalid Retry Interval",
+				fmt.Sprintf("Could not parse retry.%s %q as a duration: %s. Use Go duration format, e.g. \"2s\", \"1m\".", name, v.ValueString(), err),
+			)
+			return
+		}
+		*dst = d
+	}
+	parseInterval("initial_interval", m.InitialInterval, &policy.InitialInterval)
+	parseInterval("max_interval", m.MaxInterval, &policy.MaxInterval)
+
+	if !m.MaxAttempts.IsNull() {
+		policy.MaxAttempts = uint(max(m.MaxAttempts.ValueInt64(), 0))
+	}
+
+	if !m.ExtraRetryableStatuses.IsNull() {
+		var byMethod map[string][]int64
+		diags.Append(m.ExtraRetryableStatuses.ElementsAs(ctx, &byMethod, false)...)
+		policy.ExtraRetryableStatuses = make(map[string][]int, len(byMethod))
+		for method, statuses := range byMethod {
+			for _, status := range statuses {
+				policy.ExtraRetryableStatuses[method] = append(policy.ExtraRetryableStatuses[method], int(status))
+			}
+		}
+	}
+
+	if diags.HasError() {
+		return policy, diags
+	}
+
+	diags.Append(validateRetryPolicy(policy)...)
+	return policy, diags
+}
@@ -0,0 +1,179 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidateRetryPolicy(t *testing.T) {
+	t.Parallel()
+
+	withStatuses := func(method string, statuses ...int) RetryPolicy {
+		p := DefaultRetryPolicy()
+		p.ExtraRetryableStatuses = map[string][]int{method: statuses}
+		return p
+	}
+
+	tests := []struct {
+		name         string
+		policy       RetryPolicy
+		wantErrors   int
+		wantWarnings int
+	}{
+		{"defaults", DefaultRetryPolicy(), 0, 0},
+		{"zero initial interval", RetryPolicy{MaxInterval: time.Minute}, 1, 0},
+		{"max below initial", RetryPolicy{InitialInterval: 10 * time.Second, MaxInterval: time.Second}, 1, 0},
+		{"max interval eats operation budget", RetryPolicy{InitialInterval: time.Second, MaxInterval: 5 * time.Minute}, 0, 1},
+		{"GET 500", withStatuses("GET", 500), 0, 0},
+		{"GET 409", withStatuses("GET", 409), 0, 0},
+		{"POST 500 warns", withStatuses("POST", 500), 0, 1},
+		{"lower-case method", withStatuses("get", 500), 1, 0},
+		{"404 rejected", withStatuses("GET", 404), 1, 0},
+		{"524 rejected", withStatuses("GET", 524), 1, 0},
+		{"non-error status rejected", withStatuses("GET", 302), 1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			diags := validateRetryPolicy(tt.policy)
+			if got := diags.ErrorsCount(); got != tt.wantErrors {
+				t.Errorf("errors = %d, want %d: %v", got, tt.wantErrors, diags)
+			}
+			if got := diags.WarningsCount(); got != tt.wantWarnings {
+				t.Errorf("warnings = %d, want %d: %v", got, tt.wantWarnings, diags)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyFromConfig(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	statuses, diags := types.MapValueFrom(ctx, types.ListType{ElemType: types.Int64Type}, map[string][]int64{"GET": {500}})
+	if diags.HasError() {
+		t.Fatalf("building map: %v", diags)
+	}
+	block, diags := types.ObjectValue(
+		map[string]attr.Type{
+			"initial_interval":         types.StringType,
+			"max_interval":             types.StringType,
+			"max_attempts":             types.Int64Type,
+			"extra_retryable_statuses": types.MapType{ElemType: types.ListType{ElemType: types.Int64Type}},
+		},
+		map[string]attr.Value{
+			"initial_interval":         types.StringValue("500ms"),
+			"max_interval":             types.StringNull(),
+			"max_attempts":             types.Int64Value(3),
+			"extra_retryable_statuses": statuses,
+		},
+	)
+	if diags.HasError() {
+		t.Fatalf("building block: %v", diags)
+	}
+
+	policy, diags := retryPolicyFromConfig(ctx, block)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if policy.InitialInterval != 500*time.Millisecond {
+		t.Errorf("InitialInterval = %s, want 500ms", policy.InitialInterval)
+	}
+	if policy.MaxInterval != retryMaxInterval {
+		t.Errorf("MaxInterval = %s, want the default %s", policy.MaxInterval, retryMaxInterval)
+	}
+	if policy.MaxAttempts != 3 {
+		t.Errorf("MaxAttempts = %d, want 3", policy.MaxAttempts)
+	}
+	if !policy.isExtraRetryable(http.MethodGet, 500) || policy.isExtraRetryable(http.MethodPost, 500) {
+		t.Errorf("ExtraRetryableStatuses = %v, want GET 500 only", policy.ExtraRetryableStatuses)
+	}
+}
+
+// TestDCIRetryClient_ExtraRetryableStatuses confirms a configured status is
+// retried only for the method it is listed under.
+func TestDCIRetryClient_ExtraRetryableStatuses(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		method       string
+		wantAttempts int32
+		wantStatus   int
+	}{
+		{http.MethodGet, 2, http.StatusOK},
+		{http.MethodPost, 1, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			t.Parallel()
+
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if attempts.Add(1) == 1 {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			client := newTestRetryClient(5*time.Second, constantBackOff(time.Millisecond))
+			policy := DefaultRetryPolicy()
+			policy.ExtraRetryableStatuses = map[string][]int{http.MethodGet: {http.StatusInternalServerError}}
+			client.policy = &policy
+
+			req, err := http.NewRequestWithContext(context.Background(), tt.method, server.URL, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			resp, err := client.Do(req)
+			if err == nil {
+				defer resp.Body.Close()
+				if resp.StatusCode != tt.wantStatus {
+					t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+				}
+			} else if tt.wantStatus == http.StatusOK {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := attempts.Load(); got != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", got, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestDCIRetryClient_MaxAttempts(t *testing.T) {
+	t.Parallel()
+
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := newTestRetryClient(5*time.Second, constantBackOff(time.Millisecond))
+	policy := DefaultRetryPolicy()
+	policy.MaxAttempts = 3
+	client.policy = &policy
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	resp, err := client.Do(req)
+	if err == nil {
+		resp.Body.Close()
+		t.Fatal("expected an error once attempts were exhausted")
+	}
+	if got := attempts.Load(); got != 3 {
+		t.Errorf("attempts = %d, want 3", got)
+	}
+}
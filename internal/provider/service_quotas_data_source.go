@@ -29,7 +29,8 @@ type serviceQuotasDataSource struct {
 
 type serviceQuotasDataSourceModel struct {
 	datasource_service_quotas.ServiceQuotasModel
-	Timeouts timeouts.Value `tfsdk:"timeouts"`
+	CustomerContext types.String   `tfsdk:"customer_context"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (d *serviceQuotasDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -63,6 +64,7 @@ func (d *serviceQuotasDataSource) Schema(ctx context.Context, _ datasource.Schem
 		s.Attributes["min_utilization_percent"] = minUtilAttr
 	}
 
+	s.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx)
 	resp.Schema = s
 }
@@ -80,6 +82,7 @@ func (d *serviceQuotasDataSource) Read(ctx context.Context, req datasource.ReadR
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	// Composite inputs (config as a whole) require IsFullyKnown to catch unknown elements.
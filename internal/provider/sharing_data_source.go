@@ -26,7 +26,8 @@ type (
 	}
 	sharingDataSourceModel struct {
 		datasource_sharing.SharingModel
-		Timeouts timeouts.Value `tfsdk:"timeouts"`
+		CustomerContext types.String   `tfsdk:"customer_context"`
+		Timeouts        timeouts.Value `tfsdk:"timeouts"`
 	}
 )
 
@@ -43,6 +44,7 @@ func (d *sharingDataSource) Schema(ctx context.Context, _ datasource.SchemaReque
 	s.Description = desc
 	s.MarkdownDescription = desc
 
+	s.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx)
 
 	resp.Schema = s
@@ -80,6 +82,7 @@ func (d *sharingDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	// If either input is unknown (depends on a resource not yet created),
@@ -20,11 +20,17 @@ import (
 
 type (
 	sharingResource struct {
-		client *models.ClientWithResponses
+		client   *models.ClientWithResponses
+		readOnly bool
+		// recipientValidation and customerContext configure
+		// validateRecipients; see recipient_validation.go.
+		recipientValidation string
+		customerContext     string
 	}
 	sharingResourceModel struct {
 		resource_sharing.SharingModel
-		Timeouts timeouts.Value `tfsdk:"timeouts"`
+		CustomerContext types.String   `tfsdk:"customer_context"`
+		Timeouts        timeouts.Value `tfsdk:"timeouts"`
 	}
 )
 
@@ -34,6 +40,7 @@ var (
 	_ resource.ResourceWithConfigure        = (*sharingResource)(nil)
 	_ resource.ResourceWithImportState      = (*sharingResource)(nil)
 	_ resource.ResourceWithConfigValidators = (*sharingResource)(nil)
+	_ resource.ResourceWithModifyPlan       = (*sharingResource)(nil)
 )
 
 // NewSharingResource creates a new resource sharing resource instance.
@@ -47,16 +54,26 @@ func (r *sharingResource) Configure(_ context.Context, req resource.ConfigureReq
 		return
 	}
 
-	client, ok := req.ProviderData.(*models.ClientWithResponses)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *models.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.readOnly = data.readOnly
+	r.recipientValidation = data.recipientValidation
+	r.customerContext = data.customerContext
+}
+
+// ModifyPlan checks the users of the planned permissions when the provider's
+// validate_recipients is set.
+func (r *sharingResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(validateRecipients(ctx, r.client, r.recipientValidation, r.customerContext, req.Plan, req.State,
+		path.MatchRoot("permissions").AtAnyListIndex().AtName("user"))...)
 }
 
 func (r *sharingResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -143,6 +160,7 @@ func (r *sharingResource) Schema(ctx context.Context, _ resource.SchemaRequest,
 		s.Attributes["public"] = attr
 	}
 
+	s.Attributes["customer_context"] = customerContextResourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx, timeouts.Opts{
 		Create: true,
 		Read:   true,
@@ -161,6 +179,11 @@ func (r *sharingResource) ConfigValidators(_ context.Context) []resource.ConfigV
 }
 
 func (r *sharingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_sharing", "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan sharingResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -174,6 +197,7 @@ func (r *sharingResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	ctx = withCustomerContext(ctx, plan.CustomerContext)
 	defer cancel()
 
 	// Build API request from plan
@@ -189,10 +213,7 @@ func (r *sharingResource) Create(ctx context.Context, req resource.CreateRequest
 	// Create (PUT) permissions via API
 	putResp, err := r.client.UpdateResourcePermissionWithResponse(ctx, resType, resID, apiReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Creating Resource Sharing",
-			"Could not set resource permissions, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Creating Resource Sharing", "Could not set resource permissions", err)...)
 		return
 	}
 
@@ -235,6 +256,7 @@ func (r *sharingResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
 
 	resp.Diagnostics.Append(r.populateState(ctx, &state)...)
@@ -252,6 +274,11 @@ func (r *sharingResource) Read(ctx context.Context, req resource.ReadRequest, re
 }
 
 func (r *sharingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_sharing", "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan sharingResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -265,6 +292,7 @@ func (r *sharingResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	ctx = withCustomerContext(ctx, plan.CustomerContext)
 	defer cancel()
 
 	// Build API request from plan
@@ -280,10 +308,7 @@ func (r *sharingResource) Update(ctx context.Context, req resource.UpdateRequest
 	// Update (PUT) permissions via API
 	putResp, err := r.client.UpdateResourcePermissionWithResponse(ctx, resType, resID, apiReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Updating Resource Sharing",
-			"Could not update resource permissions, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Updating Resource Sharing", "Could not update resource permissions", err)...)
 		return
 	}
 
@@ -313,6 +338,11 @@ func (r *sharingResource) Update(ctx context.Context, req resource.UpdateRequest
 }
 
 func (r *sharingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_sharing", "delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var state sharingResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -326,6 +356,7 @@ func (r *sharingResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
 
 	resType := state.ResourceType.ValueString()
@@ -338,10 +369,7 @@ func (r *sharingResource) Delete(ctx context.Context, req resource.DeleteRequest
 		resID,
 	)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Deleting Resource Sharing",
-			"Could not read current resource permissions: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Deleting Resource Sharing", "Could not read current resource permissions", err)...)
 		return
 	}
 
@@ -398,10 +426,7 @@ func (r *sharingResource) Delete(ctx context.Context, req resource.DeleteRequest
 		resetReq,
 	)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Deleting Resource Sharing",
-			"Could not reset resource permissions: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Deleting Resource Sharing", "Could not reset resource permissions", err)...)
 		return
 	}
 
@@ -415,12 +440,16 @@ func (r *sharingResource) Delete(ctx context.Context, req resource.DeleteRequest
 }
 
 func (r *sharingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, id, diags := importCustomerContext(ctx, req, resp, 2)
+	resp.Diagnostics.Append(diags...)
+	req.ID = id
+
 	// Import ID format: {resourceType}/{resourceId}
 	parts := strings.SplitN(req.ID, "/", 2)
 	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
 		resp.Diagnostics.AddError(
 			"Invalid Import ID",
-			fmt.Sprintf("Expected import ID in the format 'resourceType/resourceId' (e.g. 'reports/abc123'), got: %s", req.ID),
+			fmt.Sprintf("Expected import ID in the format 'resourceType/resourceId' (e.g. 'reports/abc123'), optionally prefixed with 'customerContext/', got: %s", req.ID),
 		)
 		return
 	}
@@ -443,10 +472,7 @@ func (r *sharingResource) populateState(ctx context.Context, state *sharingResou
 		resID,
 	)
 	if err != nil {
-		diags.AddError(
-			"Error Reading Resource Sharing",
-			"Could not read resource permissions: "+err.Error(),
-		)
+		diags.Append(apiErrorDiagnostics("Error Reading Resource Sharing", "Could not read resource permissions", err)...)
 		return diags
 	}
 
@@ -27,7 +27,8 @@ type supportRequestCommentsDataSource struct {
 
 type supportRequestCommentsDataSourceModel struct {
 	ds.SupportRequestCommentsModel
-	Timeouts timeouts.Value `tfsdk:"timeouts"`
+	CustomerContext types.String   `tfsdk:"customer_context"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (d *supportRequestCommentsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -52,6 +53,7 @@ func (d *supportRequestCommentsDataSource) Configure(_ context.Context, req data
 func (d *supportRequestCommentsDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	s := ds.SupportRequestCommentsDataSourceSchema(ctx)
 
+	s.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx)
 
 	resp.Schema = s
@@ -70,6 +72,7 @@ func (d *supportRequestCommentsDataSource) Read(ctx context.Context, req datasou
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, data.CustomerContext)
 	defer cancel()
 
 	// If ticket_id is unknown (depends on a resource not yet created), return early
@@ -26,7 +26,8 @@ type supportRequestDataSource struct {
 
 type supportRequestDataSourceModel struct {
 	datasource_support_request.SupportRequestModel
-	Timeouts timeouts.Value `tfsdk:"timeouts"`
+	CustomerContext types.String   `tfsdk:"customer_context"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (ds *supportRequestDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -51,6 +52,7 @@ func (ds *supportRequestDataSource) Configure(_ context.Context, req datasource.
 func (ds *supportRequestDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	s := datasource_support_request.SupportRequestDataSourceSchema(ctx)
 
+	s.Attributes["customer_context"] = customerContextDataSourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx)
 
 	resp.Schema = s
@@ -69,6 +71,7 @@ func (ds *supportRequestDataSource) Read(ctx context.Context, req datasource.Rea
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
 
 	// If ticket_id is unknown (depends on a resource not yet created), return early
@@ -16,10 +16,7 @@ import (
 func (r *supportRequestTagsResource) fetchCurrentTags(ctx context.Context, ticketID int64) (tags []string, notFound bool, diags diag.Diagnostics) {
 	tagsResp, err := r.client.ListTicketTagsWithResponse(ctx, ticketID)
 	if err != nil {
-		diags.AddError(
-			"Error Reading Support Request Tags",
-			"Could not read tags: "+err.Error(),
-		)
+		diags.Append(apiErrorDiagnostics("Error Reading Support Request Tags", "Could not read tags", err)...)
 		return nil, false, diags
 	}
 
@@ -161,7 +158,7 @@ func (r *supportRequestTagsResource) syncTags(ctx context.Context, ticketId int6
 		}
 		removeResp, err := r.client.RemoveTicketTagsWithResponse(ctx, ticketId, removeReq)
 		if err != nil {
-			diags.AddError("Error Syncing Tags", "Could not remove tags: "+err.Error())
+			diags.Append(apiErrorDiagnostics("Error Syncing Tags", "Could not remove tags", err)...)
 			return
 		}
 		if removeResp.StatusCode() != 200 {
@@ -179,7 +176,7 @@ func (r *supportRequestTagsResource) syncTags(ctx context.Context, ticketId int6
 		}
 		addResp, err := r.client.AddTicketTagsWithResponse(ctx, ticketId, addReq)
 		if err != nil {
-			diags.AddError("Error Syncing Tags", "Could not add tags: "+err.Error())
+			diags.Append(apiErrorDiagnostics("Error Syncing Tags", "Could not add tags", err)...)
 			return
 		}
 		if addResp.StatusCode() != 200 {
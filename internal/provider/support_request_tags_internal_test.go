@@ -271,10 +271,11 @@ func buildTagsPlan(ctx context.Context, t *testing.T, s schema.Schema, ticketID
 	}
 
 	planValues := map[string]tftypes.Value{
-		"id":        tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
-		"ticket_id": tftypes.NewValue(tftypes.Number, new(big.Float).SetInt64(ticketID)),
-		"tags":      tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, tagVals),
-		"timeouts":  tftypes.NewValue(s.Attributes["timeouts"].GetType().TerraformType(ctx), nil),
+		"id":               tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"ticket_id":        tftypes.NewValue(tftypes.Number, new(big.Float).SetInt64(ticketID)),
+		"tags":             tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, tagVals),
+		"timeouts":         tftypes.NewValue(s.Attributes["timeouts"].GetType().TerraformType(ctx), nil),
+		"customer_context": tftypes.NewValue(tftypes.String, nil),
 	}
 
 	raw := tftypes.NewValue(
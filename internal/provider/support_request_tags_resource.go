@@ -30,13 +30,15 @@ import (
 // generated package is therefore intentionally unused.
 type (
 	supportRequestTagsResource struct {
-		client *models.ClientWithResponses
+		client   *models.ClientWithResponses
+		readOnly bool
 	}
 	supportRequestTagsResourceModel struct {
-		Id       types.String   `tfsdk:"id"`
-		TicketId types.Int64    `tfsdk:"ticket_id"`
-		Tags     types.Set      `tfsdk:"tags"`
-		Timeouts timeouts.Value `tfsdk:"timeouts"`
+		Id              types.String   `tfsdk:"id"`
+		TicketId        types.Int64    `tfsdk:"ticket_id"`
+		Tags            types.Set      `tfsdk:"tags"`
+		CustomerContext types.String   `tfsdk:"customer_context"`
+		Timeouts        timeouts.Value `tfsdk:"timeouts"`
 	}
 )
 
@@ -55,16 +57,17 @@ func (r *supportRequestTagsResource) Configure(_ context.Context, req resource.C
 		return
 	}
 
-	client, ok := req.ProviderData.(*models.ClientWithResponses)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *models.ClientWithResponses, got: %T.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.readOnly = data.readOnly
 }
 
 func (r *supportRequestTagsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -112,6 +115,7 @@ func (r *supportRequestTagsResource) Schema(ctx context.Context, _ resource.Sche
 					),
 				},
 			},
+			"customer_context": customerContextResourceAttribute(),
 			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
 				Create: true,
 				Read:   true,
@@ -123,6 +127,11 @@ func (r *supportRequestTagsResource) Schema(ctx context.Context, _ resource.Sche
 }
 
 func (r *supportRequestTagsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_support_request_tags", "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan supportRequestTagsResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -135,6 +144,7 @@ func (r *supportRequestTagsResource) Create(ctx context.Context, req resource.Cr
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	ctx = withCustomerContext(ctx, plan.CustomerContext)
 	defer cancel()
 
 	ticketId := plan.TicketId.ValueInt64()
@@ -184,6 +194,7 @@ func (r *supportRequestTagsResource) Read(ctx context.Context, req resource.Read
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
 
 	resp.Diagnostics.Append(r.populateState(ctx, &state)...)
@@ -199,6 +210,11 @@ func (r *supportRequestTagsResource) Read(ctx context.Context, req resource.Read
 }
 
 func (r *supportRequestTagsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_support_request_tags", "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan supportRequestTagsResourceModel
 	var state supportRequestTagsResourceModel
 
@@ -214,6 +230,7 @@ func (r *supportRequestTagsResource) Update(ctx context.Context, req resource.Up
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	ctx = withCustomerContext(ctx, plan.CustomerContext)
 	defer cancel()
 
 	oldTags := tagsToStringSlice(state.Tags, &resp.Diagnostics)
@@ -234,6 +251,11 @@ func (r *supportRequestTagsResource) Update(ctx context.Context, req resource.Up
 }
 
 func (r *supportRequestTagsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_support_request_tags", "delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var state supportRequestTagsResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -246,6 +268,7 @@ func (r *supportRequestTagsResource) Delete(ctx context.Context, req resource.De
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
 
 	tags := tagsToStringSlice(state.Tags, &resp.Diagnostics)
@@ -260,10 +283,7 @@ func (r *supportRequestTagsResource) Delete(ctx context.Context, req resource.De
 
 		removeResp, err := r.client.RemoveTicketTagsWithResponse(ctx, state.TicketId.ValueInt64(), removeReq)
 		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error Deleting Support Request Tags",
-				"Could not remove tags from support request: "+err.Error(),
-			)
+			resp.Diagnostics.Append(apiErrorDiagnostics("Error Deleting Support Request Tags", "Could not remove tags from support request", err)...)
 			return
 		}
 
@@ -278,6 +298,10 @@ func (r *supportRequestTagsResource) Delete(ctx context.Context, req resource.De
 }
 
 func (r *supportRequestTagsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, id, diags := importCustomerContext(ctx, req, resp, 1)
+	resp.Diagnostics.Append(diags...)
+	req.ID = id
+
 	ticketId, err := strconv.ParseInt(req.ID, 10, 64)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -2,7 +2,11 @@ package provider
 
 import (
 	"fmt"
+	"maps"
+	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -150,3 +154,95 @@ func validateRequestTimeout(requestTimeout time.Duration) diag.Diagnostics {
 
 	return diags
 }
+
+// retryPath is the provider block validateRetryPolicy reports against.
+var retryPath = path.Root("retry")
+
+// validateRetryPolicy checks a retry policy from the provider's retry {} block
+// against the invariants the compile-time assertions enforce for the defaults.
+//
+// As with validateRequestTimeout, a wait that could outlast the operation
+// budget is only a warning: the user may have raised the timeouts {} blocks to
+// match, and the provider cannot see them from Configure.
+func validateRetryPolicy(p RetryPolicy) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if p.InitialInterval <= 0 {
+		diags.AddAttributeError(
+			retryPath.AtName("initial_interval"),
+			"Invalid Retry Initial Interval",
+			fmt.Sprintf("retry.initial_interval must be a positive duration, got %s.", formatSeconds(p.InitialInterval)),
+		)
+	}
+	if p.MaxInterval < p.InitialInterval {
+		diags.AddAttributeError(
+			retryPath.AtName("max_interval"),
+			"Invalid Retry Max Interval",
+			fmt.Sprintf("retry.max_interval (%s) must not be less than retry.initial_interval (%s).",
+				formatSeconds(p.MaxInterval), formatSeconds(p.InitialInterval)),
+		)
+	}
+
+	// The runtime counterpart of the maxRetryAfter assertion in client.go:
+	// max_interval also caps Retry-After, and a wait that reaches the operation
+	// timeout leaves no budget for the retry it was waiting for.
+	if p.MaxInterval > DefaultReadTimeout-minRetryHeadroom {
+		diags.AddAttributeWarning(
+			retryPath.AtName("max_interval"),
+			"Retry Max Interval Exceeds Operation Defaults",
+			fmt.Sprintf("retry.max_interval is %s, which leaves less than %s of the default operation timeout "+
+				"of %s for the retry itself. A single wait can then consume the whole operation budget. "+
+				"Lower max_interval, or raise the timeouts {} block on the affected resources and data sources "+
+				"to at least %s.",
+				formatSeconds(p.MaxInterval), formatSeconds(minRetryHeadroom), formatSeconds(DefaultReadTimeout),
+				formatSeconds(p.MaxInterval+minRetryHeadroom)),
+		)
+	}
+
+	for _, method := range slices.Sorted(maps.Keys(p.ExtraRetryableStatuses)) {
+		statuses := p.ExtraRetryableStatuses[method]
+		statusPath := retryPath.AtName("extra_retryable_statuses").AtMapKey(method)
+		if !slices.Contains(retryPolicyMethods, method) {
+			diags.AddAttributeError(
+				statusPath,
+				"Invalid Retry Method",
+				fmt.Sprintf("%q is not a supported HTTP method; use one of %s.", method, strings.Join(retryPolicyMethods, ", ")),
+			)
+			continue
+		}
+		for _, status := range statuses {
+			switch {
+			case status < 400 || status > 599:
+				diags.AddAttributeError(
+					statusPath,
+					"Invalid Retryable Status",
+					fmt.Sprintf("%d is not an HTTP error status; only 4xx and 5xx responses can be retried.", status),
+				)
+			case status == http.StatusNotFound:
+				diags.AddAttributeError(
+					statusPath,
+					"Invalid Retryable Status",
+					"404 cannot be retried: it is passed through so resources can detect objects deleted outside Terraform.",
+				)
+			case status == httpStatusCloudflareTimeout:
+				// The request timeout > edge timeout invariant exists so that a
+				// 524 fails fast; retrying it would re-run a query that already
+				// used the whole edge timeout.
+				diags.AddAttributeError(
+					statusPath,
+					"Invalid Retryable Status",
+					fmt.Sprintf("524 cannot be retried: it means the API gave up after %s, and an identical "+
+						"request would only time out again.", formatSeconds(cloudflareEdgeTimeout)),
+				)
+			case status >= 500 && !idempotentMethod(method):
+				diags.AddAttributeWarning(
+					statusPath,
+					"Retrying Non-Idempotent Requests",
+					fmt.Sprintf("Retrying %s requests on %d can apply a change twice if the API acted before failing.", method, status),
+				)
+			}
+		}
+	}
+
+	return diags
+}
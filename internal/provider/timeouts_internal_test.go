@@ -76,13 +76,13 @@ func TestMaxRetryAfter_LeavesRetryBudget(t *testing.T) {
 	}
 }
 
-// TestNewRetryBackOff_Config verifies the retry policy is configured from the
+// TestDefaultRetryPolicy_BackOffConfig verifies the retry policy is configured from the
 // retry* constants. The DoiT API returns 429 without a Retry-After header, so
 // this policy governs the pace of nearly every retry.
-func TestNewRetryBackOff_Config(t *testing.T) {
+func TestDefaultRetryPolicy_BackOffConfig(t *testing.T) {
 	t.Parallel()
 
-	b := newRetryBackOff()
+	b := DefaultRetryPolicy().newBackOff()
 
 	if b.InitialInterval != retryInitialInterval {
 		t.Errorf("InitialInterval = %s, want %s", b.InitialInterval, retryInitialInterval)
@@ -100,17 +100,17 @@ func TestNewRetryBackOff_Config(t *testing.T) {
 	}
 }
 
-// TestNewRetryBackOff_Grows verifies the interval sequence grows geometrically
+// TestDefaultRetryPolicy_BackOffGrows verifies the interval sequence grows geometrically
 // and saturates at retryMaxInterval.
 //
 // Jitter is disabled for this check. With the production RandomizationFactor the
 // windows of consecutive intervals overlap, so asserting that each interval
 // exceeds its predecessor would be intermittently flaky —
-// TestNewRetryBackOff_JitterBounds covers the randomized case instead.
-func TestNewRetryBackOff_Grows(t *testing.T) {
+// TestDefaultRetryPolicy_BackOffJitterBounds covers the randomized case instead.
+func TestDefaultRetryPolicy_BackOffGrows(t *testing.T) {
 	t.Parallel()
 
-	b := newRetryBackOff()
+	b := DefaultRetryPolicy().newBackOff()
 	b.RandomizationFactor = 0
 	b.Reset()
 
@@ -132,12 +132,12 @@ func TestNewRetryBackOff_Grows(t *testing.T) {
 	}
 }
 
-// TestNewRetryBackOff_JitterBounds verifies every randomized interval stays
+// TestDefaultRetryPolicy_BackOffJitterBounds verifies every randomized interval stays
 // within the jitter window around its base interval.
-func TestNewRetryBackOff_JitterBounds(t *testing.T) {
+func TestDefaultRetryPolicy_BackOffJitterBounds(t *testing.T) {
 	t.Parallel()
 
-	b := newRetryBackOff()
+	b := DefaultRetryPolicy().newBackOff()
 	b.Reset()
 
 	base := retryInitialInterval
@@ -206,7 +206,7 @@ func TestParseRetryAfter(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			got, ok := parseRetryAfter(tc.header, now)
+			got, ok := parseRetryAfter(tc.header, now, retryInitialInterval, maxRetryAfter)
 
 			if ok != tc.wantOK {
 				t.Fatalf("parseRetryAfter(%q) ok = %v, want %v", tc.header, ok, tc.wantOK)
@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"golang.org/x/oauth2"
+)
+
+// Credential sources other than a literal api_token. Both are consulted while
+// the provider runs, not just at Configure, so a token rotated by a Vault
+// agent or an SSO helper is used by the next request.
+const (
+	// apiTokenCommandTimeout bounds a single run of api_token_command.
+	apiTokenCommandTimeout = 30 * time.Second
+
+	// apiTokenCommandDefaultTTL is how long a token from api_token_command
+	// that carries no expiration is reused before the command runs again.
+	apiTokenCommandDefaultTTL = 15 * time.Minute
+)
+
+// fileTokenSource reads the API token from a file, re-reading it whenever the
+// file's size or modification time changes.
+type fileTokenSource struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+	token   string
+}
+
+func newFileTokenSource(path string) *fileTokenSource {
+	return &fileTokenSource{path: path}
+}
+
+// Token implements oauth2.TokenSource.
+func (s *fileTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("reading api_token_file: %w", err)
+	}
+	if s.token == "" || !info.ModTime().Equal(s.modTime) || info.Size() != s.size {
+		data, err := os.ReadFile(s.path)
+		if err != nil {
+			return nil, fmt.Errorf("reading api_token_file: %w", err)
+		}
+		token := strings.TrimSpace(string(data))
+		if token == "" {
+			return nil, fmt.Errorf("api_token_file %s is empty", s.path)
+		}
+		s.token, s.modTime, s.size = token, info.ModTime(), info.Size()
+	}
+	return &oauth2.Token{AccessToken: s.token}, nil
+}
+
+// commandTokenOutput is the JSON form api_token_command may print. A command
+// may instead print the bare token, which is then reused for
+// apiTokenCommandDefaultTTL.
+type commandTokenOutput struct {
+	Token     string     `json:"token"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// commandTokenSource runs an external command, credential-process style, and
+// returns the token it prints. It does no caching of its own; wrap it in
+// oauth2.ReuseTokenSource, which reuses the token until shortly before its
+// expiry.
+type commandTokenSource struct {
+	argv []string
+	now  func() time.Time
+}
+
+// newCommandTokenSource returns a caching token source for argv.
+func newCommandTokenSource(argv []string) oauth2.TokenSource {
+	return oauth2.ReuseTokenSource(nil, &commandTokenSource{argv: argv, now: time.Now})
+}
+
+// Token implements oauth2.TokenSource.
+func (s *commandTokenSource) Token() (*oauth2.Token, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), apiTokenCommandTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, s.argv[0], s.argv[1:]...) //nolint:gosec // G204: the command is operator-controlled provider config
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("running api_token_command: %w: %s", err, msg)
+		}
+		return nil, fmt.Errorf("running api_token_command: %w", err)
+	}
+	return parseCommandToken(stdout.Bytes(), s.now())
+}
+
+// parseCommandToken interprets api_token_command output printed at now.
+func parseCommandToken(output []byte, now time.Time) (*oauth2.Token, error) {
+	output = bytes.TrimSpace(output)
+	if len(output) == 0 {
+		return nil, errors.New("api_token_command printed no token")
+	}
+
+	if output[0] != '{' {
+		return &oauth2.Token{AccessToken: string(output), Expiry: now.Add(apiTokenCommandDefaultTTL)}, nil
+	}
+
+	var out commandTokenOutput
+	if err := json.Unmarshal(output, &out); err != nil {
+		return nil, fmt.Errorf("parsing api_token_command output: %w", err)
+	}
+	if out.Token == "" {
+		return nil, errors.New(`api_token_command output has no "token" field`)
+	}
+	expiry := now.Add(apiTokenCommandDefaultTTL)
+	if out.ExpiresAt != nil {
+		expiry = *out.ExpiresAt
+	}
+	return &oauth2.Token{AccessToken: out.Token, Expiry: expiry}, nil
+}
+
+// apiTokenSource returns the token source selected by the provider
+// configuration, or nil when the static token applies. staticToken is the
+// token already resolved from api_token or DOIT_API_TOKEN.
+//
+// The source is asked for a token once here, so a missing file or a failing
+// command is reported against its attribute at configure time rather than as
+// an opaque error from the first API request.
+func apiTokenSource(ctx context.Context, config doitProviderModel, staticToken string) (oauth2.TokenSource, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var (
+		ts        oauth2.TokenSource
+		attribute path.Path
+	)
+	switch {
+	case !config.DoiTAPITOken.IsNull():
+		return nil, diags
+	case !config.APITokenFile.IsNull():
+		ts, attribute = newFileTokenSource(config.APITokenFile.ValueString()), path.Root("api_token_file")
+	case !config.APITokenCommand.IsNull():
+		var argv []string
+		diags.Append(config.APITokenCommand.ElementsAs(ctx, &argv, false)...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		ts, attribute = newCommandTokenSource(argv), path.Root("api_token_command")
+	case staticToken != "":
+		return nil, diags
+	case os.Getenv("DOIT_API_TOKEN_FILE") != "":
+		ts, attribute = newFileTokenSource(os.Getenv("DOIT_API_TOKEN_FILE")), path.Root("api_token_file")
+	default:
+		return nil, diags
+	}
+
+	if _, err := ts.Token(); err != nil {
+		diags.AddAttributeError(
+			attribute,
+			"Unable to Obtain DoiT API Token",
+			fmt.Sprintf("The provider could not obtain an API token: %s", err),
+		)
+		return nil, diags
+	}
+	return ts, diags
+}
+
+// EnvAPITokenSource returns the API token from the environment, the way the
+// provider falls back to it when its configuration sets no credential:
+// DOIT_API_TOKEN, else the file named by DOIT_API_TOKEN_FILE, re-read when it
+// changes. It is used by the export subcommand, which has no configuration.
+func EnvAPITokenSource() (oauth2.TokenSource, error) {
+	if token := os.Getenv("DOIT_API_TOKEN"); token != "" {
+		return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token}), nil
+	}
+	file := os.Getenv("DOIT_API_TOKEN_FILE")
+	if file == "" {
+		return nil, errors.New("DOIT_API_TOKEN or DOIT_API_TOKEN_FILE must be set")
+	}
+	ts := newFileTokenSource(file)
+	if _, err := ts.Token(); err != nil {
+		return nil, err
+	}
+	return ts, nil
+}
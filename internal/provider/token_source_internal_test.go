@@ -0,0 +1,291 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestFileTokenSource_Rotation(t *testing.T) {
+	t.Parallel()
+	file := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(file, []byte("first-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	ts := newFileTokenSource(file)
+
+	tok, err := ts.Token()
+	if err != nil || tok.AccessToken != "first-token" {
+		t.Fatalf("Token() = %v, %v, want first-token", tok, err)
+	}
+
+	// A rotated token is picked up on the next call.
+	if err := os.WriteFile(file, []byte("rotated-token-2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tok, err = ts.Token()
+	if err != nil || tok.AccessToken != "rotated-token-2" {
+		t.Fatalf("Token() after rotation = %v, %v, want rotated-token-2", tok, err)
+	}
+
+	if err := os.WriteFile(file, []byte("  \n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ts.Token(); err == nil {
+		t.Error("expected an error for an empty token file")
+	}
+}
+
+func TestParseCommandToken(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		output     string
+		wantToken  string
+		wantExpiry time.Time
+		wantErr    bool
+	}{
+		{name: "bare token", output: "abc123\n", wantToken: "abc123", wantExpiry: now.Add(apiTokenCommandDefaultTTL)},
+		{
+			name:       "json with expiry",
+			output:     `{"token": "abc123", "expires_at": "2025-06-01T13:00:00Z"}`,
+			wantToken:  "abc123",
+			wantExpiry: time.Date(2025, 6, 1, 13, 0, 0, 0, time.UTC),
+		},
+		{name: "json without expiry", output: `{"token": "abc123"}`, wantToken: "abc123", wantExpiry: now.Add(apiTokenCommandDefaultTTL)},
+		{name: "json without token", output: `{"expires_at": "2025-06-01T13:00:00Z"}`, wantErr: true},
+		{name: "malformed json", output: `{"token": `, wantErr: true},
+		{name: "empty", output: "\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tok, err := parseCommandToken([]byte(tt.output), now)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got token %q", tok.AccessToken)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tok.AccessToken != tt.wantToken || !tok.Expiry.Equal(tt.wantExpiry) {
+				t.Errorf("parseCommandToken() = %q expiring %s, want %q expiring %s", tok.AccessToken, tok.Expiry, tt.wantToken, tt.wantExpiry)
+			}
+		})
+	}
+}
+
+// TestCommandTokenSource_Cached confirms the command runs once per token
+// lifetime, not once per request.
+func TestCommandTokenSource_Cached(t *testing.T) {
+	t.Parallel()
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	runs := filepath.Join(t.TempDir(), "runs")
+	ts := newCommandTokenSource([]string{"sh", "-c", `echo run >> "$0"; echo cmd-token`, runs})
+
+	for range 3 {
+		tok, err := ts.Token()
+		if err != nil || tok.AccessToken != "cmd-token" {
+			t.Fatalf("Token() = %v, %v, want cmd-token", tok, err)
+		}
+	}
+	data, err := os.ReadFile(runs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(data), "run"); n != 1 {
+		t.Errorf("command ran %d times, want 1", n)
+	}
+
+	failing := newCommandTokenSource([]string{"sh", "-c", "echo denied >&2; exit 1"})
+	if _, err := failing.Token(); err == nil || !strings.Contains(err.Error(), "denied") {
+		t.Errorf("Token() error = %v, want the command's stderr", err)
+	}
+}
+
+func TestAPITokenSource(t *testing.T) {
+	ctx := context.Background()
+	file := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(file, []byte("file-token"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("DOIT_API_TOKEN_FILE", "")
+
+	base := doitProviderModel{
+		DoiTAPITOken:    types.StringNull(),
+		APITokenFile:    types.StringNull(),
+		APITokenCommand: types.ListNull(types.StringType),
+	}
+
+	// A literal api_token needs no token source.
+	withToken := base
+	withToken.DoiTAPITOken = types.StringValue("literal")
+	if ts, diags := apiTokenSource(ctx, withToken, "literal"); diags.HasError() || ts != nil {
+		t.Errorf("apiTokenSource(api_token) = %v, %v, want nil", ts, diags)
+	}
+
+	// api_token_file in HCL wins over DOIT_API_TOKEN.
+	withFile := base
+	withFile.APITokenFile = types.StringValue(file)
+	ts, diags := apiTokenSource(ctx, withFile, "from-env")
+	if diags.HasError() || ts == nil {
+		t.Fatalf("apiTokenSource(api_token_file) = %v, %v", ts, diags)
+	}
+	if tok, err := ts.Token(); err != nil || tok.AccessToken != "file-token" {
+		t.Errorf("Token() = %v, %v, want file-token", tok, err)
+	}
+
+	// A missing file is reported at configure time.
+	missing := base
+	missing.APITokenFile = types.StringValue(filepath.Join(t.TempDir(), "absent"))
+	if _, diags := apiTokenSource(ctx, missing, ""); !diags.HasError() {
+		t.Error("expected an error for a missing api_token_file")
+	}
+
+	// DOIT_API_TOKEN_FILE applies only when nothing else is set.
+	t.Setenv("DOIT_API_TOKEN_FILE", file)
+	if ts, diags := apiTokenSource(ctx, base, "from-env"); diags.HasError() || ts != nil {
+		t.Errorf("apiTokenSource() with DOIT_API_TOKEN set = %v, %v, want nil", ts, diags)
+	}
+	if ts, diags := apiTokenSource(ctx, base, ""); diags.HasError() || ts == nil {
+		t.Errorf("apiTokenSource() with only DOIT_API_TOKEN_FILE = %v, %v, want a file source", ts, diags)
+	}
+}
+
+// TestNewClient_WithTokenSource confirms every request asks the token source,
+// so a rotated token reaches the API without reconfiguring the provider.
+func TestNewClient_WithTokenSource(t *testing.T) {
+	t.Parallel()
+	file := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(file, []byte("before"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	authHeaders := make(chan string, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHeaders <- r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), server.URL, "", "", "1.9.0", "1.0.0", DefaultRequestTimeout,
+		WithTokenSource(newFileTokenSource(file)))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if got := <-authHeaders; got != "Bearer before" {
+		t.Errorf("Authorization = %q, want Bearer before", got)
+	}
+
+	if err := os.WriteFile(file, []byte("after-rotation"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Validate(context.Background())
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	resp.Body.Close()
+	if got := <-authHeaders; got != "Bearer after-rotation" {
+		t.Errorf("Authorization = %q, want Bearer after-rotation", got)
+	}
+}
+
+// TestProviderSchema_APITokenCommandConflicts verifies that api_token_command
+// cannot be combined with api_token, which would otherwise win silently and
+// leave the command unused.
+func TestProviderSchema_APITokenCommandConflicts(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	var schemaResp provider.SchemaResponse
+	(&doitProvider{}).Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+	sch := schemaResp.Schema
+
+	command := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("vault")})
+	config := tfsdk.Config{Schema: sch, Raw: tftypes.NewValue(sch.Type().TerraformType(ctx), nil)}
+	state := tfsdk.State{Schema: sch, Raw: config.Raw}
+	diags := state.SetAttribute(ctx, path.Root("api_token"), types.StringValue("literal"))
+	diags.Append(state.SetAttribute(ctx, path.Root("api_token_command"), command)...)
+	if diags.HasError() {
+		t.Fatalf("setting config: %v", diags)
+	}
+	config.Raw = state.Raw
+
+	attribute, ok := sch.Attributes["api_token_command"].(schema.ListAttribute)
+	if !ok {
+		t.Fatal("api_token_command is not a list attribute")
+	}
+	req := validator.ListRequest{
+		Path:           path.Root("api_token_command"),
+		PathExpression: path.MatchRoot("api_token_command"),
+		Config:         config,
+		ConfigValue:    command,
+	}
+	var resp validator.ListResponse
+	for _, v := range attribute.Validators {
+		v.ValidateList(ctx, req, &resp)
+	}
+	if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), "api_token") {
+		t.Errorf("diagnostics = %v, want a conflict with api_token", resp.Diagnostics)
+	}
+}
+
+// TestEnvAPITokenSource verifies the export subcommand's credentials:
+// DOIT_API_TOKEN, else the file named by DOIT_API_TOKEN_FILE.
+func TestEnvAPITokenSource(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(file, []byte("file-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("DOIT_API_TOKEN", "")
+	t.Setenv("DOIT_API_TOKEN_FILE", "")
+	if _, err := EnvAPITokenSource(); err == nil {
+		t.Error("expected an error without DOIT_API_TOKEN or DOIT_API_TOKEN_FILE")
+	}
+
+	t.Setenv("DOIT_API_TOKEN_FILE", file)
+	ts, err := EnvAPITokenSource()
+	if err != nil {
+		t.Fatalf("EnvAPITokenSource() with DOIT_API_TOKEN_FILE: %v", err)
+	}
+	if tok, err := ts.Token(); err != nil || tok.AccessToken != "file-token" {
+		t.Errorf("Token() = %v, %v, want file-token", tok, err)
+	}
+
+	t.Setenv("DOIT_API_TOKEN", "env-token")
+	ts, err = EnvAPITokenSource()
+	if err != nil {
+		t.Fatalf("EnvAPITokenSource() with DOIT_API_TOKEN: %v", err)
+	}
+	if tok, err := ts.Token(); err != nil || tok.AccessToken != "env-token" {
+		t.Errorf("Token() = %v, %v, want env-token", tok, err)
+	}
+
+	t.Setenv("DOIT_API_TOKEN", "")
+	t.Setenv("DOIT_API_TOKEN_FILE", filepath.Join(t.TempDir(), "absent"))
+	if _, err := EnvAPITokenSource(); err == nil {
+		t.Error("expected an error for a missing DOIT_API_TOKEN_FILE")
+	}
+}
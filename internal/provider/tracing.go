@@ -0,0 +1,440 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// OpenTelemetry tracing of API calls. When enabled, every Terraform operation
+// on a resource or data source gets a span, and every attempt DCIRetryClient
+// makes is a child span of the operation it serves. Spans are exported over
+// OTLP/HTTP in batches and flushed when Terraform stops the provider.
+
+// tracerName is the instrumentation scope of every span the provider creates.
+const tracerName = "github.com/doitintl/terraform-provider-doit"
+
+// tracingShutdownTimeout bounds the final export when the provider stops.
+// Terraform kills a provider that has not exited two seconds after being
+// told to stop.
+const tracingShutdownTimeout = 1500 * time.Millisecond
+
+// tracingModel maps the provider's tracing {} block.
+type tracingModel struct {
+	Endpoint types.String `tfsdk:"endpoint"`
+	Headers  types.Map    `tfsdk:"headers"`
+}
+
+// tracingSettings is the resolved tracing configuration.
+type tracingSettings struct {
+	enabled bool
+
+	// endpointURL is the full URL spans are sent to. Empty means the
+	// exporter reads OTEL_EXPORTER_OTLP_* itself.
+	endpointURL string
+	headers     map[string]string
+}
+
+// tracingSettingsFromConfig resolves tracing from the tracing {} block and,
+// when the block is absent, from OTEL_EXPORTER_OTLP_ENDPOINT or
+// OTEL_EXPORTER_OTLP_TRACES_ENDPOINT. The exporter reads the remaining
+// OTEL_EXPORTER_OTLP_* variables (headers, timeout, certificates) itself.
+func tracingSettingsFromConfig(ctx context.Context, block types.Object) (tracingSettings, diag.Diagnostics) {
+	var (
+		s     tracingSettings
+		diags diag.Diagnostics
+	)
+
+	if block.IsUnknown() {
+		diags.AddAttributeError(
+			path.Root("tracing"),
+			"Unknown Tracing Configuration",
+			"The provider cannot be configured because the tracing block is not known until apply. "+
+				"Set the values statically in the configuration.",
+		)
+		return s, diags
+	}
+
+	if block.IsNull() {
+		s.enabled = os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+		return s, diags
+	}
+
+	var m tracingModel
+	diags.Append(block.As(ctx, &m, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return s, diags
+	}
+	if m.Endpoint.IsUnknown() || m.Headers.IsUnknown() {
+		diags.AddAttributeError(
+			path.Root("tracing"),
+			"Unknown Tracing Configuration",
+			"The provider cannot be configured because the tracing block is not known until apply. "+
+				"Set the values statically in the configuration.",
+		)
+		return s, diags
+	}
+
+	s.enabled = true
+	if !m.Endpoint.IsNull() {
+		u, err := url.Parse(m.Endpoint.ValueString())
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			diags.AddAttributeError(
+				path.Root("tracing").AtName("endpoint"),
+				"Invalid Tracing Endpoint",
+				fmt.Sprintf("endpoint %q must be an http or https URL, e.g. \"http://localhost:4318\".", m.Endpoint.ValueString()),
+			)
+			return s, diags
+		}
+		// Like OTEL_EXPORTER_OTLP_ENDPOINT, the endpoint is the collector's
+		// base URL.
+		u.Path = strings.TrimSuffix(u.Path, "/") + "/v1/traces"
+		s.endpointURL = u.String()
+	}
+	if !m.Headers.IsNull() {
+		diags.Append(m.Headers.ElementsAs(ctx, &s.headers, false)...)
+	}
+	return s, diags
+}
+
+// newTracerProvider returns a tracer provider exporting to the collector in
+// s. The exporter connects lazily, so an unreachable collector only costs
+// dropped spans.
+func newTracerProvider(ctx context.Context, s tracingSettings, version string) (*sdktrace.TracerProvider, error) {
+	var opts []otlptracehttp.Option
+	if s.endpointURL != "" {
+		opts = append(opts, otlptracehttp.WithEndpointURL(s.endpointURL))
+	}
+	if len(s.headers) > 0 {
+		opts = append(opts, otlptracehttp.WithHeaders(s.headers))
+	}
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating OTLP exporter: %w", err)
+	}
+
+	// OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES override the defaults.
+	res, err := resource.New(ctx,
+		resource.WithAttributes(
+			attribute.String("service.name", "terraform-provider-doit"),
+			attribute.String("service.version", version),
+		),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("building trace resource: %w", err)
+	}
+
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	), nil
+}
+
+// telemetry holds the tracer provider once Configure has created one. The
+// protocol server wrapper is built before the provider is configured, so it
+// looks the tracer up for every operation.
+type telemetry struct {
+	mu       sync.RWMutex
+	provider *sdktrace.TracerProvider
+}
+
+func (t *telemetry) setTracerProvider(tp *sdktrace.TracerProvider) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.provider = tp
+}
+
+// tracer returns the provider's tracer, or a no-op tracer while tracing is
+// disabled.
+func (t *telemetry) tracer() trace.Tracer {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.provider == nil {
+		return noop.NewTracerProvider().Tracer(tracerName)
+	}
+	return t.provider.Tracer(tracerName)
+}
+
+// shutdown exports any buffered spans.
+func (t *telemetry) shutdown(ctx context.Context) error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.provider == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, tracingShutdownTimeout)
+	defer cancel()
+	return t.provider.Shutdown(ctx)
+}
+
+// tracingServer starts a span for every resource and data source operation
+// before handing the request to the framework. The span travels on the
+// request context, which the framework passes to CRUD methods and from there
+// to DCIRetryClient, so API attempts become its children.
+//
+// Only tfprotov6.ProviderServer is forwarded. Should the provider gain list
+// resources, actions or state stores, their optional server interfaces need
+// forwarding here too.
+type tracingServer struct {
+	tfprotov6.ProviderServer
+	telemetry *telemetry
+}
+
+func (s *tracingServer) start(ctx context.Context, operation, typeName string) (context.Context, trace.Span) {
+	return s.telemetry.tracer().Start(ctx, operation+" "+typeName,
+		trace.WithAttributes(
+			attribute.String("terraform.operation", operation),
+			attribute.String("terraform.type_name", typeName),
+		),
+	)
+}
+
+// endOperationSpan records the diagnostics an operation returned and ends
+// its span.
+func endOperationSpan(span trace.Span, diagnostics []*tfprotov6.Diagnostic) {
+	for _, d := range diagnostics {
+		if d != nil && d.Severity == tfprotov6.DiagnosticSeverityError {
+			span.SetStatus(codes.Error, d.Summary)
+			break
+		}
+	}
+	span.End()
+}
+
+// dynamicValueIsNull reports whether v encodes a null object, as Terraform
+// sends for the prior state of a create and the planned state of a delete.
+func dynamicValueIsNull(v *tfprotov6.DynamicValue) bool {
+	if v == nil {
+		return true
+	}
+	if len(v.MsgPack) > 0 {
+		return len(v.MsgPack) == 1 && v.MsgPack[0] == 0xc0 // msgpack nil
+	}
+	return len(v.JSON) == 0 || string(v.JSON) == "null"
+}
+
+func (s *tracingServer) ReadResource(ctx context.Context, req *tfprotov6.ReadResourceRequest) (*tfprotov6.ReadResourceResponse, error) {
+	ctx, span := s.start(ctx, "read", req.TypeName)
+	resp, err := s.ProviderServer.ReadResource(ctx, req)
+	if resp != nil {
+		endOperationSpan(span, resp.Diagnostics)
+	} else {
+		span.End()
+	}
+	return resp, err
+}
+
+func (s *tracingServer) PlanResourceChange(ctx context.Context, req *tfprotov6.PlanResourceChangeRequest) (*tfprotov6.PlanResourceChangeResponse, error) {
+	ctx, span := s.start(ctx, "plan", req.TypeName)
+	resp, err := s.ProviderServer.PlanResourceChange(ctx, req)
+	if resp != nil {
+		endOperationSpan(span, resp.Diagnostics)
+	} else {
+		span.End()
+	}
+	return resp, err
+}
+
+func (s *tracingServer) ApplyResourceChange(ctx context.Context, req *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error) {
+	operation := "update"
+	switch {
+	case dynamicValueIsNull(req.PriorState):
+		operation = "create"
+	case dynamicValueIsNull(req.PlannedState):
+		operation = "delete"
+	}
+	ctx, span := s.start(ctx, operation, req.TypeName)
+	resp, err := s.ProviderServer.ApplyResourceChange(ctx, req)
+	if resp != nil {
+		endOperationSpan(span, resp.Diagnostics)
+	} else {
+		span.End()
+	}
+	return resp, err
+}
+
+func (s *tracingServer) ImportResourceState(ctx context.Context, req *tfprotov6.ImportResourceStateRequest) (*tfprotov6.ImportResourceStateResponse, error) {
+	ctx, span := s.start(ctx, "import", req.TypeName)
+	resp, err := s.ProviderServer.ImportResourceState(ctx, req)
+	if resp != nil {
+		endOperationSpan(span, resp.Diagnostics)
+	} else {
+		span.End()
+	}
+	return resp, err
+}
+
+func (s *tracingServer) ReadDataSource(ctx context.Context, req *tfprotov6.ReadDataSourceRequest) (*tfprotov6.ReadDataSourceResponse, error) {
+	ctx, span := s.start(ctx, "read_data", req.TypeName)
+	resp, err := s.ProviderServer.ReadDataSource(ctx, req)
+	if resp != nil {
+		endOperationSpan(span, resp.Diagnostics)
+	} else {
+		span.End()
+	}
+	return resp, err
+}
+
+// apiPathTemplates are the request paths of the generated client, with "%s"
+// for each path parameter. They name attempt spans by route rather than by
+// object ID; TestAPIPathTemplates keeps the list in step with models_gen.go.
+var apiPathTemplates = []string{
+	"/analytics/v1/alerts",
+	"/analytics/v1/alerts/%s",
+	"/analytics/v1/allocations",
+	"/analytics/v1/allocations/%s",
+	"/analytics/v1/annotations",
+	"/analytics/v1/annotations/%s",
+	"/analytics/v1/budget-suggestions",
+	"/analytics/v1/budgets",
+	"/analytics/v1/budgets/%s",
+	"/analytics/v1/commitment-manager",
+	"/analytics/v1/commitment-manager/%s",
+	"/analytics/v1/dimension",
+	"/analytics/v1/dimensions",
+	"/analytics/v1/folders",
+	"/analytics/v1/folders/%s",
+	"/analytics/v1/labels",
+	"/analytics/v1/labels/%s",
+	"/analytics/v1/labels/%s/assignments",
+	"/analytics/v1/reports",
+	"/analytics/v1/reports/%s",
+	"/analytics/v1/reports/%s/config",
+	"/analytics/v1/reports/query",
+	"/analytics/v1/settings/active-theme",
+	"/analytics/v1/settings/themes",
+	"/analytics/v1/settings/themes/%s",
+	"/anomalies/v1",
+	"/anomalies/v1/%s",
+	"/auth/v1/validate",
+	"/ava/v1/askSync",
+	"/billing/v1/assets",
+	"/billing/v1/assets/%s",
+	"/billing/v1/billing-explainers/%s",
+	"/billing/v1/invoices",
+	"/billing/v1/invoices/%s",
+	"/clouddiagrams/v1/activity",
+	"/clouddiagrams/v1/activity/node-activities",
+	"/clouddiagrams/v1/scheme/find",
+	"/clouddiagrams/v1/scheme/get",
+	"/clouddiagrams/v1/scheme/search",
+	"/clouddiagrams/v1/scheme/stats",
+	"/clouddiagrams/v1/statussheet/%s/costs",
+	"/clouddiagrams/v1/statussheet/%s/export-json",
+	"/clouddiagrams/v1/statussheet/%s/get",
+	"/clouddiagrams/v1/statussheet/%s/resources/%s/relationships",
+	"/clouddiagrams/v1/statussheet/%s/snapshot",
+	"/clouddiagrams/v1/statussheet/%s/snapshots",
+	"/core/v1/cloudconnect/aws/accounts",
+	"/core/v1/cloudconnect/aws/accounts/%s",
+	"/core/v1/cloudincidents",
+	"/core/v1/cloudincidents/%s",
+	"/core/v1/service-quotas",
+	"/customers/v1/accountTeam",
+	"/customers/v1/customers/%s",
+	"/datahub/v1/datasets",
+	"/datahub/v1/datasets/%s",
+	"/iam/v1/organizations",
+	"/iam/v1/roles",
+	"/iam/v1/users",
+	"/iam/v1/users/%s",
+	"/iam/v1/users/invite",
+	"/insights/v1/results",
+	"/insights/v1/results/source/%s/insight/%s",
+	"/insights/v1/results/source/%s/insight/%s/resource-results",
+	"/ps4commitments/v1/aws/organizations",
+	"/ps4commitments/v1/aws/organizations/%s",
+	"/sharing/v1/%s/%s",
+	"/support/v1/metadata/platforms",
+	"/support/v1/metadata/products",
+	"/support/v1/tickets",
+	"/support/v1/tickets/%s",
+	"/support/v1/tickets/%s/comments",
+	"/support/v1/tickets/%s/tags",
+}
+
+// urlTemplate returns the route of an API request path, e.g.
+// "/analytics/v1/reports/{id}" for "/analytics/v1/reports/abc123". Literal
+// segments win over parameters, so "/analytics/v1/reports/query" is its own
+// route. A path matching no template is returned as "other" to keep span
+// names low-cardinality.
+func urlTemplate(requestPath string) string {
+	segments := strings.Split(strings.Trim(requestPath, "/"), "/")
+
+	best, bestLiterals := "", -1
+	for _, tmpl := range apiPathTemplates {
+		tsegs := strings.Split(strings.Trim(tmpl, "/"), "/")
+		if len(tsegs) != len(segments) {
+			continue
+		}
+		literals, ok := 0, true
+		for i, ts := range tsegs {
+			if ts == "%s" {
+				continue
+			}
+			if ts != segments[i] {
+				ok = false
+				break
+			}
+			literals++
+		}
+		if ok && literals > bestLiterals {
+			best, bestLiterals = tmpl, literals
+		}
+	}
+	if best == "" {
+		return "other"
+	}
+	return strings.ReplaceAll(best, "%s", "{id}")
+}
+
+// startAttemptSpan starts the span for one DCIRetryClient attempt. attempt
+// counts from 1; backoffWait is the delay that preceded it.
+func startAttemptSpan(tracer trace.Tracer, req *http.Request, attempt int, backoffWait time.Duration) trace.Span {
+	tmpl := urlTemplate(req.URL.Path)
+	_, span := tracer.Start(req.Context(), req.Method+" "+tmpl,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("url.template", tmpl),
+			attribute.String("server.address", req.URL.Hostname()),
+			attribute.Int("http.request.resend_count", attempt-1),
+			attribute.Int64("doit.retry.backoff_wait_ms", backoffWait.Milliseconds()),
+		),
+	)
+	return span
+}
+
+// endAttemptSpan records an attempt's outcome. status is 0 when no response
+// arrived.
+func endAttemptSpan(span trace.Span, status int, rateLimitWait time.Duration, err error) {
+	if status != 0 {
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+	}
+	if rateLimitWait > 0 {
+		span.SetAttributes(attribute.Int64("doit.rate_limit.wait_ms", rateLimitWait.Milliseconds()))
+	}
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,311 @@
+package provider
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"go.opentelemetry.io/otel/trace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// collectorStub is an in-process OTLP/HTTP collector that keeps every span it
+// receives.
+type collectorStub struct {
+	mu    sync.Mutex
+	spans []*tracepb.Span
+}
+
+func newCollectorStub(t *testing.T) (*collectorStub, *httptest.Server) {
+	t.Helper()
+	c := &collectorStub{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/traces" {
+			t.Errorf("collector received request for %s, want /v1/traces", r.URL.Path)
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("reading export request: %v", err)
+			return
+		}
+		var req coltracepb.ExportTraceServiceRequest
+		if err := proto.Unmarshal(body, &req); err != nil {
+			t.Errorf("decoding export request: %v", err)
+			return
+		}
+		c.mu.Lock()
+		for _, rs := range req.GetResourceSpans() {
+			for _, ss := range rs.GetScopeSpans() {
+				c.spans = append(c.spans, ss.GetSpans()...)
+			}
+		}
+		c.mu.Unlock()
+		w.Header().Set("Content-Type", "application/x-protobuf")
+	}))
+	t.Cleanup(server.Close)
+	return c, server
+}
+
+// span returns the received span named name, failing the test if there is
+// not exactly one.
+func (c *collectorStub) span(t *testing.T, name string) *tracepb.Span {
+	t.Helper()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var found []*tracepb.Span
+	for _, s := range c.spans {
+		if s.GetName() == name {
+			found = append(found, s)
+		}
+	}
+	if len(found) != 1 {
+		t.Fatalf("got %d spans named %q, want 1", len(found), name)
+	}
+	return found[0]
+}
+
+func (c *collectorStub) spansNamed(name string) []*tracepb.Span {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var found []*tracepb.Span
+	for _, s := range c.spans {
+		if s.GetName() == name {
+			found = append(found, s)
+		}
+	}
+	return found
+}
+
+func spanAttr(s *tracepb.Span, key string) any {
+	for _, kv := range s.GetAttributes() {
+		if kv.GetKey() != key {
+			continue
+		}
+		switch v := kv.GetValue().GetValue().(type) {
+		case *commonpb.AnyValue_StringValue:
+			return v.StringValue
+		case *commonpb.AnyValue_IntValue:
+			return v.IntValue
+		}
+	}
+	return nil
+}
+
+func newStubTracerProvider(t *testing.T, collector *httptest.Server) *telemetry {
+	t.Helper()
+	tp, err := newTracerProvider(context.Background(), tracingSettings{enabled: true, endpointURL: collector.URL + "/v1/traces"}, "test")
+	if err != nil {
+		t.Fatalf("newTracerProvider() error = %v", err)
+	}
+	tel := &telemetry{}
+	tel.setTracerProvider(tp)
+	return tel
+}
+
+func TestDCIRetryClient_TracesAttempts(t *testing.T) {
+	t.Parallel()
+
+	collector, collectorServer := newCollectorStub(t)
+	tel := newStubTracerProvider(t, collectorServer)
+
+	var calls atomic.Int32
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(api.Close)
+
+	c := newTestRetryClient(5*time.Second, constantBackOff(10*time.Millisecond))
+	c.tracer = tel.tracer()
+
+	ctx, op := tel.tracer().Start(context.Background(), "read doit_report")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, api.URL+"/analytics/v1/reports/abc123", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	resp.Body.Close()
+	op.End()
+
+	if err := tel.shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown() error = %v", err)
+	}
+
+	parent := collector.span(t, "read doit_report")
+	attempts := collector.spansNamed("GET /analytics/v1/reports/{id}")
+	if len(attempts) != 2 {
+		t.Fatalf("got %d attempt spans, want 2", len(attempts))
+	}
+	slices.SortFunc(attempts, func(a, b *tracepb.Span) int {
+		return int(spanAttr(a, "http.request.resend_count").(int64) - spanAttr(b, "http.request.resend_count").(int64))
+	})
+
+	for i, want := range []struct {
+		status      int64
+		backoffWait int64
+		isError     bool
+	}{
+		{status: 503, backoffWait: 0, isError: true},
+		{status: 200, backoffWait: 10},
+	} {
+		s := attempts[i]
+		if hex.EncodeToString(s.GetParentSpanId()) != hex.EncodeToString(parent.GetSpanId()) {
+			t.Errorf("attempt %d is not a child of the operation span", i)
+		}
+		if got := spanAttr(s, "http.request.resend_count"); got != int64(i) {
+			t.Errorf("attempt %d resend_count = %v, want %d", i, got, i)
+		}
+		if got := spanAttr(s, "http.response.status_code"); got != want.status {
+			t.Errorf("attempt %d status = %v, want %d", i, got, want.status)
+		}
+		if got := spanAttr(s, "doit.retry.backoff_wait_ms"); got != want.backoffWait {
+			t.Errorf("attempt %d backoff_wait_ms = %v, want %d", i, got, want.backoffWait)
+		}
+		if got := spanAttr(s, "url.template"); got != "/analytics/v1/reports/{id}" {
+			t.Errorf("attempt %d url.template = %v", i, got)
+		}
+		if isError := s.GetStatus().GetCode() == tracepb.Status_STATUS_CODE_ERROR; isError != want.isError {
+			t.Errorf("attempt %d error status = %v, want %v", i, isError, want.isError)
+		}
+	}
+}
+
+// stubProviderServer answers ApplyResourceChange and ReadDataSource, checking
+// that an operation span is active.
+type stubProviderServer struct {
+	tfprotov6.ProviderServer
+	t *testing.T
+}
+
+func (s *stubProviderServer) ApplyResourceChange(ctx context.Context, _ *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error) {
+	if !trace.SpanFromContext(ctx).SpanContext().IsValid() {
+		s.t.Error("ApplyResourceChange called without an active span")
+	}
+	return &tfprotov6.ApplyResourceChangeResponse{}, nil
+}
+
+func (s *stubProviderServer) ReadDataSource(ctx context.Context, _ *tfprotov6.ReadDataSourceRequest) (*tfprotov6.ReadDataSourceResponse, error) {
+	return &tfprotov6.ReadDataSourceResponse{
+		Diagnostics: []*tfprotov6.Diagnostic{{Severity: tfprotov6.DiagnosticSeverityError, Summary: "Error Reading Dimensions"}},
+	}, nil
+}
+
+func TestTracingServer_OperationSpans(t *testing.T) {
+	t.Parallel()
+
+	collector, collectorServer := newCollectorStub(t)
+	tel := newStubTracerProvider(t, collectorServer)
+	server := &tracingServer{ProviderServer: &stubProviderServer{t: t}, telemetry: tel}
+
+	ctx := context.Background()
+	object := &tfprotov6.DynamicValue{MsgPack: []byte{0x81, 0xa2, 'i', 'd', 0xa1, 'x'}}
+	null := &tfprotov6.DynamicValue{MsgPack: []byte{0xc0}}
+
+	for _, req := range []*tfprotov6.ApplyResourceChangeRequest{
+		{TypeName: "doit_folder", PriorState: null, PlannedState: object},
+		{TypeName: "doit_label", PriorState: object, PlannedState: object},
+		{TypeName: "doit_report", PriorState: object, PlannedState: null},
+	} {
+		if _, err := server.ApplyResourceChange(ctx, req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := server.ReadDataSource(ctx, &tfprotov6.ReadDataSourceRequest{TypeName: "doit_dimensions"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tel.shutdown(ctx); err != nil {
+		t.Fatalf("shutdown() error = %v", err)
+	}
+
+	for _, name := range []string{"create doit_folder", "update doit_label", "delete doit_report"} {
+		if s := collector.span(t, name); s.GetStatus().GetCode() == tracepb.Status_STATUS_CODE_ERROR {
+			t.Errorf("span %q has error status", name)
+		}
+	}
+	s := collector.span(t, "read_data doit_dimensions")
+	if s.GetStatus().GetCode() != tracepb.Status_STATUS_CODE_ERROR || s.GetStatus().GetMessage() != "Error Reading Dimensions" {
+		t.Errorf("span status = %v, want error with the diagnostic summary", s.GetStatus())
+	}
+	if got := spanAttr(s, "terraform.type_name"); got != "doit_dimensions" {
+		t.Errorf("terraform.type_name = %v, want doit_dimensions", got)
+	}
+}
+
+func TestTelemetry_DisabledIsNoop(t *testing.T) {
+	t.Parallel()
+
+	tel := &telemetry{}
+	_, span := tel.tracer().Start(context.Background(), "read doit_folder")
+	if span.SpanContext().IsValid() {
+		t.Error("span recorded while tracing is disabled")
+	}
+	span.End()
+	if err := tel.shutdown(context.Background()); err != nil {
+		t.Errorf("shutdown() error = %v", err)
+	}
+}
+
+func TestURLTemplate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"/analytics/v1/reports":                                 "/analytics/v1/reports",
+		"/analytics/v1/reports/abc123":                          "/analytics/v1/reports/{id}",
+		"/analytics/v1/reports/query":                           "/analytics/v1/reports/query",
+		"/analytics/v1/reports/abc123/config":                   "/analytics/v1/reports/{id}/config",
+		"/sharing/v1/reports/abc123":                            "/sharing/v1/{id}/{id}",
+		"/insights/v1/results/source/aws/insight/idle-vms":      "/insights/v1/results/source/{id}/insight/{id}",
+		"/iam/v1/users/invite":                                  "/iam/v1/users/invite",
+		"/iam/v1/users/someone@example.com":                     "/iam/v1/users/{id}",
+		"/analytics/v1/unknown/abc123/with/too/many/segments/x": "other",
+	}
+	for path, want := range tests {
+		if got := urlTemplate(path); got != want {
+			t.Errorf("urlTemplate(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+// TestAPIPathTemplates checks that apiPathTemplates lists exactly the paths
+// of the generated client, so a regenerated client cannot leave routes
+// unnamed.
+func TestAPIPathTemplates(t *testing.T) {
+	t.Parallel()
+
+	src, err := os.ReadFile("models/models_gen.go")
+	if err != nil {
+		t.Fatalf("reading generated client: %v", err)
+	}
+	var generated []string
+	for _, m := range regexp.MustCompile(`operationPath := fmt\.Sprintf\("([^"]*)"`).FindAllSubmatch(src, -1) {
+		generated = append(generated, string(m[1]))
+	}
+	slices.Sort(generated)
+	generated = slices.Compact(generated)
+
+	listed := slices.Clone(apiPathTemplates)
+	slices.Sort(listed)
+	if !slices.Equal(generated, listed) {
+		t.Errorf("apiPathTemplates is out of date with models_gen.go.\ngenerated: %q\nlisted:    %q", generated, listed)
+	}
+}
@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// transportSettings are the provider's network settings for reaching the API
+// from restricted environments: an explicit proxy, a private CA (typically
+// that of a TLS-inspecting proxy) and a client certificate for mutual TLS.
+// The zero value keeps the standard library's defaults, including proxies
+// from HTTPS_PROXY and NO_PROXY.
+type transportSettings struct {
+	proxyURL           *url.URL
+	caCertPEM          []byte
+	clientCertPEM      []byte
+	clientKeyPEM       []byte
+	insecureSkipVerify bool
+}
+
+// isZero reports whether s changes nothing, so the default transport can be
+// kept.
+func (s transportSettings) isZero() bool {
+	return s.proxyURL == nil && len(s.caCertPEM) == 0 && len(s.clientCertPEM) == 0 && !s.insecureSkipVerify
+}
+
+// transportSettingsFromConfig reads the transport attributes from the
+// provider configuration. Conflicts between them are left to the schema
+// validators.
+func transportSettingsFromConfig(config doitProviderModel) (transportSettings, diag.Diagnostics) {
+	var (
+		s     transportSettings
+		diags diag.Diagnostics
+	)
+
+	if config.ProxyURL.IsUnknown() || config.CACertPEM.IsUnknown() || config.CACertFile.IsUnknown() ||
+		config.ClientCert.IsUnknown() || config.ClientKey.IsUnknown() || config.InsecureSkipVerify.IsUnknown() {
+		diags.AddError(
+			"Unknown Transport Configuration",
+			"The provider cannot be configured because proxy_url, ca_cert_pem, ca_cert_file, client_cert, "+
+				"client_key or insecure_skip_verify is not known until apply. Set the values statically in the configuration.",
+		)
+		return s, diags
+	}
+
+	if v := config.ProxyURL.ValueString(); v != "" {
+		u, err := url.Parse(v)
+		switch {
+		case err != nil:
+			diags.AddAttributeError(path.Root("proxy_url"), "Invalid Proxy URL",
+				fmt.Sprintf("Could not parse proxy_url %q: %s", v, err))
+		case u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "socks5":
+			diags.AddAttributeError(path.Root("proxy_url"), "Invalid Proxy URL",
+				fmt.Sprintf("proxy_url %q must use the http, https or socks5 scheme.", v))
+		case u.Host == "":
+			diags.AddAttributeError(path.Root("proxy_url"), "Invalid Proxy URL",
+				fmt.Sprintf("proxy_url %q has no host.", v))
+		default:
+			s.proxyURL = u
+		}
+	}
+
+	switch {
+	case !config.CACertPEM.IsNull():
+		s.caCertPEM = []byte(config.CACertPEM.ValueString())
+	case !config.CACertFile.IsNull():
+		data, err := os.ReadFile(config.CACertFile.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("ca_cert_file"), "Unable to Read CA Certificate",
+				fmt.Sprintf("Could not read ca_cert_file: %s", err))
+		}
+		s.caCertPEM = data
+	}
+
+	s.clientCertPEM = []byte(config.ClientCert.ValueString())
+	s.clientKeyPEM = []byte(config.ClientKey.ValueString())
+
+	if config.InsecureSkipVerify.ValueBool() {
+		s.insecureSkipVerify = true
+		diags.AddAttributeWarning(
+			path.Root("insecure_skip_verify"),
+			"TLS Certificate Verification Disabled",
+			"insecure_skip_verify is set, so the provider does not verify the API's TLS certificate. "+
+				"Anyone able to intercept the connection can read the API token and alter responses. "+
+				"Use ca_cert_pem or ca_cert_file to trust a private CA instead.",
+		)
+	}
+
+	if diags.HasError() {
+		return s, diags
+	}
+
+	// Build the transport once here so bad PEM data is reported against its
+	// attribute rather than as a client creation error.
+	if _, err := s.tlsConfig(); err != nil {
+		attr := path.Root("ca_cert_pem")
+		switch {
+		case errors.Is(err, errClientCertificate):
+			attr = path.Root("client_cert")
+		case config.CACertPEM.IsNull():
+			attr = path.Root("ca_cert_file")
+		}
+		diags.AddAttributeError(attr, "Invalid TLS Configuration", err.Error())
+	}
+
+	return s, diags
+}
+
+var errClientCertificate = errors.New("invalid client certificate")
+
+// tlsConfig returns the TLS configuration for s, or nil when the defaults
+// apply.
+func (s transportSettings) tlsConfig() (*tls.Config, error) {
+	if len(s.caCertPEM) == 0 && len(s.clientCertPEM) == 0 && !s.insecureSkipVerify {
+		return nil, nil
+	}
+
+	cfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: s.insecureSkipVerify, //nolint:gosec // G402: opt-in, with a warning diagnostic
+	}
+
+	if len(s.caCertPEM) > 0 {
+		// The private CA is trusted in addition to the system roots, so a
+		// proxy that only inspects some hosts keeps working.
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(s.caCertPEM) {
+			return nil, errors.New("the CA certificate contains no PEM-encoded certificates")
+		}
+		cfg.RootCAs = pool
+	}
+
+	if len(s.clientCertPEM) > 0 {
+		cert, err := tls.X509KeyPair(s.clientCertPEM, s.clientKeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", errClientCertificate, err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
+
+// newTransport returns the http.Transport underneath DCIRetryClient for s.
+// It starts from http.DefaultTransport so connection pooling and timeouts
+// stay the same as without any transport settings.
+func newTransport(s transportSettings) (*http.Transport, error) {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	if s.proxyURL != nil {
+		t.Proxy = http.ProxyURL(s.proxyURL)
+	}
+	tlsConfig, err := s.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		t.TLSClientConfig = tlsConfig
+	}
+	return t, nil
+}
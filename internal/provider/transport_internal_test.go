@@ -0,0 +1,218 @@
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// selfSignedPEM returns a PEM-encoded self-signed client certificate and its
+// key.
+func selfSignedPEM(t *testing.T) (certPEM, keyPEM []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalECPrivateKey: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func serverCAPEM(server *httptest.Server) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+}
+
+func getThrough(t *testing.T, s transportSettings, target string) (*http.Response, error) {
+	t.Helper()
+	transport, err := newTransport(s)
+	if err != nil {
+		t.Fatalf("newTransport() error = %v", err)
+	}
+	client := &http.Client{Transport: transport, Timeout: 10 * time.Second}
+	resp, err := client.Get(target)
+	if err == nil {
+		resp.Body.Close()
+	}
+	return resp, err
+}
+
+func TestNewTransport_PrivateCA(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {}))
+	t.Cleanup(server.Close)
+
+	if _, err := getThrough(t, transportSettings{}, server.URL); err == nil {
+		t.Fatal("request to a server with an untrusted certificate succeeded")
+	}
+	if _, err := getThrough(t, transportSettings{caCertPEM: serverCAPEM(server)}, server.URL); err != nil {
+		t.Errorf("request with the server's CA trusted failed: %v", err)
+	}
+	if _, err := getThrough(t, transportSettings{insecureSkipVerify: true}, server.URL); err != nil {
+		t.Errorf("request with insecure_skip_verify failed: %v", err)
+	}
+}
+
+func TestNewTransport_ClientCertificate(t *testing.T) {
+	t.Parallel()
+
+	certPEM, keyPEM := selfSignedPEM(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AppendCertsFromPEM(certPEM)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {}))
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+		MinVersion: tls.VersionTLS12,
+	}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	caPEM := serverCAPEM(server)
+	if _, err := getThrough(t, transportSettings{caCertPEM: caPEM}, server.URL); err == nil {
+		t.Fatal("request without a client certificate succeeded")
+	}
+	s := transportSettings{caCertPEM: caPEM, clientCertPEM: certPEM, clientKeyPEM: keyPEM}
+	if _, err := getThrough(t, s, server.URL); err != nil {
+		t.Errorf("request with a client certificate failed: %v", err)
+	}
+}
+
+func TestNewTransport_Proxy(t *testing.T) {
+	t.Parallel()
+
+	var gotHost string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHost = r.URL.Host
+	}))
+	t.Cleanup(proxy.Close)
+
+	proxyURL, err := url.Parse(proxy.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := getThrough(t, transportSettings{proxyURL: proxyURL}, "http://api.doit.invalid/auth/v1/validate"); err != nil {
+		t.Fatalf("request through proxy failed: %v", err)
+	}
+	if gotHost != "api.doit.invalid" {
+		t.Errorf("proxy received request for host %q, want %q", gotHost, "api.doit.invalid")
+	}
+}
+
+func TestTransportSettingsFromConfig(t *testing.T) {
+	t.Parallel()
+
+	certPEM, keyPEM := selfSignedPEM(t)
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, certPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		config      doitProviderModel
+		wantZero    bool
+		wantErr     string
+		wantWarning bool
+	}{
+		{
+			name:     "unset",
+			wantZero: true,
+		},
+		{
+			name:   "valid",
+			config: doitProviderModel{ProxyURL: types.StringValue("http://proxy:3128"), CACertFile: types.StringValue(caFile), ClientCert: types.StringValue(string(certPEM)), ClientKey: types.StringValue(string(keyPEM))},
+		},
+		{
+			name:    "proxy scheme",
+			config:  doitProviderModel{ProxyURL: types.StringValue("ftp://proxy")},
+			wantErr: "must use the http, https or socks5 scheme",
+		},
+		{
+			name:    "CA without certificates",
+			config:  doitProviderModel{CACertPEM: types.StringValue("not a certificate")},
+			wantErr: "no PEM-encoded certificates",
+		},
+		{
+			name:    "missing CA file",
+			config:  doitProviderModel{CACertFile: types.StringValue(filepath.Join(t.TempDir(), "missing.pem"))},
+			wantErr: "Could not read ca_cert_file",
+		},
+		{
+			name:    "mismatched client key",
+			config:  doitProviderModel{ClientCert: types.StringValue(string(certPEM)), ClientKey: types.StringValue("bad key")},
+			wantErr: "invalid client certificate",
+		},
+		{
+			name:        "insecure",
+			config:      doitProviderModel{InsecureSkipVerify: types.BoolValue(true)},
+			wantWarning: true,
+		},
+		{
+			name:    "unknown",
+			config:  doitProviderModel{ProxyURL: types.StringUnknown()},
+			wantErr: "not known until apply",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, diags := transportSettingsFromConfig(tt.config)
+			if tt.wantErr != "" {
+				if !diags.HasError() {
+					t.Fatal("expected an error, got none")
+				}
+				if detail := diags.Errors()[0].Detail(); !strings.Contains(detail, tt.wantErr) {
+					t.Errorf("error detail %q does not contain %q", detail, tt.wantErr)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if got := diags.WarningsCount() > 0; got != tt.wantWarning {
+				t.Errorf("warning = %v, want %v", got, tt.wantWarning)
+			}
+			if s.isZero() != tt.wantZero {
+				t.Errorf("isZero() = %v, want %v", s.isZero(), tt.wantZero)
+			}
+		})
+	}
+}
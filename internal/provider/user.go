@@ -124,10 +124,7 @@ func (r *userResource) resolveInternalID(ctx context.Context, email string) (str
 
 	listResp, err := r.client.ListUsersWithResponse(ctx, params)
 	if err != nil {
-		diags.AddError(
-			"Error Resolving User ID",
-			"Could not list users to resolve internal ID for "+email+": "+err.Error(),
-		)
+		diags.Append(apiErrorDiagnostics("Error Resolving User ID", "Could not list users to resolve internal ID for "+email, err)...)
 		return "", diags
 	}
 
@@ -170,10 +167,7 @@ func (r *userResource) lookupUser(ctx context.Context, email string) (*models.Us
 
 	listResp, err := r.client.ListUsersWithResponse(ctx, params)
 	if err != nil {
-		diags.AddError(
-			"Error Reading User",
-			"Could not list users for email "+email+": "+err.Error(),
-		)
+		diags.Append(apiErrorDiagnostics("Error Reading User", "Could not list users for email "+email, err)...)
 		return nil, diags
 	}
 
@@ -21,22 +21,24 @@ import (
 
 type (
 	userResource struct {
-		client *models.ClientWithResponses
+		client   *models.ClientWithResponses
+		readOnly bool
 	}
 	userResourceModel struct {
-		Id             types.String   `tfsdk:"id"`
-		Email          types.String   `tfsdk:"email"`
-		FirstName      types.String   `tfsdk:"first_name"`
-		LastName       types.String   `tfsdk:"last_name"`
-		JobTitle       types.String   `tfsdk:"job_title"`
-		RoleId         types.String   `tfsdk:"role_id"`
-		OrganizationId types.String   `tfsdk:"organization_id"`
-		Phone          types.String   `tfsdk:"phone"`
-		PhoneExtension types.String   `tfsdk:"phone_extension"`
-		Language       types.String   `tfsdk:"language"`
-		DisplayName    types.String   `tfsdk:"display_name"`
-		Status         types.String   `tfsdk:"status"`
-		Timeouts       timeouts.Value `tfsdk:"timeouts"`
+		Id              types.String   `tfsdk:"id"`
+		Email           types.String   `tfsdk:"email"`
+		FirstName       types.String   `tfsdk:"first_name"`
+		LastName        types.String   `tfsdk:"last_name"`
+		JobTitle        types.String   `tfsdk:"job_title"`
+		RoleId          types.String   `tfsdk:"role_id"`
+		OrganizationId  types.String   `tfsdk:"organization_id"`
+		Phone           types.String   `tfsdk:"phone"`
+		PhoneExtension  types.String   `tfsdk:"phone_extension"`
+		Language        types.String   `tfsdk:"language"`
+		DisplayName     types.String   `tfsdk:"display_name"`
+		Status          types.String   `tfsdk:"status"`
+		CustomerContext types.String   `tfsdk:"customer_context"`
+		Timeouts        timeouts.Value `tfsdk:"timeouts"`
 	}
 )
 
@@ -58,16 +60,17 @@ func (r *userResource) Configure(_ context.Context, req resource.ConfigureReques
 		return
 	}
 
-	client, ok := req.ProviderData.(*models.ClientWithResponses)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *models.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.readOnly = data.readOnly
 }
 
 func (r *userResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -75,6 +78,10 @@ func (r *userResource) Metadata(_ context.Context, req resource.MetadataRequest,
 }
 
 func (r *userResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, id, diags := importCustomerContext(ctx, req, resp, 1)
+	resp.Diagnostics.Append(diags...)
+	req.ID = id
+
 	// Import identifier is the email address (which is also the resource id).
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 	// Also set email = id so the Read path works correctly.
@@ -179,6 +186,7 @@ func (r *userResource) Schema(ctx context.Context, _ resource.SchemaRequest, res
 	}
 
 	// --- Add timeouts ---
+	s.Attributes["customer_context"] = customerContextResourceAttribute()
 	s.Attributes["timeouts"] = timeouts.Attributes(ctx, timeouts.Opts{
 		Create: true,
 		Read:   true,
@@ -190,6 +198,11 @@ func (r *userResource) Schema(ctx context.Context, _ resource.SchemaRequest, res
 }
 
 func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_user", "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan userResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -203,6 +216,7 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	ctx = withCustomerContext(ctx, plan.CustomerContext)
 	defer cancel()
 
 	// Build invite request from plan.
@@ -211,10 +225,7 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 	// Invite the user.
 	inviteResp, err := r.client.InviteUserWithResponse(ctx, apiReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Inviting User",
-			"Could not invite user, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Inviting User", "Could not invite user", err)...)
 		return
 	}
 
@@ -279,10 +290,7 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 		patchResp, err := r.client.UpdateUserWithResponse(ctx, *internalID, patchReq)
 		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error Updating User After Invite",
-				"Could not set phone/language after invite: "+err.Error(),
-			)
+			resp.Diagnostics.Append(apiErrorDiagnostics("Error Updating User After Invite", "Could not set phone/language after invite", err)...)
 			return
 		}
 
@@ -331,6 +339,7 @@ func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
 
 	diags = r.populateState(ctx, &state)
@@ -349,6 +358,11 @@ func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_user", "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan userResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -362,6 +376,7 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	ctx = withCustomerContext(ctx, plan.CustomerContext)
 	defer cancel()
 
 	// Resolve the current internal UUID from the email.
@@ -376,10 +391,7 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 	updateResp, err := r.client.UpdateUserWithResponse(ctx, internalID, patchReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Updating User",
-			"Could not update user, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Updating User", "Could not update user", err)...)
 		return
 	}
 
@@ -413,6 +425,11 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 }
 
 func (r *userResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(checkReadOnly(r.readOnly, "doit_user", "delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var state userResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -426,6 +443,7 @@ func (r *userResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	ctx = withCustomerContext(ctx, state.CustomerContext)
 	defer cancel()
 
 	// Resolve the current internal UUID from the email.
@@ -445,10 +463,7 @@ func (r *userResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	// Delete the user via API.
 	deleteResp, err := r.client.DeleteUserWithResponse(ctx, internalID)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Deleting User",
-			"Could not delete user, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics("Error Deleting User", "Could not delete user", err)...)
 		return
 	}
 
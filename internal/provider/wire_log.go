@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// HTTP wire logging. With DOIT_HTTP_DEBUG set, every request attempt and its
+// response are logged with headers and bodies to the "http" tflog subsystem,
+// so the JSON the provider actually sent can be compared with the API's
+// answer. Credentials, email addresses and webhook URLs are redacted before
+// anything is logged.
+
+const (
+	// wireLogSubsystem is the tflog subsystem wire logs are written to.
+	wireLogSubsystem = "http"
+
+	// defaultWireLogMaxBody is how many bytes of each body are logged unless
+	// DOIT_HTTP_DEBUG_MAX_BODY says otherwise.
+	defaultWireLogMaxBody = 16 * 1024
+
+	redacted = "[REDACTED]"
+)
+
+// wireLogRedactedHeaders are logged with their values replaced.
+var wireLogRedactedHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Proxy-Authorization",
+	"Set-Cookie",
+	"X-Api-Key",
+}
+
+var (
+	// credentialFieldPattern matches a JSON string member whose key names a
+	// credential. Pagination tokens such as pageToken are deliberately not
+	// matched: they grant nothing and are useful when debugging.
+	credentialFieldPattern = regexp.MustCompile(`(?i)("(?:(?:access|api|auth|id|refresh)_?)?(?:token|api_?key|secret|client_?secret|password|authorization)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+	// bearerPattern matches a bearer credential in free text.
+	bearerPattern = regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9._~+/=-]+`)
+
+	// webhookPattern matches webhook URLs such as Slack, Teams and Discord
+	// incoming webhooks, whose path is itself the credential.
+	webhookPattern = regexp.MustCompile(`(?i)https?://[^\s"'<>]*hook[^\s"'<>]*`)
+
+	// emailPattern matches email addresses, in JSON bodies as well as
+	// URL-encoded in query strings.
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+(?:@|%40)[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+)
+
+// wireLogger writes redacted request and response dumps.
+type wireLogger struct {
+	maxBody int
+}
+
+// wireLoggerFromEnv returns a wireLogger when DOIT_HTTP_DEBUG is true, or nil.
+func wireLoggerFromEnv() (*wireLogger, error) {
+	v := os.Getenv("DOIT_HTTP_DEBUG")
+	if v == "" {
+		return nil, nil
+	}
+	enabled, err := strconv.ParseBool(v)
+	if err != nil {
+		return nil, fmt.Errorf("DOIT_HTTP_DEBUG must be true or false, got %q", v)
+	}
+	if !enabled {
+		return nil, nil
+	}
+
+	maxBody := defaultWireLogMaxBody
+	if v := os.Getenv("DOIT_HTTP_DEBUG_MAX_BODY"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("DOIT_HTTP_DEBUG_MAX_BODY must be a positive number of bytes, got %q", v)
+		}
+		maxBody = n
+	}
+	return &wireLogger{maxBody: maxBody}, nil
+}
+
+// subsystem returns ctx with the wire log subsystem. Wire logs are written at
+// debug level, so TF_LOG=DEBUG (or TF_LOG_PROVIDER=DEBUG) shows them.
+func (w *wireLogger) subsystem(ctx context.Context) context.Context {
+	return tflog.NewSubsystem(ctx, wireLogSubsystem, tflog.WithLevel(hclog.Debug))
+}
+
+// logRequest logs one attempt of req. body is the request body, which the
+// retry loop keeps outside req.
+func (w *wireLogger) logRequest(ctx context.Context, req *http.Request, body []byte, attempt int) {
+	ctx = w.subsystem(ctx)
+	tflog.SubsystemDebug(ctx, wireLogSubsystem, "HTTP request", map[string]any{
+		"attempt": attempt,
+		"method":  req.Method,
+		"url":     redactText(req.URL.String()),
+		"headers": redactHeaders(req.Header),
+		"body":    w.formatBody(body),
+	})
+}
+
+// logResponse logs resp and replaces its body with an unread copy.
+func (w *wireLogger) logResponse(ctx context.Context, req *http.Request, resp *http.Response) {
+	body, err := io.ReadAll(resp.Body)
+	if closeErr := resp.Body.Close(); err == nil {
+		err = closeErr
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	fields := map[string]any{
+		"method":  req.Method,
+		"url":     redactText(req.URL.String()),
+		"status":  resp.StatusCode,
+		"headers": redactHeaders(resp.Header),
+		"body":    w.formatBody(body),
+	}
+	if err != nil {
+		fields["body_error"] = err.Error()
+	}
+	tflog.SubsystemDebug(w.subsystem(ctx), wireLogSubsystem, "HTTP response", fields)
+}
+
+// formatBody redacts body and truncates it to maxBody bytes.
+func (w *wireLogger) formatBody(body []byte) string {
+	s := redactText(string(body))
+	if len(s) <= w.maxBody {
+		return s
+	}
+	return strings.ToValidUTF8(s[:w.maxBody], "") + fmt.Sprintf("... (truncated, %d bytes total)", len(s))
+}
+
+// redactHeaders flattens h for logging, hiding credential headers.
+func redactHeaders(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for name, values := range h {
+		if slices.Contains(wireLogRedactedHeaders, http.CanonicalHeaderKey(name)) {
+			out[name] = redacted
+			continue
+		}
+		out[name] = redactText(strings.Join(values, ", "))
+	}
+	return out
+}
+
+// redactText hides credentials, webhook URLs and email addresses in s. The
+// text is otherwise unchanged, so a JSON body keeps the key order and
+// formatting it was sent with.
+func redactText(s string) string {
+	s = credentialFieldPattern.ReplaceAllString(s, `$1"`+redacted+`"`)
+	s = bearerPattern.ReplaceAllString(s, "Bearer "+redacted)
+	s = webhookPattern.ReplaceAllString(s, redacted)
+	return emailPattern.ReplaceAllString(s, redacted)
+}
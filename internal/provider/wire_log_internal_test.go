@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestRedactText(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		`{"name":"Monthly","pageToken":"abc"}`:                            `{"name":"Monthly","pageToken":"abc"}`,
+		`{"token":"s3cret","apiKey": "k"}`:                                `{"token":"[REDACTED]","apiKey": "[REDACTED]"}`,
+		`{"access_token":"a\"b","client_secret":"c"}`:                     `{"access_token":"[REDACTED]","client_secret":"[REDACTED]"}`,
+		`{"recipients":["jane@example.com","ops@corp.example.io"]}`:       `{"recipients":["[REDACTED]","[REDACTED]"]}`,
+		`{"url":"https://hooks.slack.com/services/T0/B0/xyz","id":"1"}`:   `{"url":"[REDACTED]","id":"1"}`,
+		`{"url":"https://console.doit.com/reports/1"}`:                    `{"url":"https://console.doit.com/reports/1"}`,
+		"https://api.doit.com/iam/v1/users?email=jane%40example.com":      "https://api.doit.com/iam/v1/users?email=[REDACTED]",
+		"invalid credentials: Bearer eyJhbGciOi.payload.sig for the user": "invalid credentials: Bearer [REDACTED] for the user",
+	}
+	for in, want := range tests {
+		if got := redactText(in); got != want {
+			t.Errorf("redactText(%q)\n got %q\nwant %q", in, got, want)
+		}
+	}
+}
+
+func TestRedactHeaders(t *testing.T) {
+	t.Parallel()
+
+	got := redactHeaders(http.Header{
+		"Authorization": {"Bearer s3cret"},
+		"Content-Type":  {"application/json"},
+		"Set-Cookie":    {"session=abc"},
+	})
+	want := map[string]string{
+		"Authorization": redacted,
+		"Content-Type":  "application/json",
+		"Set-Cookie":    redacted,
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("header %s = %q, want %q", k, got[k], v)
+		}
+	}
+}
+
+func TestWireLogger_FormatBodyTruncates(t *testing.T) {
+	t.Parallel()
+
+	w := &wireLogger{maxBody: 8}
+	if got := w.formatBody([]byte("short")); got != "short" {
+		t.Errorf("formatBody(short) = %q", got)
+	}
+	got := w.formatBody([]byte(`{"name":"a long report name"}`))
+	if want := `{"name":... (truncated, 29 bytes total)`; got != want {
+		t.Errorf("formatBody(long) = %q, want %q", got, want)
+	}
+	// A cut through a multi-byte character drops the partial rune.
+	if got := (&wireLogger{maxBody: 2}).formatBody([]byte("aé b")); !strings.HasPrefix(got, "a...") {
+		t.Errorf("formatBody(multibyte) = %q, want the partial rune dropped", got)
+	}
+}
+
+func TestWireLoggerFromEnv(t *testing.T) {
+	tests := []struct {
+		name        string
+		debug       string
+		maxBody     string
+		wantMaxBody int
+		wantNil     bool
+		wantErr     bool
+	}{
+		{name: "unset", wantNil: true},
+		{name: "disabled", debug: "false", wantNil: true},
+		{name: "default size", debug: "1", wantMaxBody: defaultWireLogMaxBody},
+		{name: "custom size", debug: "true", maxBody: "512", wantMaxBody: 512},
+		{name: "invalid flag", debug: "verbose", wantErr: true},
+		{name: "invalid size", debug: "true", maxBody: "0", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("DOIT_HTTP_DEBUG", tt.debug)
+			t.Setenv("DOIT_HTTP_DEBUG_MAX_BODY", tt.maxBody)
+
+			w, err := wireLoggerFromEnv()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("wireLoggerFromEnv() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if (w == nil) != tt.wantNil {
+				t.Fatalf("wireLoggerFromEnv() = %v, wantNil %v", w, tt.wantNil)
+			}
+			if w != nil && w.maxBody != tt.wantMaxBody {
+				t.Errorf("maxBody = %d, want %d", w.maxBody, tt.wantMaxBody)
+			}
+		})
+	}
+}
+
+func TestDCIRetryClient_WireLogging(t *testing.T) {
+	t.Parallel()
+
+	const respBody = `{"error":"invalid metric","owner":"jane@example.com"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = io.WriteString(w, respBody)
+	}))
+	t.Cleanup(server.Close)
+
+	c := newTestRetryClient(5*time.Second, constantBackOff(time.Millisecond))
+	c.wireLog = &wireLogger{maxBody: defaultWireLogMaxBody}
+
+	var logs bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &logs)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/analytics/v1/reports",
+		strings.NewReader(`{"name":"Monthly","recipients":["jane@example.com"]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer s3cret-token")
+
+	// The 400 still fails the call, with the API's message intact.
+	if _, err := c.Do(req); err == nil || !strings.Contains(err.Error(), "invalid metric") {
+		t.Fatalf("Do() error = %v, want the 400 response body", err)
+	}
+
+	output := logs.String()
+	entries, err := tflogtest.MultilineJSONDecode(&logs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bodies := map[string]any{}
+	for _, e := range entries {
+		if e["@module"] == "provider."+wireLogSubsystem {
+			bodies[e["@message"].(string)] = e["body"]
+		}
+	}
+	if want := `{"name":"Monthly","recipients":["[REDACTED]"]}`; bodies["HTTP request"] != want {
+		t.Errorf("logged request body = %v, want %s", bodies["HTTP request"], want)
+	}
+	if want := `{"error":"invalid metric","owner":"[REDACTED]"}`; bodies["HTTP response"] != want {
+		t.Errorf("logged response body = %v, want %s", bodies["HTTP response"], want)
+	}
+	for _, secret := range []string{"s3cret-token", "jane@example.com"} {
+		if strings.Contains(output, secret) {
+			t.Errorf("logs contain %q", secret)
+		}
+	}
+}
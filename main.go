@@ -7,10 +7,12 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"log"
+	"os"
 
 	"github.com/doitintl/terraform-provider-doit/internal/provider"
-	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
 )
 
 // Run "go generate" to format example terraform files and generate the docs for the registry/website
@@ -33,22 +35,74 @@ var (
 )
 
 func main() {
+	// Terraform starts the provider with no arguments, so a leading
+	// subcommand can only come from a user running the binary directly.
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		os.Exit(runExport(os.Args[2:]))
+	}
+
 	var debug bool
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
-	opts := providerserver.ServeOpts{
-		// NOTE: This is not a typical Terraform Registry provider address,
-		// such as registry.terraform.io/hashicorp/hashicups. This specific
-		// provider address is used in these tutorials in conjunction with a
-		// specific Terraform CLI configuration for manual development testing
-		// of this provider.
+	var opts []tf6server.ServeOpt
+	if debug {
+		opts = append(opts, tf6server.WithManagedDebug())
+	}
+
+	// The server is served directly rather than through providerserver.Serve
+	// so operations can be traced; see provider.NewServer.
+	server, shutdownTelemetry := provider.NewServer(version)
+
+	// NOTE: This is not a typical Terraform Registry provider address,
+	// such as registry.terraform.io/hashicorp/hashicups. This specific
+	// provider address is used in these tutorials in conjunction with a
+	// specific Terraform CLI configuration for manual development testing
+	// of this provider.
+	err := tf6server.Serve("registry.terraform.io/doitintl/doit", server, opts...)
 
-		Address: "registry.terraform.io/doitintl/doit",
-		Debug:   debug,
+	// Serve returns once Terraform stops the provider; export any buffered
+	// spans before exiting.
+	if shutdownErr := shutdownTelemetry(context.Background()); shutdownErr != nil {
+		log.Printf("[WARN] Error exporting traces: %v", shutdownErr)
 	}
-	err := providerserver.Serve(context.Background(), provider.New(version), opts)
 	if err != nil {
 		log.Fatal(err.Error())
 	}
 }
+
+// runExport implements `terraform-provider-doit export`, which writes the
+// tenant's folders, labels, allocations, reports, budgets and alerts as
+// Terraform configuration with matching import blocks. Credentials come from
+// the same environment variables the provider reads.
+func runExport(args []string) int {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	dir := fs.String("dir", ".", "directory to write the generated .tf files to")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	ts, err := provider.EnvAPITokenSource()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	host := os.Getenv("DOIT_HOST")
+	if host == "" {
+		host = provider.HostURL
+	}
+
+	ctx := context.Background()
+	client, err := provider.NewClient(ctx, host, "", os.Getenv("DOIT_CUSTOMER_CONTEXT"), "export", version, provider.DefaultRequestTimeout,
+		provider.WithTokenSource(ts))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to create DoiT API client: %v\n", err)
+		return 1
+	}
+
+	if err := provider.Export(ctx, client, *dir); err != nil {
+		fmt.Fprintf(os.Stderr, "Export finished with errors:\n%v\n", err)
+		return 1
+	}
+	return 0
+}